@@ -0,0 +1,156 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    cd $BATS_TMPDIR
+    cd dolt-repo-$$
+    mkdir "dolt-repo-clones"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+@test "backup: add, list and remove a backup" {
+    mkdir bac1
+    dolt backup add bac1 file://./bac1
+    run dolt backup -v
+    [ "$status" -eq 0 ]
+    regex='bac1 file://.*/bac1'
+    [[ "$output" =~ $regex ]] || false
+
+    run dolt backup add bac1 file://./bac1
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "A backup named 'bac1' already exists" ]] || false
+
+    # backups are not remotes
+    run dolt remote
+    [ "$status" -eq 0 ]
+    [ "$output" = "" ]
+
+    dolt backup rm bac1
+    run dolt backup
+    [ "$status" -eq 0 ]
+    [ "$output" = "" ]
+}
+
+@test "backup: only file, aws and gs urls are backups" {
+    run dolt backup add bac1 http://localhost:50051/test-org/test-repo
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "backups must be aws, gs or file urls" ]] || false
+}
+
+@test "backup: sync to an unknown backup" {
+    run dolt backup sync bac1
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "unknown backup bac1" ]] || false
+}
+
+@test "backup: sync and restore a repository with its working set, refs and remotes" {
+    mkdir bac1 remote
+    dolt remote add origin file://./remote
+    dolt backup add bac1 file://./bac1
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY, c1 int)"
+    dolt sql -q "INSERT INTO test VALUES (1, 1)"
+    dolt commit -am "added test"
+    dolt tag v1
+    dolt checkout -b other
+    dolt sql -q "INSERT INTO test VALUES (2, 2)"
+    dolt commit -am "added a row on other"
+    dolt sql -q "CREATE TABLE staged (pk int PRIMARY KEY)"
+    dolt add staged
+    dolt sql -q "INSERT INTO test VALUES (3, 3)"
+
+    dolt backup sync bac1
+
+    cd dolt-repo-clones
+    dolt backup restore file://../bac1 restored
+    cd restored
+
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "master" ]] || false
+    [[ "$output" =~ "* other" ]] || false
+
+    run dolt tag
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "v1" ]] || false
+
+    run dolt remote
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "origin" ]] || false
+
+    run dolt backup
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "bac1" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "new table:      staged" ]] || false
+    [[ "$output" =~ "modified:       test" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "added a row on other" ]] || false
+
+    # the repo state of the backup is not restored as a ref
+    run dolt sql -q "SELECT * FROM dolt_branches" -r csv
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "backup" ]] || false
+}
+
+@test "backup: sync only uploads table files the backup does not have" {
+    mkdir bac1
+    dolt backup add bac1 file://./bac1
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY)"
+    dolt commit -am "added test"
+    dolt backup sync bac1
+
+    before=$(ls bac1 | sort)
+
+    dolt sql -q "INSERT INTO test VALUES (1)"
+    dolt commit -am "added a row"
+    dolt backup sync bac1
+
+    # every table file already in the backup is left alone
+    for f in $before; do
+        [ "$f" = "manifest" ] || [ "$f" = "LOCK" ] || [ -f "bac1/$f" ]
+    done
+
+    cd dolt-repo-clones
+    dolt backup restore file://../bac1 restored
+    cd restored
+    run dolt sql -q "SELECT count(*) FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+}
+
+@test "backup: restore into an existing repository fails" {
+    mkdir bac1
+    dolt backup add bac1 file://./bac1
+    dolt backup sync bac1
+
+    mkdir -p dolt-repo-clones/existing/.dolt
+    cd dolt-repo-clones
+    run dolt backup restore file://../bac1 existing
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "data repository already exists" ]] || false
+}
+
+@test "backup: restore a database which is not a backup" {
+    mkdir remote
+    dolt remote add origin file://./remote
+    dolt push origin master
+
+    cd dolt-repo-clones
+    run dolt backup restore file://../remote restored
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "not a dolt backup" ]] || false
+    [ ! -d restored ]
+}
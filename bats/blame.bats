@@ -136,3 +136,73 @@ SQL
     [ "$status" -eq 1 ]
     [[ "$output" =~ "no table named blame_test found" ]] || false
 }
+
+@test "blame: dolt_blame_ system table annotates each row" {
+    run dolt sql -r csv -q "SELECT pk, email, message FROM dolt_blame_blame_test ORDER BY pk"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "pk,email,message" ]] || false
+    [[ "$output" =~ "1,bats-1@email.fake,create blame_test table" ]] || false
+    [[ "$output" =~ "2,bats-3@email.fake,replace richard with harry" ]] || false
+    [[ "$output" =~ "3,bats-4@email.fake,add more people to blame_test" ]] || false
+    [[ "$output" =~ "4,bats-4@email.fake,add more people to blame_test" ]] || false
+    [[ ! "$output" =~ "bats-2@email.fake" ]] || false
+
+    run dolt sql -r csv -q "SELECT committer FROM dolt_blame_blame_test WHERE pk = 1"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Thomas Foolery" ]] || false
+}
+
+@test "blame: dolt_blame_ system table can be joined with the table" {
+    run dolt sql -r csv -q "SELECT t.name, b.email FROM blame_test t JOIN dolt_blame_blame_test b ON t.pk = b.pk WHERE b.commit_hash = (SELECT commit_hash FROM dolt_log LIMIT 1) ORDER BY t.pk"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "name,email" ]] || false
+    [[ "$output" =~ "Alan,bats-4@email.fake" ]] || false
+    [[ "$output" =~ "Betty,bats-4@email.fake" ]] || false
+    [ "${#lines[@]}" -eq 3 ]
+}
+
+@test "blame: dolt_blame_ system table blames every row on a schema change" {
+    dolt sql -q "ALTER TABLE blame_test ADD COLUMN age INT"
+    dolt add blame_test
+    dolt commit -m "add age column"
+
+    run dolt sql -r csv -q "SELECT DISTINCT message FROM dolt_blame_blame_test"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "add age column" ]] || false
+    [ "${#lines[@]}" -eq 2 ]
+}
+
+@test "blame: dolt_blame_ system table is not supported for keyless tables" {
+    dolt sql -q "CREATE TABLE keyless (c0 INT, c1 INT)"
+    dolt add keyless
+    dolt commit -m "add keyless table"
+
+    run dolt sql -q "SELECT * FROM dolt_blame_keyless"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "blame is not supported for keyless tables" ]] || false
+}
+
+@test "blame: dolt_blame_ system table rejects a primary key column named like a blame column" {
+    dolt sql -q "CREATE TABLE notes (message VARCHAR(20) PRIMARY KEY, c0 INT)"
+    dolt add notes
+    dolt commit -m "add notes table"
+
+    run dolt sql -q "SELECT * FROM dolt_blame_notes"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "primary key column has the same name as a blame column: 'message'" ]] || false
+}
+
+@test "blame: --columns annotates each column of each row" {
+    dolt sql -q "ALTER TABLE blame_test ADD COLUMN age INT"
+    dolt add blame_test
+    dolt commit -m "add age column"
+
+    run dolt blame --columns blame_test
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "COLUMN" ]] || false
+    [[ "$output" =~ "| 1  | name   | create blame_test table" ]] || false
+    [[ "$output" =~ "| 2  | pk     | add richard to blame_test" ]] || false
+    [[ "$output" =~ "| 2  | name   | replace richard with harry" ]] || false
+    [[ "$output" =~ "| 3  | age    | add age column" ]] || false
+    [[ ! "$output" =~ "| 1  | name   | add age column" ]] || false
+}
@@ -468,7 +468,6 @@ CREATE TABLE test (
   PRIMARY KEY (pk)
 );
 SQL
-    skip "This currently is a failed merge. I think it should be a conflict that you can resolve by modifying the schema. Basically choose a column name for the tag. The data is the same."
     dolt add test
     dolt commit -m "table created"
     dolt branch rename-column
@@ -481,10 +480,8 @@ SQL
     dolt commit -m "renamed c5 to c6"
     dolt checkout master
     run dolt merge rename-column
-    [ $status -eq 1 ]
-    [[ "$output" =~ "Bad merge" ]] || false
     [ $status -eq 0 ]
-    [[ "$output" =~ "CONFLICT" ]] || false
+    [[ "$output" =~ "CONFLICT (schema)" ]] || false
 }
 
 @test "conflict-detection: two branches rename different column to same name. merge. conflict" {
@@ -852,7 +849,6 @@ SQL
     dolt commit -m "dropped table bar"
 
     dolt checkout master
-    skip "test currently panics on merge at doltcore/env/actions/merge.go:79"
     run dolt merge other
     [ "$status" -eq 0 ]
     [[ "$output" =~ "Updating" ]] || false
@@ -883,7 +879,6 @@ SQL
     dolt commit -m "renamed table bar to barbar"
 
     dolt checkout master
-    skip "test currently panics on merge at doltcore/env/actions/merge.go:79"
     run dolt merge other
     [ "$status" -eq 0 ]
     [[ "$output" =~ "Updating" ]] || false
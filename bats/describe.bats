@@ -0,0 +1,126 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE t (pk int PRIMARY KEY);"
+    dolt add -A && dolt commit -m "created table t"
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+get_head_commit() {
+    dolt log -n 1 | grep -m 1 commit | cut -c 8-
+}
+
+@test "describe: tagged commit" {
+    dolt tag -m "first release" v1
+    run dolt describe
+    [ "$status" -eq 0 ]
+    [ "$output" = "v1" ]
+
+    head=`get_head_commit`
+    run dolt describe --long
+    [ "$status" -eq 0 ]
+    [ "$output" = "v1-0-g$head" ]
+}
+
+@test "describe: commits after the nearest tag" {
+    dolt tag -m "first release" v1
+    dolt sql -q "INSERT INTO t VALUES (1);"
+    dolt commit -am "added 1"
+    dolt tag -m "second release" v2
+    dolt sql -q "INSERT INTO t VALUES (2);"
+    dolt commit -am "added 2"
+    dolt sql -q "INSERT INTO t VALUES (3);"
+    dolt commit -am "added 3"
+    head=`get_head_commit`
+
+    run dolt describe
+    [ "$status" -eq 0 ]
+    [ "$output" = "v2-2-g$head" ]
+
+    run dolt describe HEAD~2
+    [ "$status" -eq 0 ]
+    [ "$output" = "v2" ]
+
+    run dolt describe HEAD~3
+    [ "$status" -eq 0 ]
+    [ "$output" = "v1" ]
+}
+
+@test "describe: lightweight tags require --tags" {
+    dolt tag -m "first release" v1
+    dolt sql -q "INSERT INTO t VALUES (1);"
+    dolt commit -am "added 1"
+    dolt tag lightweight
+    head=`get_head_commit`
+
+    run dolt describe
+    [ "$status" -eq 0 ]
+    [ "$output" = "v1-1-g$head" ]
+
+    run dolt describe --tags
+    [ "$status" -eq 0 ]
+    [ "$output" = "lightweight" ]
+}
+
+@test "describe: no tags" {
+    run dolt describe
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "no tags can describe this commit" ]] || false
+
+    dolt tag lightweight
+    run dolt describe
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "Try --tags" ]] || false
+}
+
+@test "describe: --dirty" {
+    dolt tag -m "first release" v1
+    run dolt describe --dirty
+    [ "$status" -eq 0 ]
+    [ "$output" = "v1" ]
+
+    dolt sql -q "INSERT INTO t VALUES (1);"
+    run dolt describe --dirty
+    [ "$status" -eq 0 ]
+    [ "$output" = "v1-dirty" ]
+
+    dolt add t
+    run dolt describe --dirty
+    [ "$status" -eq 0 ]
+    [ "$output" = "v1-dirty" ]
+
+    run dolt describe
+    [ "$status" -eq 0 ]
+    [ "$output" = "v1" ]
+
+    run dolt describe --dirty HEAD
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "--dirty can only be used when describing HEAD" ]] || false
+}
+
+@test "describe: sql function" {
+    dolt tag -m "first release" v1
+    dolt sql -q "INSERT INTO t VALUES (1);"
+    dolt commit -am "added 1"
+    dolt tag lightweight
+    head=`get_head_commit`
+
+    run dolt sql -q "SELECT DOLT_DESCRIBE(), DOLT_DESCRIBE('HEAD~1'), DOLT_DESCRIBE('--tags')" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "v1-1-g$head,v1,lightweight" ]
+
+    run dolt sql -q "SELECT DOLT_DESCRIBE('--long', '--tags')" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "lightweight-0-g$head" ]
+
+    dolt sql -q "INSERT INTO t VALUES (2);"
+    run dolt sql -q "SELECT DOLT_DESCRIBE('--dirty', '--tags')" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "lightweight-dirty" ]
+}
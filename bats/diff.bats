@@ -460,3 +460,105 @@ SQL
     [ $status -eq 0 ]
     [[ $output =~ "CONSTRAINT \`fk_named\` FOREIGN KEY (\`cv1\`) REFERENCES \`parent\` (\`pv1\`)" ]] || false
 }
+
+@test "diff: json output" {
+    dolt add .
+    dolt commit -m table
+    dolt sql -q 'insert into test values (0,0,0,0,0,0), (1,1,1,1,1,1)'
+    dolt add .
+    dolt commit -m rows
+    dolt sql -q 'alter table test add column c6 bigint'
+    dolt sql -q 'update test set c1 = 10, c6 = 6 where pk = 0'
+    dolt sql -q 'delete from test where pk = 1'
+    dolt sql -q 'insert into test (pk, c1) values (2, 2)'
+    run dolt diff -r json
+    [ $status -eq 0 ]
+    [[ "$output" =~ '{"tables":[{"name":"test","from_name":"test","to_name":"test","diff_type":"modified","schema_diff":{"columns":[{"diff_type":"added","to":{"name":"c6"' ]] || false
+    [[ "$output" =~ '{"diff_type":"modified","from":{"c1":0,"c2":0,"c3":0,"c4":0,"c5":0,"pk":0},"to":{"c1":10,"c2":0,"c3":0,"c4":0,"c5":0,"c6":6,"pk":0}}' ]] || false
+    [[ "$output" =~ '{"diff_type":"removed","from":{"c1":1,"c2":1,"c3":1,"c4":1,"c5":1,"pk":1}}' ]] || false
+    [[ "$output" =~ '{"diff_type":"added","to":{"c1":2,"c2":null,"c3":null,"c4":null,"c5":null,"c6":null,"pk":2}}' ]] || false
+    run dolt diff -r json --where to_pk=0
+    [ $status -eq 0 ]
+    [[ "$output" =~ '"diff_type":"modified"' ]] || false
+    [[ ! "$output" =~ '"diff_type":"removed"' ]] || false
+    run dolt diff -r json --summary
+    [ $status -eq 1 ]
+}
+
+@test "diff: csv output" {
+    dolt add .
+    dolt commit -m table
+    dolt sql -q 'insert into test values (0,0,0,0,0,0), (1,1,1,1,1,1)'
+    dolt add .
+    dolt commit -m rows
+    dolt sql -q 'update test set c1 = 10 where pk = 0'
+    dolt sql -q 'delete from test where pk = 1'
+    dolt sql -q 'insert into test (pk, c1) values (2, 2)'
+    run dolt diff -r csv
+    [ $status -eq 0 ]
+    [ "${lines[0]}" = "diff_type,from_pk,to_pk,from_c1,to_c1,from_c2,to_c2,from_c3,to_c3,from_c4,to_c4,from_c5,to_c5" ]
+    [ "${lines[1]}" = "modified,0,0,0,10,0,0,0,0,0,0,0,0" ]
+    [ "${lines[2]}" = "removed,1,,1,,1,,1,,1,,1," ]
+    [ "${lines[3]}" = "added,,2,,2,,,,,,,," ]
+    run dolt diff -r csv --limit 1
+    [ $status -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+}
+
+@test "diff: inline highlighting" {
+    dolt sql -q 'create table words (pk int primary key, txt varchar(100))'
+    dolt sql -q "insert into words values (1, 'the quick brown fox')"
+    dolt add .
+    dolt commit -m words
+    dolt sql -q "update words set txt = 'the slow brown fox' where pk = 1"
+    run dolt diff --inline words
+    [ $status -eq 0 ]
+    [[ "$output" =~ "the quick brown fox" ]] || false
+    [[ "$output" =~ "the slow brown fox" ]] || false
+    run dolt diff --inline -r sql words
+    [ $status -eq 1 ]
+    [[ "$output" =~ "--inline can only be used with tabular output" ]] || false
+}
+
+@test "diff: columns are aligned across renames and type changes" {
+    dolt sql -q 'create table t (pk int primary key, a int, b varchar(20), c int)'
+    dolt sql -q "insert into t values (1, 1, 'x', 5), (2, 2, 'y', 6)"
+    dolt add .
+    dolt commit -m "create t"
+    dolt sql -q 'alter table t rename column a to aa'
+    dolt sql -q 'alter table t modify column c varchar(10)'
+    dolt sql -q "update t set b = 'z' where pk = 2"
+    dolt add .
+    dolt commit -m "rename a, change type of c"
+
+    run dolt diff -r csv HEAD~1 HEAD
+    [ $status -eq 0 ]
+    [ "${lines[0]}" = "diff_type,from_pk,to_pk,from_a,to_aa,from_b,to_b,from_c,to_c" ]
+    [ "${lines[1]}" = "modified,2,2,2,2,y,z,6,6" ]
+    [ "${#lines[@]}" -eq 2 ]
+
+    run dolt diff HEAD~1 HEAD
+    [ $status -eq 0 ]
+    [[ "$output" =~ "|  <  | pk | a  | b | c |" ]] || false
+    [[ "$output" =~ "|  >  | pk | aa | b | c |" ]] || false
+    [[ ! "$output" =~ "| 1  |" ]] || false
+}
+
+@test "diff: columns whose values change when converted to their new type are not aligned" {
+    dolt sql -q 'create table t (pk int primary key, c float, d varchar(10))'
+    dolt sql -q "insert into t values (1, 1, '1'), (2, 2.5, '007')"
+    dolt add .
+    dolt commit -m "create t"
+    dolt sql -q 'alter table t modify column c int'
+    dolt sql -q 'alter table t modify column d int'
+    dolt commit -am "change types of c and d"
+
+    run dolt diff -r csv HEAD~1 HEAD
+    [ $status -eq 0 ]
+    [[ "$output" =~ "modified,2,2,2.5,,007,,,2,,7" ]] || false
+
+    run dolt diff HEAD~1 HEAD
+    [ $status -eq 0 ]
+    [[ "$output" =~ "2.5" ]] || false
+    [[ "$output" =~ "007" ]] || false
+}
@@ -782,13 +782,17 @@ SQL
     run cat README.md
     [[ "$output" =~ "test-a branch" ]] || false
 
-    # A merge with conflicts does not change the working root.
-    # If the conflicts are resolved with --ours, the working root and the docs on the filesystem remain the same.
+    # A merge with conflicts writes both versions of the conflicting lines to the docs, between conflict markers.
+    # If the conflicts are resolved with --ours, the docs on the filesystem are our version.
     run dolt merge test-b
     [ "$status" -eq 0 ]
     [[ $output =~ "CONFLICT" ]] || false
     run cat README.md
-    [[ "$output" =~ "test-a branch" ]] || false
+    [ "${lines[0]}" = "<<<<<<< ours" ]
+    [ "${lines[1]}" = "test-a branch" ]
+    [ "${lines[2]}" = "=======" ]
+    [ "${lines[3]}" = "test-b branch" ]
+    [ "${lines[4]}" = ">>>>>>> theirs" ]
     run dolt conflicts cat dolt_docs
     [ "$status" -eq 0 ]
     [[ $output =~ "test-a branch" ]] || false
@@ -837,3 +841,86 @@ SQL
     [[ "$output" =~ "Changes to be committed:" ]] || false
     [[ "$output" =~ "README.md" ]] || false
 }
+
+@test "docs: merge docs changed on both branches line by line" {
+    printf 'line one\nline two\nline three\n' > README.md
+    dolt add .
+    dolt commit -m "Committing initial docs"
+    dolt branch other
+    printf 'line one changed on master\nline two\nline three\n' > README.md
+    dolt add .
+    dolt commit -m "Changed the first line on master"
+    dolt checkout other
+    printf 'line one\nline two\nline three changed on other\n' > README.md
+    dolt add .
+    dolt commit -m "Changed the last line on other"
+    dolt checkout master
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "CONFLICT" ]] || false
+    run cat README.md
+    [ "${lines[0]}" = "line one changed on master" ]
+    [ "${lines[1]}" = "line two" ]
+    [ "${lines[2]}" = "line three changed on other" ]
+    [ "${#lines[@]}" -eq 3 ]
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "All conflicts fixed" ]] || false
+    [[ "$output" =~ "README.md" ]] || false
+}
+
+@test "docs: overlapping changes to docs are conflicts" {
+    printf 'line one\nline two\nline three\n' > README.md
+    dolt add .
+    dolt commit -m "Committing initial docs"
+    dolt branch other
+    printf 'line one ours\nline two\nline three\n' > README.md
+    dolt add .
+    dolt commit -m "Changed the first line on master"
+    dolt checkout other
+    printf 'line one theirs\nline two\nline three theirs\n' > README.md
+    dolt add .
+    dolt commit -m "Changed the first and last lines on other"
+    dolt checkout master
+
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "CONFLICT (content): Merge conflict in dolt_docs" ]] || false
+    run cat README.md
+    [ "${lines[0]}" = "<<<<<<< ours" ]
+    [ "${lines[1]}" = "line one ours" ]
+    [ "${lines[2]}" = "=======" ]
+    [ "${lines[3]}" = "line one theirs" ]
+    [ "${lines[4]}" = ">>>>>>> theirs" ]
+    [ "${lines[5]}" = "line two" ]
+    [ "${lines[6]}" = "line three theirs" ]
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "You have unmerged tables" ]] || false
+    [[ "$output" =~ "both modified:  README.md" ]] || false
+    [[ ! "$output" =~ "both modified:  dolt_docs" ]] || false
+
+    run dolt conflicts cat dolt_docs
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "doc: README.md" ]] || false
+    [[ "$output" =~ "<<<<<<< ours" ]] || false
+    [[ "$output" =~ "line one theirs" ]] || false
+
+    run dolt commit -am "can't commit conflicts"
+    [ "$status" -ne 0 ]
+
+    printf 'line one merged\nline two\nline three theirs\n' > README.md
+    dolt add README.md
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "All conflicts fixed" ]] || false
+    dolt commit -m "merged docs"
+
+    run dolt sql -r csv -q "SELECT doc_text FROM dolt_docs WHERE doc_name = 'README.md'"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "line one merged" ]] || false
+    [[ ! "$output" =~ "<<<<<<<" ]] || false
+}
@@ -0,0 +1,147 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE t (pk int PRIMARY KEY);"
+    dolt add -A && dolt commit -m "created table t"
+    dolt branch feature
+}
+
+teardown() {
+    assert_feature_version
+    teardown_common
+}
+
+get_head_commit() {
+    dolt log -n 1 | grep -m 1 commit | cut -c 8-
+}
+
+@test "merge-base: finds the merge base of two branches" {
+    base=`get_head_commit`
+    dolt sql -q "INSERT INTO t VALUES (1);"
+    dolt commit -am "added 1 on master"
+    dolt checkout feature
+    dolt sql -q "INSERT INTO t VALUES (2);"
+    dolt commit -am "added 2 on feature"
+
+    run dolt merge-base master feature
+    [ "$status" -eq 0 ]
+    [ "$output" = "$base" ]
+
+    run dolt merge-base master master~1
+    [ "$status" -eq 0 ]
+    [ "$output" = "$base" ]
+}
+
+@test "merge-base: --is-ancestor" {
+    dolt sql -q "INSERT INTO t VALUES (1);"
+    dolt commit -am "added 1 on master"
+
+    run dolt merge-base --is-ancestor feature master
+    [ "$status" -eq 0 ]
+    [ "$output" = "" ]
+
+    run dolt merge-base --is-ancestor master master
+    [ "$status" -eq 0 ]
+
+    run dolt merge-base --is-ancestor master feature
+    [ "$status" -eq 1 ]
+    [ "$output" = "" ]
+
+    run dolt merge-base --is-ancestor master doesnotexist
+    [ "$status" -eq 128 ]
+    [[ "$output" =~ "doesnotexist" ]] || false
+
+    run dolt merge-base --is-ancestor --all master feature
+    [ "$status" -eq 128 ]
+}
+
+@test "merge-base: --all and --octopus with criss-cross merges" {
+    base=`get_head_commit`
+    dolt sql -q "INSERT INTO t VALUES (1);"
+    dolt commit -am "added 1 on master"
+    master1=`get_head_commit`
+    dolt checkout feature
+    dolt sql -q "INSERT INTO t VALUES (2);"
+    dolt commit -am "added 2 on feature"
+    feature1=`get_head_commit`
+    dolt checkout -b other master
+    dolt sql -q "INSERT INTO t VALUES (3);"
+    dolt commit -am "added 3 on other"
+
+    # merge each of master and feature into the other
+    dolt checkout master
+    dolt merge feature
+    dolt commit -m "merged feature"
+    dolt checkout feature
+    dolt merge $master1
+    dolt commit -m "merged master"
+
+    run dolt merge-base --all master feature
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "$output" =~ "$master1" ]] || false
+    [[ "$output" =~ "$feature1" ]] || false
+
+    run dolt merge-base master feature
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "$output" = "$master1" ]] || [[ "$output" = "$feature1" ]] || false
+
+    run dolt merge-base --octopus master feature other
+    [ "$status" -eq 0 ]
+    [ "$output" = "$master1" ]
+
+    run dolt merge-base --octopus master~1 feature~1 HEAD
+    [ "$status" -eq 0 ]
+    [ "$output" = "$base" ]
+
+    run dolt merge-base master feature other
+    [ "$status" -eq 128 ]
+}
+
+@test "merge-base: no common ancestor" {
+    dolt checkout feature
+    dolt sql -q "INSERT INTO t VALUES (1);"
+    dolt commit -am "added 1 on feature"
+    mkdir remote
+    dolt remote add origin file://remote
+    dolt push origin feature
+
+    mkdir other && cd other
+    dolt init
+    dolt remote add origin file://../remote
+    dolt fetch origin
+
+    run dolt merge-base master origin/feature
+    [ "$status" -eq 1 ]
+    [ "$output" = "" ]
+
+    run dolt merge-base --is-ancestor master origin/feature
+    [ "$status" -eq 1 ]
+}
+
+@test "merge-base: sql functions" {
+    base=`get_head_commit`
+    dolt sql -q "INSERT INTO t VALUES (1);"
+    dolt commit -am "added 1 on master"
+    dolt checkout feature
+    dolt sql -q "INSERT INTO t VALUES (2);"
+    dolt commit -am "added 2 on feature"
+
+    run dolt sql -q "SELECT DOLT_MERGE_BASE('master', 'feature')" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "$base" ]
+
+    run dolt sql -q "SELECT DOLT_MERGE_BASE('HEAD', 'HEAD~1')" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "$base" ]
+
+    run dolt sql -q "SELECT DOLT_IS_ANCESTOR('$base', 'master'), DOLT_IS_ANCESTOR('master', 'feature'), DOLT_IS_ANCESTOR('HEAD~1', 'HEAD')" -r csv
+    [ "$status" -eq 0 ]
+    [ "${lines[1]}" = "true,false,true" ]
+
+    run dolt sql -q "SELECT DOLT_IS_ANCESTOR('master', 'doesnotexist')"
+    [ "$status" -eq 1 ]
+}
@@ -34,14 +34,18 @@ teardown() {
     [[ "$output" =~ "diff - Diff a table." ]] || false
     [[ "$output" =~ "blame - Show what revision and author last modified each row of a table." ]] || false
     [[ "$output" =~ "merge - Merge a branch." ]] || false
+    [[ "$output" =~ "merge-base - Find the common ancestors of commits." ]] || false
     [[ "$output" =~ "branch - Create, list, edit, delete branches." ]] || false
     [[ "$output" =~ "tag - Create, list, delete tags" ]] || false
+    [[ "$output" =~ "describe - Name a commit after the nearest tag." ]] || false
     [[ "$output" =~ "checkout - Checkout a branch or overwrite a table from HEAD." ]] || false
     [[ "$output" =~ "remote - Manage set of tracked repositories." ]] || false
     [[ "$output" =~ "push - Push to a dolt remote." ]] || false
     [[ "$output" =~ "pull - Fetch from a dolt remote data repository and merge." ]] || false
     [[ "$output" =~ "fetch - Update the database from a remote data repository." ]] || false
     [[ "$output" =~ "clone - Clone from a remote data repository." ]] || false
+    [[ "$output" =~ "backup - Manage a set of backups of the repository." ]] || false
+    [[ "$output" =~ "remote-server - Serve repositories as remotes." ]] || false
     [[ "$output" =~ "creds - Commands for managing credentials." ]] || false
     [[ "$output" =~ "login - Login to a dolt remote host." ]] || false
     [[ "$output" =~ "version - Displays the current Dolt cli version." ]] || false
@@ -122,6 +126,12 @@ NOT_VALID_REPO_ERROR="The current directory is not a valid dolt repository."
     [ "${lines[0]}" = "$NOT_VALID_REPO_ERROR" ]
 }
 
+@test "no-repo: dolt backup sync outside of a dolt repository" {
+    run dolt backup sync bac1
+    [ "$status" -ne 0 ]
+    [ "${lines[0]}" = "$NOT_VALID_REPO_ERROR" ]
+}
+
 @test "no-repo: dolt diff outside of a dolt repository" {
     run dolt diff
     [ "$status" -ne 0 ]
@@ -134,6 +144,24 @@ NOT_VALID_REPO_ERROR="The current directory is not a valid dolt repository."
     [ "${lines[0]}" = "$NOT_VALID_REPO_ERROR" ]
 }
 
+@test "no-repo: dolt merge-base outside of a dolt repository" {
+    run dolt merge-base master feature
+    [ "$status" -ne 0 ]
+    [ "${lines[0]}" = "$NOT_VALID_REPO_ERROR" ]
+}
+
+@test "no-repo: dolt describe outside of a dolt repository" {
+    run dolt describe
+    [ "$status" -ne 0 ]
+    [ "${lines[0]}" = "$NOT_VALID_REPO_ERROR" ]
+}
+
+@test "no-repo: dolt remote-server outside of a dolt repository" {
+    run dolt remote-server
+    [ "$status" -ne 0 ]
+    [ "${lines[0]}" = "$NOT_VALID_REPO_ERROR" ]
+}
+
 @test "no-repo: dolt branch outside of a dolt repository" {
     run dolt branch
     [ "$status" -ne 0 ]
@@ -0,0 +1,172 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+remote_server_pid=
+setup() {
+    setup_common
+    dolt sql -q "CREATE TABLE t (pk int PRIMARY KEY);"
+    dolt sql -q "INSERT INTO t VALUES (1);"
+    dolt add -A && dolt commit -m "created table t"
+}
+
+teardown() {
+    teardown_common
+    if [ -n "$remote_server_pid" ]; then
+        kill $remote_server_pid
+    fi
+    rm -rf $BATS_TMPDIR/remote-server-$$
+}
+
+start_remote_server() {
+    dolt remote-server --grpc-port 50053 --http-port 1236 "$@" &> $BATS_TMPDIR/remote-server-$$.log 3>&- &
+    remote_server_pid=$!
+    for i in $(seq 1 50); do
+        if (echo > /dev/tcp/localhost/50053) 2>/dev/null; then
+            return 0
+        fi
+        sleep 0.1
+    done
+    echo "dolt remote-server did not start"
+    cat $BATS_TMPDIR/remote-server-$$.log
+    return 1
+}
+
+@test "remote-server: clone, push to and fetch from the current repository" {
+    start_remote_server
+    mkdir -p $BATS_TMPDIR/remote-server-$$
+    cd $BATS_TMPDIR/remote-server-$$
+
+    run dolt clone http://localhost:50053/any-org/any-repo clone
+    [ "$status" -eq 0 ]
+    cd clone
+    run dolt sql -q "SELECT * FROM t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO t VALUES (2);"
+    dolt commit -am "added a row"
+    run dolt push origin feature
+    [ "$status" -eq 0 ]
+
+    cd "$BATS_TMPDIR/dolt-repo-$$"
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "feature" ]] || false
+    run dolt sql -q "SELECT COUNT(*) FROM t AS OF 'feature'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    dolt sql -q "INSERT INTO t VALUES (3);"
+    dolt commit -am "added another row"
+    cd "$BATS_TMPDIR/remote-server-$$/clone"
+    run dolt fetch
+    [ "$status" -eq 0 ]
+    run dolt sql -q "SELECT MAX(pk) FROM t AS OF 'origin/master'" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+}
+
+@test "remote-server: read only server rejects pushes" {
+    start_remote_server --read-only
+    mkdir -p $BATS_TMPDIR/remote-server-$$
+    cd $BATS_TMPDIR/remote-server-$$
+
+    run dolt clone http://localhost:50053/org/repo clone
+    [ "$status" -eq 0 ]
+    cd clone
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO t VALUES (2);"
+    dolt commit -am "added a row"
+    run dolt push origin feature
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "read only" ]] || false
+
+    cd "$BATS_TMPDIR/dolt-repo-$$"
+    run dolt branch
+    [[ ! "$output" =~ "feature" ]] || false
+}
+
+@test "remote-server: serve every repository in a multi-db-dir" {
+    mkdir -p $BATS_TMPDIR/remote-server-$$/dbs/repo1 $BATS_TMPDIR/remote-server-$$/dbs/repo2 $BATS_TMPDIR/remote-server-$$/dbs/not-a-repo
+    cd $BATS_TMPDIR/remote-server-$$/dbs/repo1
+    dolt init
+    dolt sql -q "CREATE TABLE one (pk int PRIMARY KEY);"
+    dolt add -A && dolt commit -m "created table one"
+    cd ../repo2
+    dolt init
+    dolt sql -q "CREATE TABLE two (pk int PRIMARY KEY);"
+    dolt add -A && dolt commit -m "created table two"
+    cd $BATS_TMPDIR/remote-server-$$
+
+    start_remote_server --multi-db-dir ./dbs
+
+    run dolt clone http://localhost:50053/org/repo1 clone1
+    [ "$status" -eq 0 ]
+    run bash -c "cd clone1 && dolt ls"
+    [[ "$output" =~ "one" ]] || false
+
+    run dolt clone http://localhost:50053/org/repo2 clone2
+    [ "$status" -eq 0 ]
+    run bash -c "cd clone2 && dolt ls"
+    [[ "$output" =~ "two" ]] || false
+
+    run dolt clone http://localhost:50053/org/not-a-repo clone3
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "not found" ]] || false
+}
+
+@test "remote-server: requires a repository or a multi-db-dir" {
+    cd $BATS_TMPDIR
+    mkdir -p remote-server-$$/empty
+    cd remote-server-$$/empty
+    run dolt remote-server --grpc-port 50053 --http-port 1236
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "The current directory is not a valid dolt repository." ]] || false
+
+    run dolt remote-server --grpc-port 50053 --http-port 1236 --multi-db-dir .
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "does not contain any dolt repositories" ]] || false
+}
+
+@test "remote-server: serve over TLS and clone with a custom CA bundle" {
+    if ! command -v openssl > /dev/null; then
+        skip "openssl is not installed"
+    fi
+    mkdir -p $BATS_TMPDIR/remote-server-$$
+    cd $BATS_TMPDIR/remote-server-$$
+    openssl req -x509 -newkey rsa:2048 -nodes -days 1 -subj "/CN=localhost" \
+        -addext "subjectAltName=DNS:localhost" -keyout key.pem -out cert.pem 2> /dev/null
+
+    cd "$BATS_TMPDIR/dolt-repo-$$"
+    start_remote_server --tls-cert $BATS_TMPDIR/remote-server-$$/cert.pem --tls-key $BATS_TMPDIR/remote-server-$$/key.pem
+    cd $BATS_TMPDIR/remote-server-$$
+
+    run dolt clone https://localhost:50053/org/repo untrusted
+    [ "$status" -ne 0 ]
+
+    run dolt clone --ca-bundle cert.pem https://localhost:50053/org/repo clone
+    [ "$status" -eq 0 ]
+    cd clone
+    run dolt sql -q "SELECT * FROM t" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+
+    dolt checkout -b feature
+    dolt sql -q "INSERT INTO t VALUES (2);"
+    dolt commit -am "added a row"
+    run dolt push origin feature
+    [ "$status" -eq 0 ]
+}
+
+@test "remote-server: ca-bundle requires an https remote" {
+    run dolt remote add origin --ca-bundle cert.pem http://localhost:50053/org/repo
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "https" ]] || false
+}
+
+@test "remote-server: tls-cert and tls-key must be provided together" {
+    run dolt remote-server --grpc-port 50053 --http-port 1236 --tls-cert cert.pem
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "must be provided together" ]] || false
+}
@@ -0,0 +1,105 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+remotesrv_pid=
+setup() {
+    setup_common
+    cd $BATS_TMPDIR
+    mkdir remotes-auth-$$
+    cat > remotes-auth-$$/auth.json <<JSON
+{
+  "users": [
+    {"name": "writer", "token": "writertoken", "repos": {"test-org/*": "write"}},
+    {"name": "reader", "password": "readerpass", "repos": {"test-org/test-repo": "read"}}
+  ],
+  "anonymous": {"test-org/public-repo": "read"}
+}
+JSON
+    echo remotesrv log available here $BATS_TMPDIR/remotes-auth-$$/remotesrv.log
+    remotesrv --http-port 1235 --grpc-port 50052 --dir ./remotes-auth-$$ --auth-file ./remotes-auth-$$/auth.json &> ./remotes-auth-$$/remotesrv.log 3>&- &
+    remotesrv_pid=$!
+    cd dolt-repo-$$
+    dolt sql -q "CREATE TABLE t (pk int PRIMARY KEY);"
+    dolt add -A && dolt commit -m "created table t"
+    dolt remote add origin http://localhost:50052/test-org/test-repo
+    dolt remote add public http://localhost:50052/test-org/public-repo
+}
+
+teardown() {
+    teardown_common
+    kill $remotesrv_pid
+    rm -rf $BATS_TMPDIR/remotes-auth-$$
+}
+
+@test "remotes-auth: push requires credentials with write access" {
+    run dolt push origin master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "credentials are required" ]] || false
+
+    DOLT_REMOTE_TOKEN=wrongtoken run dolt push origin master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid credentials" ]] || false
+
+    DOLT_REMOTE_USER=reader DOLT_REMOTE_PASSWORD=readerpass run dolt push origin master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "reader does not have write access to test-org/test-repo" ]] || false
+
+    DOLT_REMOTE_TOKEN=writertoken run dolt push origin master
+    [ "$status" -eq 0 ]
+}
+
+@test "remotes-auth: clone with read access" {
+    DOLT_REMOTE_TOKEN=writertoken dolt push origin master
+
+    cd "$BATS_TMPDIR"
+    run dolt clone http://localhost:50052/test-org/test-repo anon-clone-$$
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "credentials are required" ]] || false
+
+    DOLT_REMOTE_USER=reader DOLT_REMOTE_PASSWORD=wrongpass run dolt clone http://localhost:50052/test-org/test-repo reader-clone-$$
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "invalid credentials" ]] || false
+
+    DOLT_REMOTE_USER=reader DOLT_REMOTE_PASSWORD=readerpass run dolt clone http://localhost:50052/test-org/test-repo reader-clone-$$
+    [ "$status" -eq 0 ]
+    cd reader-clone-$$
+    run dolt ls
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "t" ]] || false
+    cd ..
+    rm -rf reader-clone-$$
+}
+
+@test "remotes-auth: anonymous read access" {
+    DOLT_REMOTE_TOKEN=writertoken dolt push public master
+
+    cd "$BATS_TMPDIR"
+    run dolt clone http://localhost:50052/test-org/public-repo anon-clone-$$
+    [ "$status" -eq 0 ]
+    rm -rf anon-clone-$$
+
+    cd dolt-repo-$$
+    dolt sql -q "INSERT INTO t VALUES (1);"
+    dolt commit -am "added a row"
+    run dolt push public master
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "credentials are required" ]] || false
+}
+
+@test "remotes-auth: creating a repository requires write access" {
+    dolt remote add other http://localhost:50052/test-org/other-repo
+    DOLT_REMOTE_USER=reader DOLT_REMOTE_PASSWORD=readerpass run dolt push other master
+    [ "$status" -eq 1 ]
+    [ ! -d "$BATS_TMPDIR/remotes-auth-$$/test-org/other-repo" ]
+
+    DOLT_REMOTE_TOKEN=writertoken run dolt push other master
+    [ "$status" -eq 0 ]
+    [ -d "$BATS_TMPDIR/remotes-auth-$$/test-org/other-repo" ]
+}
+
+@test "remotes-auth: table files are not served without a signature" {
+    DOLT_REMOTE_TOKEN=writertoken dolt push origin master
+
+    run curl -s -o /dev/null -w "%{http_code}" http://localhost:1235/test-org/test-repo/manifest
+    [ "$output" = "403" ]
+}
@@ -175,3 +175,350 @@ SQL
     [ ! -d test-repo ]
     cd ..
 }
+
+@test "remotes-file-system: shallow clone and unshallow fetch" {
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY)"
+    dolt add test
+    dolt commit -m "created test"
+    for i in 1 2 3 4; do
+        dolt sql -q "INSERT INTO test VALUES ($i)"
+        dolt commit -am "inserted $i"
+    done
+
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+    dolt push origin master
+
+    cd dolt-repo-clones
+    dolt clone --depth 2 file://../remotedir test-repo
+    cd test-repo
+
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "inserted 4" ]] || false
+    [[ "$output" =~ "inserted 3" ]] || false
+    [[ ! "$output" =~ "inserted 2" ]] || false
+    [[ ! "$output" =~ "created test" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "4" ]] || false
+
+    # commits on top of a shallow history can be pushed
+    dolt sql -q "INSERT INTO test VALUES (5)"
+    dolt commit -am "inserted 5"
+    dolt push origin master
+
+    dolt fetch --unshallow
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "inserted 5" ]] || false
+    [[ "$output" =~ "inserted 1" ]] || false
+    [[ "$output" =~ "created test" ]] || false
+
+    run dolt fetch --unshallow
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "complete repository" ]] || false
+}
+
+@test "remotes-file-system: history of a shallow clone stops at its boundary" {
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY, c1 int)"
+    dolt add test
+    dolt commit -m "created test"
+    for i in 1 2 3; do
+        dolt sql -q "INSERT INTO test VALUES ($i, $i)"
+        dolt commit -am "inserted $i"
+    done
+
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+    dolt push origin master
+
+    cd dolt-repo-clones
+    dolt clone --depth 2 file://../remotedir test-repo
+    cd test-repo
+
+    run dolt sql -q "SELECT message FROM dolt_log" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "inserted 3" ]] || false
+    [[ "$output" =~ "inserted 2" ]] || false
+    [[ ! "$output" =~ "inserted 1" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM dolt_commit_ancestors" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    run dolt sql -q "SELECT count(*) FROM dolt_history_test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "5" ]] || false
+
+    run dolt sql -q "SELECT to_pk FROM dolt_diff_test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+
+    # rows older than the boundary are blamed on the boundary commit
+    run dolt blame test
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "inserted 2" ]] || false
+    [[ "$output" =~ "inserted 3" ]] || false
+    [[ ! "$output" =~ "inserted 1" ]] || false
+
+    run dolt merge-base master HEAD~1
+    [ "$status" -eq 0 ]
+
+    dolt diff HEAD~1 HEAD
+    run dolt diff HEAD~2 HEAD
+    [ "$status" -ne 0 ]
+    [[ ! "$output" =~ "panic" ]] || false
+    [[ "$output" =~ "shallow clone" ]] || false
+
+    run dolt log HEAD~2
+    [ "$status" -ne 0 ]
+    [[ ! "$output" =~ "panic" ]] || false
+    [[ "$output" =~ "shallow clone" ]] || false
+
+    # the parents of commits which are not recorded at the boundary are expected to be present
+    boundary=`dolt log | grep '^commit' | sed -n '2p' | awk '{print $2}'`
+    sed "s/$boundary/00000000000000000000000000000000/" .dolt/repo_state.json > repo_state.json
+    mv repo_state.json .dolt/repo_state.json
+    run dolt sql -q "SELECT count(*) FROM dolt_log" -r csv
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "failed to get commit" ]] || false
+    [[ ! "$output" =~ "shallow clone" ]] || false
+}
+
+@test "remotes-file-system: shallow fetch" {
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY)"
+    dolt add test
+    dolt commit -m "created test"
+    for i in 1 2 3; do
+        dolt sql -q "INSERT INTO test VALUES ($i)"
+        dolt commit -am "inserted $i"
+    done
+
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+    dolt push origin master
+
+    cd dolt-repo-clones
+    mkdir test-repo
+    cd test-repo
+    dolt init
+    dolt remote add origin file://../../remotedir
+    dolt fetch --depth 1
+
+    run dolt log origin/master
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "inserted 3" ]] || false
+    [[ ! "$output" =~ "inserted 2" ]] || false
+
+    run dolt fetch --depth 0
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "not a positive number" ]] || false
+
+    run dolt fetch --depth 1 --unshallow
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "cannot be used together" ]] || false
+
+    dolt fetch --unshallow
+    run dolt log origin/master
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "created test" ]] || false
+}
+
+@test "remotes-file-system: sparse clone" {
+    dolt sql -q "CREATE TABLE a (pk int PRIMARY KEY)"
+    dolt sql -q "CREATE TABLE b (pk int PRIMARY KEY)"
+    dolt sql -q "CREATE TABLE c (pk int PRIMARY KEY)"
+    dolt add .
+    dolt commit -m "created tables"
+    dolt sql -q "INSERT INTO a VALUES (1)"
+    dolt sql -q "INSERT INTO b VALUES (2)"
+    dolt sql -q "INSERT INTO c VALUES (3)"
+    dolt commit -am "inserted rows"
+    dolt tag v1
+    dolt branch other
+
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+    dolt push origin master
+    dolt push origin other
+    dolt push origin v1
+
+    cd dolt-repo-clones
+    run dolt clone --tables a,missing file://../remotedir test-repo
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "table 'missing' not found on branch 'master'" ]] || false
+    [ ! -d test-repo ]
+
+    dolt clone --tables a,b file://../remotedir test-repo
+    cd test-repo
+
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "inserted rows" ]] || false
+    [[ "$output" =~ "created tables" ]] || false
+
+    run dolt sql -q "SELECT * FROM a" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "1" ]] || false
+    run dolt sql -q "SELECT * FROM b" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    run dolt sql -q "SELECT * FROM c" -r csv
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "table 'c' is not present locally" ]] || false
+
+    run dolt ls
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "c" ]] || false
+
+    run dolt branch -a
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "remotes/origin/other" ]] || false
+
+    run dolt tag
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "v1" ]] || false
+
+    # changes to the present tables can be committed and pushed
+    dolt sql -q "INSERT INTO a VALUES (4)"
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "modified:       a" ]] || false
+    dolt commit -am "inserted into a"
+    dolt push origin master
+
+    # later fetches stay sparse
+    cd ../..
+    dolt pull origin
+    dolt sql -q "INSERT INTO c VALUES (5)"
+    dolt sql -q "INSERT INTO b VALUES (6)"
+    dolt commit -am "inserted into b and c"
+    dolt push origin master
+    cd dolt-repo-clones/test-repo
+    dolt pull origin
+    run dolt sql -q "SELECT count(*) FROM b" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+    run dolt sql -q "SELECT * FROM c" -r csv
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "not present locally" ]] || false
+}
+
+@test "remotes-file-system: branches of a sparse clone" {
+    dolt sql -q "CREATE TABLE a (pk int PRIMARY KEY)"
+    dolt sql -q "CREATE TABLE b (pk int PRIMARY KEY)"
+    dolt add .
+    dolt commit -m "created tables"
+
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+    dolt push origin master
+
+    cd dolt-repo-clones
+    dolt clone --tables a file://../remotedir test-repo
+    cd test-repo
+
+    run dolt checkout -b feature
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "panic" ]] || false
+    run dolt branch
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "* feature" ]] || false
+
+    dolt sql -q "INSERT INTO a VALUES (1)"
+    dolt commit -am "inserted 1 on feature"
+    dolt checkout master
+    dolt branch other
+
+    # working changes are carried to the checked out branch
+    dolt sql -q "INSERT INTO a VALUES (2)"
+    dolt checkout other
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "modified:       a" ]] || false
+    dolt commit -am "inserted 2 on other"
+
+    dolt checkout feature
+    dolt merge other
+    dolt commit -m "merged other"
+    run dolt sql -q "SELECT count(*) FROM a" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "2" ]] || false
+
+    run dolt sql -q "SELECT * FROM b" -r csv
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "not present locally" ]] || false
+
+    run dolt sql -q "SHOW TABLES" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "b" ]] || false
+
+    # changes to the tables which were left out can't be diffed, rather than being left out of the diff
+    cd ../..
+    dolt sql -q "INSERT INTO b VALUES (3)"
+    dolt commit -am "inserted into b"
+    dolt push origin master
+    cd dolt-repo-clones/test-repo
+    dolt fetch
+    run dolt diff master origin/master
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "table 'b' is not present locally" ]] || false
+    run dolt merge origin/master
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "table 'b' is not present locally" ]] || false
+}
+
+@test "remotes-file-system: resume an interrupted clone" {
+    dolt sql -q "CREATE TABLE test (pk int PRIMARY KEY)"
+    dolt add test
+    dolt commit -m "created test"
+
+    mkdir remotedir
+    dolt remote add origin file://remotedir
+    dolt push origin master
+    for i in 1 2 3; do
+        dolt sql -q "INSERT INTO test VALUES ($i)"
+        dolt commit -am "inserted $i"
+        dolt push origin master
+    done
+
+    # corrupt a table file of the remote, which makes the clone fail once the other table files are downloaded
+    tablefile=`ls -S remotedir | head -n 1`
+    cp "remotedir/$tablefile" saved-table-file
+    printf '\377' | dd of="remotedir/$tablefile" bs=1 seek=0 count=1 conv=notrunc
+
+    cd dolt-repo-clones
+    run dolt clone file://../remotedir test-repo
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "invalid or corrupt table file" ]] || false
+    [[ "$output" =~ "resume the clone" ]] || false
+    [ -d test-repo/.dolt ]
+
+    cp ../saved-table-file "../remotedir/$tablefile"
+    run dolt clone file://../remotedir test-repo
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "resuming the interrupted clone" ]] || false
+
+    cd test-repo
+    run dolt sql -q "SELECT count(*) FROM test" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "3" ]] || false
+
+    run dolt log
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "inserted 3" ]] || false
+    [[ "$output" =~ "created test" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "up to date with 'origin/master'" ]] || false
+
+    # a complete clone is not resumed
+    cd ..
+    run dolt clone file://../remotedir test-repo
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "data repository already exists" ]] || false
+}
@@ -0,0 +1,120 @@
+#!/usr/bin/env bats
+load $BATS_TEST_DIRNAME/helper/common.bash
+
+setup() {
+    setup_common
+    dolt sql <<SQL
+CREATE TABLE test (
+  pk BIGINT NOT NULL,
+  c1 BIGINT,
+  c2 BIGINT,
+  PRIMARY KEY (pk)
+);
+INSERT INTO test VALUES (1,1,1);
+SQL
+    dolt add .
+    dolt commit -m "created table"
+    dolt branch other
+    dolt sql -q "ALTER TABLE test RENAME COLUMN c1 TO ours"
+    dolt sql -q "INSERT INTO test VALUES (2,2,2)"
+    dolt commit -am "renamed c1 to ours"
+    dolt checkout other
+    dolt sql -q "ALTER TABLE test RENAME COLUMN c1 TO theirs"
+    dolt sql -q "INSERT INTO test VALUES (3,3,3)"
+    dolt commit -am "renamed c1 to theirs"
+    dolt checkout master
+}
+
+teardown() {
+    teardown_common
+}
+
+@test "schema-conflicts: merge records schema conflicts" {
+    run dolt merge other
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "CONFLICT (schema): Merge conflict in test" ]] || false
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "You have unmerged tables" ]] || false
+    [[ "$output" =~ "both modified:  test (schema conflict)" ]] || false
+
+    run dolt sql -r csv -q "SELECT table_name, description FROM dolt_schema_conflicts"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "test,different column definitions for our column ours and their column theirs" ]] || false
+
+    run dolt sql -r csv -q "SELECT our_schema, their_schema FROM dolt_schema_conflicts"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '`ours` BIGINT' ]] || false
+    [[ "$output" =~ '`theirs` BIGINT' ]] || false
+
+    run dolt sql -r csv -q "SELECT status FROM dolt_status WHERE table_name = 'test' AND status = 'schema conflict'"
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+
+    run dolt add test
+    [ "$status" -ne 0 ]
+
+    run dolt merge other
+    [ "$status" -eq 1 ]
+    [[ "$output" =~ "unmerged files" ]] || false
+}
+
+@test "schema-conflicts: resolve with --ours and --theirs" {
+    dolt merge other
+    dolt conflicts resolve --theirs test
+
+    run dolt sql -r csv -q "SELECT * FROM test ORDER BY pk"
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = "pk,theirs,c2" ]
+    [ "${lines[1]}" = "1,1,1" ]
+    [ "${lines[2]}" = "3,3,3" ]
+    [ "${#lines[@]}" -eq 3 ]
+
+    run dolt sql -q "SELECT COUNT(*) FROM dolt_schema_conflicts" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "0" ]] || false
+
+    dolt add test
+    dolt commit -m "merged"
+
+    dolt checkout -b resolve-ours HEAD~1
+    dolt merge other
+    run dolt sql -q "SELECT DOLT_CONFLICTS_RESOLVE('--ours', '.')"
+    [ "$status" -eq 0 ]
+
+    run dolt sql -r csv -q "SELECT * FROM test ORDER BY pk"
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = "pk,ours,c2" ]
+    [ "${lines[1]}" = "1,1,1" ]
+    [ "${lines[2]}" = "2,2,2" ]
+    [ "${#lines[@]}" -eq 3 ]
+}
+
+@test "schema-conflicts: resolve with ALTER TABLE and merge the rows" {
+    dolt merge other
+
+    run dolt sql -q "DELETE FROM dolt_schema_conflicts WHERE table_name = 'test'"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "schema conflicts for table test" ]] || false
+
+    dolt sql -q "ALTER TABLE test RENAME COLUMN ours TO theirs"
+    run dolt sql -r csv -q "SELECT description FROM dolt_schema_conflicts"
+    [ "$status" -eq 0 ]
+    [[ ! "$output" =~ "different column definitions" ]] || false
+
+    dolt sql -q "DELETE FROM dolt_schema_conflicts WHERE table_name = 'test'"
+
+    run dolt sql -r csv -q "SELECT * FROM test ORDER BY pk"
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = "pk,theirs,c2" ]
+    [ "${lines[1]}" = "1,1,1" ]
+    [ "${lines[2]}" = "2,2,2" ]
+    [ "${lines[3]}" = "3,3,3" ]
+
+    run dolt status
+    [[ "$output" =~ "All conflicts fixed but you are still merging" ]] || false
+
+    dolt add test
+    dolt commit -m "merged"
+}
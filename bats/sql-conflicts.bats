@@ -216,4 +216,85 @@ teardown() {
   run dolt sql -r csv -q "SELECT * FROM dolt_conflicts"
   [ "$status" -eq 0 ]
   [[ "$output" =~ "$EXPECTED" ]] || false
-}
\ No newline at end of file
+}
+
+@test "sql-conflicts: resolve conflicts by updating our columns" {
+  dolt SQL -q "INSERT INTO one_pk (pk1,c1,c2) VALUES (0,0,0), (1,1,1)"
+  dolt add .
+  dolt commit -m "add rows"
+  dolt branch feature_branch master
+  dolt SQL -q "UPDATE one_pk SET c1 = 10, c2 = 10 WHERE pk1 = 0"
+  dolt SQL -q "DELETE FROM one_pk WHERE pk1 = 1"
+  dolt add .
+  dolt commit -m "changed master"
+  dolt checkout feature_branch
+  dolt SQL -q "UPDATE one_pk SET c1 = 20, c2 = 20"
+  dolt add .
+  dolt commit -m "changed feature_branch"
+  dolt checkout master
+  dolt merge feature_branch
+
+  dolt sql -q "UPDATE dolt_conflicts_one_pk SET our_c1 = their_c1 WHERE our_pk1 = 0"
+  dolt sql -q "UPDATE dolt_conflicts_one_pk SET our_pk1 = their_pk1, our_c1 = their_c1, our_c2 = their_c2 WHERE their_pk1 = 1"
+
+  run dolt sql -r csv -q "SELECT our_pk1, our_c1, our_c2 FROM dolt_conflicts_one_pk ORDER BY their_pk1"
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "0,20,10" ]] || false
+  [[ "$output" =~ "1,20,20" ]] || false
+
+  run dolt sql -r csv -q "SELECT * FROM one_pk ORDER BY pk1"
+  [ "$status" -eq 0 ]
+  [ "${lines[1]}" = "0,20,10" ]
+  [ "${lines[2]}" = "1,20,20" ]
+
+  dolt sql -q "DELETE FROM dolt_conflicts_one_pk"
+  run dolt sql -r csv -q "SELECT COUNT(*) FROM dolt_conflicts_one_pk"
+  [ "$status" -eq 0 ]
+  [ "${lines[1]}" = "0" ]
+
+  run dolt sql -r csv -q "SELECT * FROM one_pk ORDER BY pk1"
+  [ "${lines[1]}" = "0,20,10" ]
+  [ "${lines[2]}" = "1,20,20" ]
+}
+
+@test "sql-conflicts: DOLT_CONFLICTS_RESOLVE" {
+  dolt branch feature_branch master
+  dolt SQL -q "INSERT INTO one_pk (pk1,c1,c2) VALUES (0,0,0)"
+  dolt SQL -q "INSERT INTO two_pk (pk1,pk2,c1,c2) VALUES (0,0,0,0)"
+  dolt add .
+  dolt commit -m "changed master"
+  dolt checkout feature_branch
+  dolt SQL -q "INSERT INTO one_pk (pk1,c1,c2) VALUES (0,1,1)"
+  dolt SQL -q "INSERT INTO two_pk (pk1,pk2,c1,c2) VALUES (0,0,1,1)"
+  dolt add .
+  dolt commit -m "changed feature_branch"
+  dolt checkout master
+  dolt merge feature_branch
+
+  run dolt sql -q "SELECT DOLT_CONFLICTS_RESOLVE('--ours', '--theirs', 'one_pk')"
+  [ "$status" -eq 1 ]
+  [[ "$output" =~ "exactly one of --ours or --theirs must be given" ]] || false
+
+  run dolt sql -r csv -q "SELECT DOLT_CONFLICTS_RESOLVE('--theirs', 'one_pk')"
+  [ "$status" -eq 0 ]
+
+  run dolt sql -r csv -q "SELECT * FROM one_pk"
+  [ "$status" -eq 0 ]
+  [ "${lines[1]}" = "0,1,1" ]
+
+  run dolt sql -r csv -q "SELECT * FROM dolt_conflicts ORDER BY \`table\`"
+  [ "$status" -eq 0 ]
+  [[ "$output" =~ "one_pk,0" ]] || false
+  [[ "$output" =~ "two_pk,1" ]] || false
+
+  run dolt sql -r csv -q "SELECT DOLT_CONFLICTS_RESOLVE('--ours', '.')"
+  [ "$status" -eq 0 ]
+
+  run dolt sql -r csv -q "SELECT * FROM two_pk"
+  [ "$status" -eq 0 ]
+  [ "${lines[1]}" = "0,0,0,0" ]
+
+  run dolt sql -r csv -q "SELECT COUNT(*) FROM dolt_conflicts_two_pk"
+  [ "$status" -eq 0 ]
+  [ "${lines[1]}" = "0" ]
+}
@@ -172,4 +172,150 @@ SQL
     run dolt sql -q "SELECT sum(pk) FROM test" -r csv
     [ "$status" -eq 0 ]
     [[ "$output" =~ "1" ]] || false
-}
\ No newline at end of file
+}
+@test "status: --porcelain" {
+    dolt sql <<SQL
+CREATE TABLE staged (pk int PRIMARY KEY);
+CREATE TABLE both (pk int PRIMARY KEY);
+CREATE TABLE unstaged (pk int PRIMARY KEY);
+CREATE TABLE old_name (pk int PRIMARY KEY);
+SQL
+    dolt add -A && dolt commit -m "created tables"
+    dolt sql -q "INSERT INTO staged VALUES (1)"
+    dolt sql -q "INSERT INTO both VALUES (1)"
+    dolt sql -q "ALTER TABLE old_name RENAME TO new_name"
+    dolt add staged both old_name new_name
+    dolt sql -q "INSERT INTO both VALUES (2)"
+    dolt sql -q "INSERT INTO unstaged VALUES (1)"
+    dolt sql -q "CREATE TABLE untracked (pk int PRIMARY KEY)"
+    echo "a readme" > README.md
+
+    run dolt status --porcelain
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = "# branch.head master" ]
+    [[ "$output" =~ "M  staged" ]] || false
+    [[ "$output" =~ "MM both" ]] || false
+    [[ "$output" =~ "R  old_name -> new_name" ]] || false
+    [[ "$output" =~ " M unstaged" ]] || false
+    [[ "$output" =~ "?? untracked" ]] || false
+    [[ "$output" =~ "?? README.md" ]] || false
+    [[ ! "$output" =~ "branch.upstream" ]] || false
+    [[ ! "$output" =~ "merge.in-progress" ]] || false
+    [ "${#lines[@]}" -eq 7 ]
+
+    run dolt status --porcelain --format json
+    [ "$status" -ne 0 ]
+    run dolt status --format xml
+    [ "$status" -ne 0 ]
+}
+
+@test "status: --porcelain with conflicts and upstream" {
+    mkdir remote
+    dolt remote add origin file://remote
+    dolt sql <<SQL
+CREATE TABLE t (pk int PRIMARY KEY, c0 int);
+INSERT INTO t VALUES (1,1);
+SQL
+    dolt add -A && dolt commit -m "created table t"
+    dolt push -u origin master
+    dolt checkout -b other
+    dolt sql -q "INSERT INTO t VALUES (2,12);"
+    dolt add -A && dolt commit -m "added values on branch other"
+    dolt checkout master
+    dolt sql -q "INSERT INTO t VALUES (2,2);"
+    dolt add -A && dolt commit -m "added values on branch master"
+    dolt merge other
+
+    run dolt status --porcelain
+    [ "$status" -eq 0 ]
+    [ "${lines[0]}" = "# branch.head master" ]
+    [ "${lines[1]}" = "# branch.upstream origin/master" ]
+    [ "${lines[2]}" = "# branch.ab +1 -0" ]
+    [ "${lines[3]}" = "# merge.in-progress" ]
+    [ "${lines[4]}" = "UU t" ]
+    [ "${#lines[@]}" -eq 5 ]
+}
+
+@test "status: --format json" {
+    skiponwindows "Need to install python before this test will work."
+    dolt sql <<SQL
+CREATE TABLE t (pk int PRIMARY KEY, c0 int);
+CREATE TABLE u (pk int PRIMARY KEY, c0 int);
+SQL
+    dolt add -A && dolt commit -m "created tables"
+    dolt sql -q "INSERT INTO t VALUES (1,1);"
+    dolt add t
+    dolt sql -q "DROP TABLE u"
+
+    run dolt status --format json
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ '"branch": "master"' ]] || false
+    [[ "$output" =~ '"upstream": null' ]] || false
+    [[ "$output" =~ '"merge_in_progress": false' ]] || false
+    [[ "$output" =~ '"conflicts": []' ]] || false
+
+    run bash -c "dolt status --format json | python3 -c 'import json,sys; s=json.load(sys.stdin); print(s[\"staged\"][0][\"name\"], s[\"staged\"][0][\"status\"], s[\"unstaged\"][0][\"name\"], s[\"unstaged\"][0][\"status\"])'"
+    [ "$status" -eq 0 ]
+    [ "$output" = "t modified u deleted" ]
+}
+
+@test "status: ahead and behind upstream" {
+    mkdir remote
+    dolt remote add origin file://remote
+    dolt sql -q "CREATE TABLE t (pk int PRIMARY KEY);"
+    dolt add -A && dolt commit -m "created table t"
+    dolt push -u origin master
+
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Your branch is up to date with 'origin/master'." ]] || false
+
+    dolt sql -q "INSERT INTO t VALUES (1);"
+    dolt commit -am "added 1"
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Your branch is ahead of 'origin/master' by 1 commit." ]] || false
+
+    run dolt branch -v
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "[ahead 1]" ]] || false
+
+    dolt push origin master
+    dolt reset --hard HEAD~1
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Your branch is behind 'origin/master' by 1 commit" ]] || false
+
+    dolt sql -q "INSERT INTO t VALUES (2);"
+    dolt commit -am "added 2"
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Your branch and 'origin/master' have diverged," ]] || false
+    [[ "$output" =~ "and have 1 and 1 different commits each, respectively." ]] || false
+
+    run dolt branch -v
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "[ahead 1, behind 1]" ]] || false
+
+    run dolt sql -q "SELECT name, upstream, ahead, behind FROM dolt_branches" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "master,origin/master,1,1" ]] || false
+}
+
+@test "status: cloned branch tracks its upstream" {
+    mkdir remote
+    dolt remote add origin file://remote
+    dolt sql -q "CREATE TABLE t (pk int PRIMARY KEY);"
+    dolt add -A && dolt commit -m "created table t"
+    dolt push origin master
+
+    dolt clone file://remote clone
+    cd clone
+    run dolt status
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "Your branch is up to date with 'origin/master'." ]] || false
+
+    run dolt sql -q "SELECT name, upstream, ahead, behind FROM dolt_branches" -r csv
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "master,origin/master,0,0" ]] || false
+}
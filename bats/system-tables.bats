@@ -218,6 +218,42 @@ teardown() {
     [ ! "$status" -eq 0 ]
 }
 
+@test "system-tables: query dolt_commit_diff_ with commit specs and primary key filters" {
+    dolt sql -q "CREATE TABLE test (pk INT, c1 INT, PRIMARY KEY(pk))"
+    dolt sql -q "INSERT INTO test VALUES (1,1),(2,2),(3,3)"
+    dolt add test
+    dolt commit -m "added rows 1-3"
+    dolt tag v1
+    dolt sql -q "UPDATE test SET c1=20 WHERE pk=2"
+    dolt sql -q "DELETE FROM test WHERE pk=3"
+    dolt add test
+    dolt commit -m "modified 2, deleted 3"
+    dolt sql -q "INSERT INTO test VALUES (4,4)"
+    dolt add test
+    dolt commit -m "added 4"
+
+    EXPECTED=$(echo -e "to_pk,to_c1,from_pk,from_c1,diff_type\n2,20,2,2,modified\n,,3,3,removed\n4,4,,,added")
+    run dolt sql -r csv -q "SELECT to_pk, to_c1, from_pk, from_c1, diff_type FROM dolt_commit_diff_test WHERE from_commit='HEAD~2' AND to_commit='WORKING' ORDER BY COALESCE(to_pk, from_pk)"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$EXPECTED" ]] || false
+
+    EXPECTED=$(echo -e "to_pk,to_c1,from_pk,from_c1,diff_type\n2,20,2,2,modified")
+    run dolt sql -r csv -q "SELECT to_pk, to_c1, from_pk, from_c1, diff_type FROM dolt_commit_diff_test WHERE from_commit='v1' AND to_commit='master' AND to_pk=2"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$EXPECTED" ]] || false
+    [ "${#lines[@]}" -eq 2 ]
+
+    EXPECTED=$(echo -e "to_pk,to_c1,from_pk,from_c1,diff_type\n,,3,3,removed")
+    run dolt sql -r csv -q "SELECT to_pk, to_c1, from_pk, from_c1, diff_type FROM dolt_commit_diff_test WHERE from_commit='v1' AND to_commit='HEAD' AND from_pk=3"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "$EXPECTED" ]] || false
+    [ "${#lines[@]}" -eq 2 ]
+
+    run dolt sql -r csv -q "SELECT to_pk FROM dolt_commit_diff_test WHERE from_commit='v1' AND to_commit='HEAD' AND to_pk=1"
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+}
+
 @test "system-tables: query dolt_diff_ system table without committing table" {
     dolt sql -q "create table test (pk int not null primary key);"
     dolt sql -q "insert into test values (0), (1);"
@@ -347,3 +383,74 @@ teardown() {
     [[ "$output" =~ "0,commit B" ]] || false
     [[ "$output" =~ "1,commit C" ]] || false
 }
+
+@test "system-tables: query dolt_diff_summary system table" {
+    dolt sql -q "CREATE TABLE test (pk INT, c1 INT, PRIMARY KEY(pk))"
+    dolt sql -q "CREATE TABLE keyless (c1 INT, c2 INT)"
+    dolt sql -q "INSERT INTO test VALUES (1,1),(2,2),(3,3)"
+    dolt sql -q "INSERT INTO keyless VALUES (1,1)"
+    dolt add -A && dolt commit -m "added tables"
+    dolt sql -q "UPDATE test SET c1=4 WHERE pk=2"
+    dolt sql -q "DELETE FROM test WHERE pk=3"
+    dolt sql -q "INSERT INTO test VALUES (5,5),(6,6)"
+    dolt sql -q "INSERT INTO keyless VALUES (2,2)"
+
+    run dolt sql -r csv -q "SELECT * FROM dolt_diff_summary WHERE from_commit='HEAD' AND to_commit='WORKING' ORDER BY table_name"
+    [ "$status" -eq 0 ]
+    [[ "$output" =~ "from_commit,to_commit,table_name,rows_unmodified,rows_added,rows_deleted,rows_modified,cells_modified,old_row_count,new_row_count" ]] || false
+    [[ "$output" =~ "HEAD,WORKING,keyless,,1,0,,,," ]] || false
+    [[ "$output" =~ "HEAD,WORKING,test,1,2,1,1,1,3,4" ]] || false
+
+    run dolt sql -r csv -q "SELECT table_name, rows_added FROM dolt_diff_summary WHERE from_commit='HEAD~1' AND to_commit='HEAD' AND table_name='test'"
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "$output" =~ "test,3" ]] || false
+
+    run dolt sql -q "SELECT * FROM dolt_diff_summary WHERE to_commit='WORKING'"
+    [ "$status" -ne 0 ]
+    [[ "$output" =~ "must be filtered to a single 'from_commit'" ]] || false
+}
+
+@test "system-tables: dolt_diff_summary reports tables whose schema changed but not their rows" {
+    dolt sql -q "CREATE TABLE test (pk INT, c1 INT, PRIMARY KEY(pk))"
+    dolt sql -q "CREATE TABLE other (pk INT PRIMARY KEY)"
+    dolt sql -q "INSERT INTO test VALUES (1,1),(2,2)"
+    dolt add -A && dolt commit -m "added tables"
+    dolt sql -q "CREATE INDEX c1_idx ON test (c1)"
+    dolt sql -q "CREATE TABLE empty (pk INT PRIMARY KEY)"
+
+    run dolt sql -r csv -q "SELECT * FROM dolt_diff_summary WHERE from_commit='HEAD' AND to_commit='WORKING' ORDER BY table_name"
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 3 ]
+    [[ "$output" =~ "HEAD,WORKING,empty,0,0,0,0,0,0,0" ]] || false
+    [[ "$output" =~ "HEAD,WORKING,test,2,0,0,0,0,2,2" ]] || false
+    [[ ! "$output" =~ "other" ]] || false
+}
+
+@test "system-tables: query dolt_schema_diff system table" {
+    dolt sql -q "CREATE TABLE test (pk INT, c1 INT, c2 INT, PRIMARY KEY(pk))"
+    dolt add -A && dolt commit -m "added test"
+    dolt sql -q "ALTER TABLE test ADD COLUMN c3 VARCHAR(20)"
+    dolt sql -q "ALTER TABLE test DROP COLUMN c2"
+    dolt sql -q "CREATE INDEX c1_idx ON test (c1)"
+    dolt sql -q "CREATE TABLE other (pk INT PRIMARY KEY)"
+
+    run dolt sql -r csv -q "SELECT table_name, element_type, element_name, diff_type, from_definition, to_definition FROM dolt_schema_diff WHERE from_commit='HEAD' AND to_commit='WORKING' AND table_name='test'"
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 4 ]
+    [[ "$output" =~ 'test,column,c2,removed,`c2` INT,' ]] || false
+    [[ "$output" =~ 'test,column,c3,added,,`c3` VARCHAR(20)' ]] || false
+    [[ "$output" =~ 'test,index,c1_idx,added,,INDEX `c1_idx` (`c1`)' ]] || false
+
+    run dolt sql -r csv -q "SELECT table_name, element_type, diff_type FROM dolt_schema_diff WHERE from_commit='HEAD' AND to_commit='WORKING' AND element_type='table'"
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 2 ]
+    [[ "$output" =~ "other,table,added" ]] || false
+}
+
+@test "system-tables: dolt_replication_status is empty outside of a replicating sql-server" {
+    run dolt sql -r csv -q "SELECT * FROM dolt_replication_status"
+    [ "$status" -eq 0 ]
+    [ "${#lines[@]}" -eq 1 ]
+    [[ "$output" =~ "branch,remote,head_hash,replicated_hash,lag_seconds,last_replicated,last_error" ]] || false
+}
@@ -84,6 +84,11 @@ const (
 	NoFFParam        = "no-ff"
 	SquashParam      = "squash"
 	AbortParam       = "abort"
+	OursFlag         = "ours"
+	TheirsFlag       = "theirs"
+	TagsFlag         = "tags"
+	LongFlag         = "long"
+	DirtyFlag        = "dirty"
 )
 
 var mergeAbortDetails = `Abort the current conflict resolution process, and try to reconstruct the pre-merge state.
@@ -112,6 +117,16 @@ func CreateMergeArgParser() *argparser.ArgParser {
 	return ap
 }
 
+// Creates the argparser shared by dolt describe and DOLT_DESCRIBE.
+func CreateDescribeArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"commit", "The commit to describe. Defaults to HEAD."})
+	ap.SupportsFlag(TagsFlag, "", "Use any tag, including lightweight tags which have no message, instead of only annotated tags.")
+	ap.SupportsFlag(LongFlag, "", "Always output the long format, even when the commit is tagged.")
+	ap.SupportsFlag(DirtyFlag, "", "Append \"-dirty\" if the working set differs from HEAD. Can only be used when describing HEAD.")
+	return ap
+}
+
 func CreateAddArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParser()
 	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "Working table(s) to add to the list tables staged to be committed. The abbreviation '.' can be used to add all tables."})
@@ -131,3 +146,13 @@ func CreateCheckoutArgParser() *argparser.ArgParser {
 	ap.SupportsString(CheckoutCoBranch, "", "branch", "Create a new branch named {{.LessThan}}new_branch{{.GreaterThan}} and start it at {{.LessThan}}start_point{{.GreaterThan}}.")
 	return ap
 }
+
+// Creates the argparser shared by dolt conflicts resolve and DOLT_CONFLICTS_RESOLVE.
+func CreateConflictsResolveArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "List of tables to be printed. When in auto-resolve mode, '.' can be used to resolve all tables."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"key", "key(s) of rows within a table whose conflicts have been resolved"})
+	ap.SupportsFlag(OursFlag, "", "For all conflicts, take the version from our branch and resolve the conflict")
+	ap.SupportsFlag(TheirsFlag, "", "For all conflicts, take the version from their branch and resolve the conflict")
+	return ap
+}
@@ -0,0 +1,365 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var backupDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Manage a set of backups of the repository",
+	LongDesc: `With no arguments, shows a list of existing backups. Several subcommands are available to perform operations on the backups.
+
+A backup holds everything in the repository: every branch, tag and remote-tracking branch, the working set and the staged changes, and the configuration of remotes and branches. Unlike a remote, a backup is never pulled from or merged with; it is only synced and restored.
+
+{{.EmphasisLeft}}add{{.EmphasisRight}}
+Adds a backup named {{.LessThan}}name{{.GreaterThan}} at {{.LessThan}}url{{.GreaterThan}}. The {{.LessThan}}url{{.GreaterThan}} parameter supports url schemes of aws, gs, and file, and the same aws parameters as {{.EmphasisLeft}}dolt remote add{{.EmphasisRight}}. The directory of a file url must exist.
+
+{{.EmphasisLeft}}remove{{.EmphasisRight}}, {{.EmphasisLeft}}rm{{.EmphasisRight}}
+Removes the backup named {{.LessThan}}name{{.GreaterThan}}. The data of the backup is not deleted.
+
+{{.EmphasisLeft}}sync{{.EmphasisRight}}
+Brings the backup named {{.LessThan}}name{{.GreaterThan}} up to date with the repository. Only the table files which the backup does not have yet are uploaded, so syncing a backup again only uploads the changes made since it was last synced.
+
+{{.EmphasisLeft}}restore{{.EmphasisRight}}
+Restores the backup at {{.LessThan}}url{{.GreaterThan}} into the newly created directory {{.LessThan}}new-dir{{.GreaterThan}}. The restored repository has the branches, working set and configuration of the repository when it was last synced.`,
+
+	Synopsis: []string{
+		"[-v | --verbose]",
+		"add [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}url{{.GreaterThan}}",
+		"remove {{.LessThan}}name{{.GreaterThan}}",
+		"sync {{.LessThan}}name{{.GreaterThan}}",
+		"restore [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}url{{.GreaterThan}} {{.LessThan}}new-dir{{.GreaterThan}}",
+	},
+}
+
+const (
+	addBackupId         = "add"
+	removeBackupId      = "remove"
+	removeBackupShortId = "rm"
+	syncBackupId        = "sync"
+	restoreBackupId     = "restore"
+)
+
+type BackupCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd BackupCmd) Name() string {
+	return "backup"
+}
+
+// Description returns a description of the command
+func (cmd BackupCmd) Description() string {
+	return "Manage a set of backups of the repository."
+}
+
+// RequiresRepo should return false if this interface is implemented, and the command does not have the requirement
+// that it be run from within a data repository directory
+func (cmd BackupCmd) RequiresRepo() bool {
+	// restore creates a new repository, every other subcommand checks for a repository itself
+	return false
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd BackupCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, backupDocs, ap))
+}
+
+func (cmd BackupCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"region", "cloud provider region associated with this backup."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"creds-type", "credential type.  Valid options are role, env, and file.  See the help section of dolt remote for additional details."})
+	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"profile", "AWS profile to use."})
+	ap.SupportsFlag(verboseFlag, "v", "When printing the list of backups adds additional details.")
+	ap.SupportsString(dbfactory.AWSRegionParam, "", "region", "")
+	ap.SupportsValidatedString(dbfactory.AWSCredsTypeParam, "", "creds-type", "", argparser.ValidatorFromStrList(dbfactory.AWSCredsTypeParam, credTypes))
+	ap.SupportsString(dbfactory.AWSCredsFileParam, "", "file", "AWS credentials file")
+	ap.SupportsString(dbfactory.AWSCredsProfile, "", "profile", "AWS profile to use")
+	return ap
+}
+
+// Exec executes the command
+func (cmd BackupCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, backupDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() == 0 || apr.Arg(0) != restoreBackupId {
+		if !cli.CheckEnvIsValid(dEnv) {
+			return 2
+		}
+	}
+
+	var verr errhand.VerboseError
+
+	switch {
+	case apr.NArg() == 0:
+		verr = printBackups(dEnv, apr)
+	case apr.Arg(0) == addBackupId:
+		verr = addBackup(dEnv, apr)
+	case apr.Arg(0) == removeBackupId:
+		verr = removeBackup(dEnv, apr)
+	case apr.Arg(0) == removeBackupShortId:
+		verr = removeBackup(dEnv, apr)
+	case apr.Arg(0) == syncBackupId:
+		verr = syncBackup(ctx, dEnv, apr)
+	case apr.Arg(0) == restoreBackupId:
+		verr = restoreBackup(ctx, dEnv, apr)
+	default:
+		verr = errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	return HandleVErrAndExitCode(verr, usage)
+}
+
+func printBackups(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	backups, err := dEnv.GetBackups()
+
+	if err != nil {
+		return errhand.BuildDError("Unable to get backups from the local directory").AddCause(err).Build()
+	}
+
+	names := make([]string, 0, len(backups))
+	for name := range backups {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		b := backups[name]
+		if apr.Contains(verboseFlag) {
+			paramStr := make([]byte, 0)
+			if len(b.Params) > 0 {
+				paramStr, _ = json.Marshal(b.Params)
+			}
+
+			cli.Printf("%s %s %s\n", b.Name, b.Url, paramStr)
+		} else {
+			cli.Println(b.Name)
+		}
+	}
+
+	return nil
+}
+
+func addBackup(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() != 3 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	backupName := strings.TrimSpace(apr.Arg(1))
+
+	if strings.IndexAny(backupName, " \t\n\r./\\!@#$%^&*(){}[],.<>'\"?=+|") != -1 {
+		return errhand.BuildDError("invalid backup name: " + backupName).Build()
+	}
+
+	if _, ok := dEnv.RepoState.Backups[backupName]; ok {
+		return errhand.BuildDError("error: A backup named '%s' already exists.", backupName).AddDetails("remove it before running this command again").Build()
+	}
+
+	b, verr := newBackup(dEnv, apr, backupName, apr.Arg(2))
+
+	if verr != nil {
+		return verr
+	}
+
+	dEnv.RepoState.AddBackup(b)
+	err := dEnv.RepoState.Save(dEnv.FS)
+
+	if err != nil {
+		return errhand.BuildDError("error: Unable to save changes.").AddCause(err).Build()
+	}
+
+	return nil
+}
+
+// newBackup returns the backup named |backupName| at |backupUrl|. Backups are written to directly, so only urls of
+// storage which dolt can write table files to are valid.
+func newBackup(dEnv *env.DoltEnv, apr *argparser.ArgParseResults, backupName, backupUrl string) (env.Remote, errhand.VerboseError) {
+	scheme, absBackupUrl, err := getAbsRemoteUrl(dEnv.FS, dEnv.Config, backupUrl)
+
+	if err != nil {
+		return env.NoRemote, errhand.BuildDError("error: '%s' is not valid.", backupUrl).AddCause(err).Build()
+	}
+
+	switch scheme {
+	case dbfactory.AWSScheme, dbfactory.GSScheme, dbfactory.FileScheme:
+	default:
+		return env.NoRemote, errhand.BuildDError("error: '%s' is not valid. backups must be aws, gs or file urls.", backupUrl).Build()
+	}
+
+	params, verr := parseRemoteArgs(apr, scheme, absBackupUrl)
+
+	if verr != nil {
+		return env.NoRemote, verr
+	}
+
+	return env.NewRemote(backupName, absBackupUrl, params), nil
+}
+
+func removeBackup(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() != 2 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	old := strings.TrimSpace(apr.Arg(1))
+
+	if _, ok := dEnv.RepoState.Backups[old]; !ok {
+		return errhand.BuildDError("error: unknown backup " + old).Build()
+	}
+
+	delete(dEnv.RepoState.Backups, old)
+	err := dEnv.RepoState.Save(dEnv.FS)
+
+	if err != nil {
+		return errhand.BuildDError("error: unable to save changes.").AddCause(err).Build()
+	}
+
+	return nil
+}
+
+func syncBackup(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() != 2 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	backupName := strings.TrimSpace(apr.Arg(1))
+	b, ok := dEnv.RepoState.Backups[backupName]
+
+	if !ok {
+		return errhand.BuildDError("error: unknown backup " + backupName).Build()
+	}
+
+	backupDB, err := b.GetRemoteDB(ctx, dEnv.DoltDB.Format())
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get backup db").AddCause(err).Build()
+	}
+
+	err = runWithTableFileProgress(syncBackupProg, func(eventCh chan<- datas.TableFileEvent) error {
+		return actions.SyncBackup(ctx, dEnv, backupDB, eventCh)
+	})
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to sync backup '%s'", backupName).AddCause(err).Build()
+	}
+
+	return nil
+}
+
+func restoreBackup(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgParseResults) errhand.VerboseError {
+	if apr.NArg() != 3 {
+		return errhand.BuildDError("").SetPrintUsage().Build()
+	}
+
+	b, verr := newBackup(dEnv, apr, "", apr.Arg(1))
+
+	if verr != nil {
+		return verr
+	}
+
+	dir := apr.Arg(2)
+	backupDB, err := b.GetRemoteDB(ctx, types.Format_Default)
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get backup db").AddCause(err).Build()
+	}
+
+	dEnv, verr = envForClone(ctx, backupDB.Format(), env.NoRemote, dir, dEnv.FS, dEnv.Version)
+
+	if verr != nil {
+		return verr
+	}
+
+	err = runWithTableFileProgress(cloneProg, func(eventCh chan<- datas.TableFileEvent) error {
+		return actions.RestoreBackup(ctx, backupDB, dEnv, eventCh)
+	})
+
+	if err != nil {
+		// Make best effort to delete the directory we created.
+		_ = os.Chdir("../")
+		_ = dEnv.FS.Delete(dir, true)
+
+		if err == datas.ErrNoData {
+			err = actions.ErrNotABackup
+		}
+
+		return errhand.BuildDError("error: failed to restore backup").AddCause(err).Build()
+	}
+
+	return nil
+}
+
+// runWithTableFileProgress runs |f|, and prints the progress of the table files it copies with |prog|.
+func runWithTableFileProgress(prog func(<-chan datas.TableFileEvent), f func(eventCh chan<- datas.TableFileEvent) error) error {
+	eventCh := make(chan datas.TableFileEvent, 128)
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		prog(eventCh)
+	}()
+
+	err := f(eventCh)
+	close(eventCh)
+
+	wg.Wait()
+
+	return err
+}
+
+func syncBackupProg(eventCh <-chan datas.TableFileEvent) {
+	var (
+		files       int
+		filesSynced int
+		cliPos      int
+	)
+
+	for tblFEvt := range eventCh {
+		switch tblFEvt.EventType {
+		case datas.Listed:
+			files += len(tblFEvt.TableFiles)
+		case datas.DownloadSuccess:
+			filesSynced += len(tblFEvt.TableFiles)
+		default:
+			continue
+		}
+
+		cliPos = cli.DeleteAndPrint(cliPos, fmt.Sprintf("Uploaded %d of %d table files.", filesSynced, files))
+	}
+
+	if cliPos > 0 {
+		cli.Println()
+	}
+}
@@ -16,39 +16,42 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
 	"time"
 
 	pretty "github.com/jedib0t/go-pretty/table"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	eventsapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/eventsapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
-	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
-	"github.com/dolthub/dolt/go/libraries/doltcore/row"
-	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
-	"github.com/dolthub/dolt/go/libraries/doltcore/table"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
-	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
 var blameDocs = cli.CommandDocumentationContent{
 	ShortDesc: `Show what revision and author last modified each row of a table`,
-	LongDesc:  `Annotates each row in the given table with information from the revision which last modified the row. Optionally, start annotating from the given revision.`,
+	LongDesc: `Annotates each row in the given table with information from the revision which last modified the row. Optionally, start annotating from the given revision.
+
+If {{.EmphasisLeft}}--columns{{.EmphasisRight}} is given, each column of each row is annotated separately with information from the revision which last modified that column's value.`,
 	Synopsis: []string{
-		`[{{.LessThan}}rev{{.GreaterThan}}] {{.LessThan}}tablename{{.GreaterThan}}`,
+		`[--columns] [{{.LessThan}}rev{{.GreaterThan}}] {{.LessThan}}tablename{{.GreaterThan}}`,
 	},
 }
 
+const blameColumnsFlag = "columns"
+
 // blameInfo contains blame information for a row
 type blameInfo struct {
 	// Key represents the primary key of the row
 	Key types.Value
 
+	// Column is the name of the column this blame is for. It is empty when blaming whole rows.
+	Column string
+
 	// CommitHash is the commit hash of the commit which last modified the row
 	CommitHash string
 
@@ -72,8 +75,8 @@ func (bi *blameInfo) TimestampString() string {
 	return bi.TimestampTime().Format(time.UnixDate)
 }
 
-// A blame graph is a map of primary key hashes to blameInfo structs
-type blameGraph map[hash.Hash]blameInfo
+// A blame graph is a list of blameInfo structs, ordered by primary key
+type blameGraph []blameInfo
 
 type BlameCmd struct{}
 
@@ -95,6 +98,7 @@ func (cmd BlameCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string)
 
 func (cmd BlameCmd) createArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParser()
+	ap.SupportsFlag(blameColumnsFlag, "", "Annotate each column of each row with the revision which last modified its value.")
 	return ap
 }
 
@@ -106,19 +110,10 @@ func (cmd BlameCmd) EventType() eventsapi.ClientEventType {
 // Exec implements the `dolt blame` command. Blame annotates each row in the given table with information
 // from the revision which last modified the row, optionally starting from a given revision.
 //
-// Blame is computed as follows:
-//
-// First, a blame graph is initialized with one node for every row in the table at the given commit (defaulting
-// to HEAD of the currently checked-out branch).
-//
-// Starting from the given commit, walk backwards through the commit graph (currently by following each commit's
-// first parent, though this may change in the future).
-//
-// For each adjacent pair of commits `old` and `new`, check each remaining unblamed node to see if the row it represents
-// changed between the commits. If so, mark it with `new` as the blame origin and continue to the next node without blame.
-//
-// When all nodes have blame information, stop iterating through commits and print the blame graph.
-// Exec executes the command
+// Blame is computed by diff.BlameRows, starting from the given commit (defaulting to HEAD of the currently checked-out
+// branch) and walking backwards through the commit graph until every row of the table has been blamed on the commit
+// which last changed it. With --columns, diff.BlameCells is used instead, and each column of each row is blamed on the
+// commit which last changed its value.
 func (cmd BlameCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
 	ap := cmd.createArgParser()
 	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, blameDocs, ap))
@@ -135,7 +130,7 @@ func (cmd BlameCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return 1
 	}
 
-	if err := runBlame(ctx, dEnv, cs, tableName); err != nil {
+	if err := runBlame(ctx, dEnv, cs, tableName, apr.Contains(blameColumnsFlag)); err != nil {
 		cli.PrintErr(err)
 		return 1
 	}
@@ -166,13 +161,18 @@ func parseCommitSpecAndTableName(dEnv *env.DoltEnv, apr *argparser.ArgParseResul
 	return cs, tableName, nil
 }
 
-func runBlame(ctx context.Context, dEnv *env.DoltEnv, cs *doltdb.CommitSpec, tableName string) error {
+func runBlame(ctx context.Context, dEnv *env.DoltEnv, cs *doltdb.CommitSpec, tableName string, byColumn bool) error {
 	commit, err := dEnv.DoltDB.Resolve(ctx, cs, dEnv.RepoState.CWBHeadRef())
 	if err != nil {
 		return err
 	}
 
-	blameGraph, err := blameGraphFromCommit(ctx, dEnv, commit, tableName)
+	var blameGraph *blameGraph
+	if byColumn {
+		blameGraph, err = cellBlameGraphFromCommit(ctx, dEnv, commit, tableName)
+	} else {
+		blameGraph, err = blameGraphFromCommit(ctx, dEnv, commit, tableName)
+	}
 	if err != nil {
 		return err
 	}
@@ -182,322 +182,78 @@ func runBlame(ctx context.Context, dEnv *env.DoltEnv, cs *doltdb.CommitSpec, tab
 		return err
 	}
 
-	cli.Println(blameGraph.String(ctx, pkColNames))
+	cli.Println(blameGraph.String(ctx, pkColNames, byColumn))
 	return nil
 }
 
-type blameInput struct {
-	Commit       *doltdb.Commit
-	Hash         string
-	Parent       *doltdb.Commit
-	ParentHash   string
-	ParentSchema schema.Schema
-	ParentTable  *doltdb.Table
-	Table        *doltdb.Table
-	TableName    string
-	Schema       schema.Schema
-}
-
 func blameGraphFromCommit(ctx context.Context, dEnv *env.DoltEnv, commit *doltdb.Commit, tableName string) (*blameGraph, error) {
-	// get the commits in reverse topological order ending with `commit`
-	hash, err := commit.HashOf()
-	if err != nil {
-		return nil, err
-	}
-	commits, err := commitwalk.GetTopologicalOrderCommits(ctx, dEnv.DoltDB, hash)
-	if err != nil {
-		return nil, err
-	}
-
-	rows, err := rowsFromCommit(ctx, commit, tableName)
-	if err != nil {
-		return nil, err
-	}
-
-	tbl, err := maybeTableFromCommit(ctx, commit, tableName)
-	if err != nil {
-		return nil, err
-	}
-	if tbl == nil {
+	blame, err := diff.BlameRows(ctx, dEnv.DoltDB, commit, tableName)
+	if errors.Is(err, doltdb.ErrTableNotFound) {
 		return nil, fmt.Errorf("no table named %s found", tableName)
-	}
-
-	nbf := tbl.Format()
-
-	blameGraph, err := blameGraphFromRows(ctx, nbf, rows)
-	if err != nil {
-		return nil, err
-	}
-
-	// precompute blame inputs for each commit
-	blameInputs, err := blameInputsFromCommits(ctx, dEnv, tableName, commits)
-	if err != nil {
+	} else if err != nil {
 		return nil, err
 	}
 
-ROWLOOP:
-	for _, node := range *blameGraph {
-		for _, blameInput := range *blameInputs {
-			// did the node change between the commit-parent pair represented by blameInput?
-			changed, err := rowChanged(ctx, blameInput, node.Key)
-			if err != nil {
-				return nil, err
-			}
-
-			// if so, mark the commit as the blame origin
-			if changed {
-				blameGraph.AssignBlame(node.Key, nbf, blameInput.Commit)
-				continue ROWLOOP
-			}
+	graph := make(blameGraph, len(blame))
+	for i, rb := range blame {
+		graph[i] = blameInfo{
+			Key:         rb.Key,
+			CommitHash:  rb.CommitHash.String(),
+			Author:      rb.Meta.Name,
+			Description: rb.Meta.Description,
+			Timestamp:   rb.Meta.UserTimestamp,
 		}
-		// didn't find blame for a row...something's wrong
-		return nil, fmt.Errorf("couldn't find blame for row with primary key %v", strings.Join(getPKStrs(ctx, node.Key), ", "))
 	}
 
-	return blameGraph, nil
-}
-
-func blameInputsFromCommits(ctx context.Context, dEnv *env.DoltEnv, tableName string, commits []*doltdb.Commit) (*[]blameInput, error) {
-	numCommits := len(commits)
-	blameInputs := make([]blameInput, numCommits)
-	for i, c := range commits {
-		// don't precompute inputs for the initial commit; we don't need them
-		if i == numCommits-1 {
-			break
-		}
-
-		parent, err := dEnv.DoltDB.ResolveParent(ctx, c, 0)
-		if err != nil {
-			return nil, err
-		}
-
-		parentHash, hash, err := getCommitHashes(parent, c)
-		if err != nil {
-			return nil, err
-		}
-
-		tbl, err := maybeTableFromCommit(ctx, c, tableName)
-		if err != nil {
-			return nil, fmt.Errorf("error getting table from child commit %s: %v", hash, err)
-		}
-		parentTbl, err := maybeTableFromCommit(ctx, parent, tableName)
-		if err != nil {
-			return nil, fmt.Errorf("error getting table from parent commit %s: %v", parentHash, err)
-		}
-
-		var s schema.Schema
-		if tbl != nil {
-			s, err = tbl.GetSchema(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("error getting schema from table %s in child commit %s: %v", tableName, hash, err)
-			}
-		}
-
-		var parentSchema schema.Schema
-		if parentTbl != nil {
-			parentSchema, err = parentTbl.GetSchema(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("error getting schema from table %s in parent commit %s: %v", tableName, parentHash, err)
-			}
-		}
-
-		blameInputs[i] = blameInput{
-			Commit:       c,
-			Hash:         hash,
-			Parent:       parent,
-			ParentHash:   parentHash,
-			ParentSchema: parentSchema,
-			ParentTable:  parentTbl,
-			Table:        tbl,
-			TableName:    tableName,
-			Schema:       s,
-		}
-	}
-	return &blameInputs, nil
+	return &graph, nil
 }
 
-// rowsFromCommit returns the row data of the table with the given name at the given commit
-func rowsFromCommit(ctx context.Context, commit *doltdb.Commit, tableName string) (types.Map, error) {
-	root, err := commit.GetRootValue()
-	if err != nil {
-		return types.EmptyMap, err
-	}
-
-	table, ok, err := root.GetTable(ctx, tableName)
-	if err != nil {
-		return types.EmptyMap, err
-	}
-	if !ok {
-		return types.EmptyMap, fmt.Errorf("no table named %s found", tableName)
+func cellBlameGraphFromCommit(ctx context.Context, dEnv *env.DoltEnv, commit *doltdb.Commit, tableName string) (*blameGraph, error) {
+	blame, sch, err := diff.BlameCells(ctx, dEnv.DoltDB, commit, tableName)
+	if errors.Is(err, doltdb.ErrTableNotFound) {
+		return nil, fmt.Errorf("no table named %s found", tableName)
+	} else if err != nil {
+		return nil, err
 	}
 
-	rowData, err := table.GetRowData(ctx)
-	if err != nil {
-		return types.EmptyMap, err
+	var graph blameGraph
+	for _, rb := range blame {
+		for _, cb := range rb.Cells {
+			col, _ := sch.GetAllCols().GetByTag(cb.Tag)
+			graph = append(graph, blameInfo{
+				Key:         rb.Key,
+				Column:      col.Name,
+				CommitHash:  cb.CommitHash.String(),
+				Author:      cb.Meta.Name,
+				Description: cb.Meta.Description,
+				Timestamp:   cb.Meta.UserTimestamp,
+			})
+		}
 	}
 
-	return rowData, nil
-}
-
-func getCommitHashes(old, new *doltdb.Commit) (string, string, error) {
-	oldHash, err := old.HashOf()
-	if err != nil {
-		return "", "", fmt.Errorf("error getting hash of old commit: %v", err)
-	}
-	newHash, err := new.HashOf()
-	if err != nil {
-		return "", "", fmt.Errorf("error getting hash of new commit: %v", err)
-	}
-	return oldHash.String(), newHash.String(), nil
+	return &graph, nil
 }
 
-// maybeTableFromCommit takes a commit and a table name and returns a (possibly nil) pointer to a table
-func maybeTableFromCommit(ctx context.Context, c *doltdb.Commit, tableName string) (*doltdb.Table, error) {
+func pkColNamesFromCommit(ctx context.Context, c *doltdb.Commit, tableName string) ([]string, error) {
 	root, err := c.GetRootValue()
 	if err != nil {
 		return nil, fmt.Errorf("error getting root value of commit: %v", err)
 	}
-	table, _, err := root.GetTable(ctx, tableName)
-	if err != nil {
-		return nil, fmt.Errorf("error getting table %s from root value: %v", tableName, err)
-	}
-	return table, nil
-}
 
-// maybeRowFromTable takes a table and a primary key and returns a (possibly nil) pointer to a row
-func maybeRowFromTable(ctx context.Context, t *doltdb.Table, rowPK types.Value) (*row.Row, error) {
-	sch, err := t.GetSchema(ctx)
+	t, ok, err := root.GetTable(ctx, tableName)
 	if err != nil {
-		return nil, fmt.Errorf("error getting schema from table: %v", err)
-	}
-
-	r, ok, err := table.GetRow(ctx, t, sch, rowPK.(types.Tuple))
-	if err != nil {
-		return nil, fmt.Errorf("error getting row from table: %v", err)
+		return nil, fmt.Errorf("error getting table %s from root value: %v", tableName, err)
 	}
 	if !ok {
-		return nil, nil
-	}
-
-	return &r, err
-}
-
-func schemaFromCommit(ctx context.Context, c *doltdb.Commit, tableName string) (schema.Schema, error) {
-	t, err := maybeTableFromCommit(ctx, c, tableName)
-	if err != nil {
-		return nil, fmt.Errorf("error getting table %s from commit: %v", tableName, err)
-	}
-	if t == nil {
 		return nil, fmt.Errorf("no table named %s found in commit", tableName)
 	}
 
-	schema, err := t.GetSchema(ctx)
+	sch, err := t.GetSchema(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("error getting schema from table %s: %v", tableName, err)
 	}
 
-	return schema, nil
-}
-
-func pkColNamesFromCommit(ctx context.Context, c *doltdb.Commit, tableName string) ([]string, error) {
-	schema, err := schemaFromCommit(ctx, c, tableName)
-	if err != nil {
-		return nil, fmt.Errorf("error getting schema for commit: %v", err)
-	}
-	return schema.GetPKCols().GetColumnNames(), nil
-}
-
-// rowChanged returns true if the row identified by `rowPK` changed between the parent-child commit pair
-// represented by `input`
-func rowChanged(ctx context.Context, input blameInput, rowPK types.Value) (bool, error) {
-	parentTable := input.ParentTable
-	childTable := input.Table
-
-	// if the table is in the parent commit but not the child one...something's wrong. bail!
-	if parentTable != nil && childTable == nil {
-		return false, fmt.Errorf("expected to find table with name %v in child commit %s, but didn't", input.TableName, input.Hash)
-	}
-	// if the table is in the child commit but not the parent one, it must be new; return true
-	if childTable != nil && parentTable == nil {
-		return true, nil
-	}
-
-	if input.Schema == nil {
-		return false, fmt.Errorf("unexpected nil schema for table %s in child commit %s", input.TableName, input.Hash)
-	}
-	if input.ParentSchema == nil {
-		return false, fmt.Errorf("unexpected nil schema for table %s in parent commit %s", input.TableName, input.ParentHash)
-	}
-
-	// if the table schema has changed, every row has changed (according to our current definition of blame)
-	if !schema.SchemasAreEqual(input.ParentSchema, input.Schema) {
-		return true, nil
-	}
-
-	parentRow, err := maybeRowFromTable(ctx, parentTable, rowPK)
-	if err != nil {
-		return false, fmt.Errorf("error getting row from %s in parent commit %s: %v", input.TableName, input.ParentHash, err)
-	}
-	childRow, err := maybeRowFromTable(ctx, childTable, rowPK)
-	if err != nil {
-		return false, fmt.Errorf("error getting row from %s in child commit %s: %v", input.TableName, input.Hash, err)
-	}
-
-	// if the row is in the parent table but not the child one...something's wrong. bail!
-	if parentRow != nil && childRow == nil {
-		return false, fmt.Errorf("expected to find row with PK %v in table %s in child commit %s, but didn't", rowPK, input.TableName, input.Hash)
-	}
-	// if the row is in the child table but not the parent one, it must be new; return true
-	if childRow != nil && parentRow == nil {
-		return true, nil
-	}
-
-	return !row.AreEqual(*parentRow, *childRow, input.ParentSchema), nil
-}
-
-func blameGraphFromRows(ctx context.Context, nbf *types.NomsBinFormat, rows types.Map) (*blameGraph, error) {
-	graph := make(blameGraph)
-	err := rows.IterAll(ctx, func(key, val types.Value) error {
-		hash, err := key.Hash(nbf)
-		if err != nil {
-			return err
-		}
-		graph[hash] = blameInfo{Key: key}
-		return nil
-	})
-	if err != nil {
-		return nil, err
-	}
-	return &graph, nil
-}
-
-// AssignBlame updates the blame graph to contain blame information from the given commit
-// for the row identified by the given primary key
-func (bg *blameGraph) AssignBlame(rowPK types.Value, nbf *types.NomsBinFormat, c *doltdb.Commit) error {
-	commitHash, err := c.HashOf()
-	if err != nil {
-		return fmt.Errorf("error getting commit hash: %v", err)
-	}
-
-	meta, err := c.GetCommitMeta()
-	if err != nil {
-		return fmt.Errorf("error getting metadata for commit %s: %v", commitHash.String(), err)
-	}
-
-	pkHash, err := rowPK.Hash(nbf)
-	if err != nil {
-		return fmt.Errorf("error getting PK hash for commit %s: %v", commitHash.String(), err)
-	}
-
-	(*bg)[pkHash] = blameInfo{
-		Key:         rowPK,
-		CommitHash:  commitHash.String(),
-		Author:      meta.Name,
-		Description: meta.Description,
-		Timestamp:   meta.UserTimestamp,
-	}
-
-	return nil
+	return sch.GetPKCols().GetColumnNames(), nil
 }
 
 func getPKStrs(ctx context.Context, pk types.Value) (strs []string) {
@@ -532,12 +288,19 @@ func truncateString(str string, maxLength int) string {
 
 var dataColNames = []string{"Commit Msg", "Author", "Time", "Commit"}
 
-// String returns the string representation of this blame graph
-func (bg *blameGraph) String(ctx context.Context, pkColNames []string) string {
+// String returns the string representation of this blame graph. If |showColumn| is true, the name of the column each
+// entry blames is included after the primary key.
+func (bg *blameGraph) String(ctx context.Context, pkColNames []string, showColumn bool) string {
 	// here we have two []string and need one []interface{} (aka table.Row)
 	// this works but is not beautiful. if you know a better way, have at it!
 	header := []interface{}{}
-	for _, cellText := range append(pkColNames, dataColNames...) {
+	for _, cellText := range pkColNames {
+		header = append(header, cellText)
+	}
+	if showColumn {
+		header = append(header, "Column")
+	}
+	for _, cellText := range dataColNames {
 		header = append(header, cellText)
 	}
 
@@ -556,6 +319,9 @@ func (bg *blameGraph) String(ctx context.Context, pkColNames []string) string {
 		for _, cellText := range pkVals {
 			row = append(row, cellText)
 		}
+		if showColumn {
+			row = append(row, v.Column)
+		}
 		for _, cellText := range dataVals {
 			row = append(row, cellText)
 		}
@@ -98,7 +98,7 @@ func (cmd BranchCmd) createArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(moveFlag, "m", "Move/rename a branch")
 	ap.SupportsFlag(deleteFlag, "d", "Delete a branch. The branch must be fully merged in its upstream branch.")
 	ap.SupportsFlag(deleteForceFlag, "", "Shortcut for {{.EmphasisLeft}}--delete --force{{.EmphasisRight}}.")
-	ap.SupportsFlag(verboseFlag, "v", "When in list mode, show the hash and commit subject line for each head")
+	ap.SupportsFlag(verboseFlag, "v", "When in list mode, show the hash and commit subject line for each head, along with the number of commits it is ahead and behind its upstream branch (if any)")
 	ap.SupportsFlag(allFlag, "a", "When in list mode, shows remote tracked branches")
 	ap.SupportsFlag(remoteFlag, "r", "When in list mode, show only remote tracked branches. When with -d, delete a remote tracking branch.")
 	ap.SupportsFlag(showCurrentFlag, "", "Print the name of the current branch")
@@ -195,6 +195,16 @@ func printBranches(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPar
 
 				commitStr = h.String()
 			}
+
+			if branch.GetType() == ref.BranchRefType {
+				upstream, err := actions.GetUpstreamStatus(ctx, dEnv.DoltDB, dEnv.RepoState.Branches, branch.GetPath())
+
+				if err != nil {
+					return HandleVErrAndExitCode(errhand.BuildDError("error: failed to compare %s to its upstream", branch.GetPath()).AddCause(err).Build(), nil)
+				}
+
+				commitStr += upstreamTrackingStr(upstream)
+			}
 		}
 
 		fmtStr := fmt.Sprintf("%%s%%%ds\t%%s", 48-branchLen)
@@ -206,6 +216,28 @@ func printBranches(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPar
 	return 0
 }
 
+// upstreamTrackingStr returns the number of commits a branch is ahead and behind its upstream, e.g. " [ahead 1, behind 2]".
+// It is empty if the branch has no upstream or is up to date with it.
+func upstreamTrackingStr(upstream *actions.UpstreamStatus) string {
+	if upstream == nil {
+		return ""
+	}
+
+	var counts []string
+	if upstream.Ahead > 0 {
+		counts = append(counts, fmt.Sprintf("ahead %d", upstream.Ahead))
+	}
+	if upstream.Behind > 0 {
+		counts = append(counts, fmt.Sprintf("behind %d", upstream.Behind))
+	}
+
+	if len(counts) == 0 {
+		return ""
+	}
+
+	return " [" + strings.Join(counts, ", ") + "]"
+}
+
 func printCurrentBranch(dEnv *env.DoltEnv) int {
 	cli.Println(dEnv.RepoState.CWBHeadRef().GetPath())
 	return 0
@@ -21,6 +21,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
@@ -36,6 +37,8 @@ import (
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/earl"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/libraries/utils/funcitr"
+	"github.com/dolthub/dolt/go/libraries/utils/set"
 	"github.com/dolthub/dolt/go/libraries/utils/strhelp"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/types"
@@ -44,6 +47,7 @@ import (
 const (
 	remoteParam = "remote"
 	branchParam = "branch"
+	tablesParam = "tables"
 )
 
 var cloneDocs = cli.CommandDocumentationContent{
@@ -53,9 +57,15 @@ var cloneDocs = cli.CommandDocumentationContent{
 After the clone, a plain {{.EmphasisLeft}}dolt fetch{{.EmphasisRight}} without arguments will update all the remote-tracking branches, and a {{.EmphasisLeft}}dolt pull{{.EmphasisRight}} without arguments will in addition merge the remote branch into the current branch.
 
 This default configuration is achieved by creating references to the remote branch heads under {{.LessThan}}refs/remotes/origin{{.GreaterThan}}  and by creating a remote named 'origin'.
+
+With {{.EmphasisLeft}}--depth{{.EmphasisRight}}, a shallow clone is created, which only has the last {{.LessThan}}depth{{.GreaterThan}} commits of the history of the cloned branch, and which only tracks that branch. The rest of the history can be fetched later with {{.EmphasisLeft}}dolt fetch --unshallow{{.EmphasisRight}}.
+
+With {{.EmphasisLeft}}--tables{{.EmphasisRight}}, a sparse clone is created, which has the full history of commits, but only the data and schemas of the listed tables and of the dolt system tables. Reading any other table fails, and later fetches and pulls only fetch the listed tables.
+
+If a clone is interrupted while it downloads data, the data which was already downloaded is kept in the new directory. Running the same clone command again resumes the clone, and only downloads the data which is still missing.
 `,
 	Synopsis: []string{
-		"[-remote {{.LessThan}}remote{{.GreaterThan}}] [-branch {{.LessThan}}branch{{.GreaterThan}}]  [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}remote-url{{.GreaterThan}} {{.LessThan}}new-dir{{.GreaterThan}}",
+		"[-remote {{.LessThan}}remote{{.GreaterThan}}] [-branch {{.LessThan}}branch{{.GreaterThan}}] [--depth {{.LessThan}}depth{{.GreaterThan}}] [--tables {{.LessThan}}table1,table2,...{{.GreaterThan}}] [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] [--ca-bundle {{.LessThan}}file{{.GreaterThan}}] {{.LessThan}}remote-url{{.GreaterThan}} {{.LessThan}}new-dir{{.GreaterThan}}",
 	},
 }
 
@@ -87,10 +97,13 @@ func (cmd CloneCmd) createArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParser()
 	ap.SupportsString(remoteParam, "", "name", "Name of the remote to be added. Default will be 'origin'.")
 	ap.SupportsString(branchParam, "b", "branch", "The branch to be cloned.  If not specified all branches will be cloned.")
+	ap.SupportsInt(DepthParam, "", "depth", "Create a shallow clone with a history truncated to the specified number of commits. Only the cloned branch is fetched.")
+	ap.SupportsString(tablesParam, "", "table1,table2,...", "Create a sparse clone which only has the data of the specified tables, along with the dolt system tables.")
 	ap.SupportsString(dbfactory.AWSRegionParam, "", "region", "")
 	ap.SupportsValidatedString(dbfactory.AWSCredsTypeParam, "", "creds-type", "", argparser.ValidatorFromStrList(dbfactory.AWSCredsTypeParam, credTypes))
 	ap.SupportsString(dbfactory.AWSCredsFileParam, "", "file", "AWS credentials file.")
 	ap.SupportsString(dbfactory.AWSCredsProfile, "", "profile", "AWS profile to use.")
+	ap.SupportsString(dbfactory.CABundleParam, "", "file", "A file of PEM encoded certificates of certificate authorities which are trusted to sign the certificate of an https remote, in addition to the system's certificate authorities.")
 	return ap
 }
 
@@ -109,6 +122,16 @@ func (cmd CloneCmd) Exec(ctx context.Context, commandStr string, args []string,
 	branch := apr.GetValueOrDefault(branchParam, "")
 	dir, urlStr, verr := parseArgs(apr)
 
+	var depth int
+	if verr == nil {
+		depth, verr = parseDepth(apr)
+	}
+
+	var tables []string
+	if val, ok := apr.GetValue(tablesParam); ok {
+		tables = funcitr.MapStrings(strings.Split(val, ","), strings.TrimSpace)
+	}
+
 	scheme, remoteUrl, err := getAbsRemoteUrl(dEnv.FS, dEnv.Config, urlStr)
 
 	if err != nil {
@@ -128,7 +151,7 @@ func (cmd CloneCmd) Exec(ctx context.Context, commandStr string, args []string,
 				dEnv, verr = envForClone(ctx, srcDB.ValueReadWriter().Format(), r, dir, dEnv.FS, dEnv.Version)
 
 				if verr == nil {
-					verr = cloneRemote(ctx, srcDB, remoteName, branch, dEnv)
+					verr = cloneRemote(ctx, srcDB, remoteName, branch, depth, tables, dEnv)
 
 					if verr == nil {
 						evt := events.GetEventFromContext(ctx)
@@ -141,8 +164,9 @@ func (cmd CloneCmd) Exec(ctx context.Context, commandStr string, args []string,
 						}
 					}
 
-					// Make best effort to delete the directory we created.
-					if verr != nil {
+					// Make best effort to delete the directory we created, unless the clone was interrupted while
+					// downloading data, which is kept so that the clone can be resumed.
+					if _, interrupted := verr.(cloneInterruptedError); verr != nil && !interrupted {
 						_ = os.Chdir("../")
 						_ = dEnv.FS.Delete(dir, true)
 					}
@@ -185,7 +209,14 @@ func envForClone(ctx context.Context, nbf *types.NomsBinFormat, r env.Remote, di
 	exists, _ := fs.Exists(filepath.Join(dir, dbfactory.DoltDir))
 
 	if exists {
-		return nil, errhand.BuildDError("error: data repository already exists at " + dir).Build()
+		dEnv, ok := envForResumedClone(ctx, r, dir, fs, version)
+
+		if !ok {
+			return nil, errhand.BuildDError("error: data repository already exists at " + dir).Build()
+		}
+
+		cli.Printf("resuming the interrupted clone in %s\n", dir)
+		return dEnv, nil
 	}
 
 	err := fs.MkDirs(dir)
@@ -219,6 +250,45 @@ func envForClone(ctx context.Context, nbf *types.NomsBinFormat, r env.Remote, di
 	return dEnv, nil
 }
 
+// envForResumedClone loads the repository in |dir| if it is an interrupted clone of the remote |r|, so that the clone
+// can be resumed. The returned bool is false if it is not.
+func envForResumedClone(ctx context.Context, r env.Remote, dir string, fs filesys.Filesys, version string) (*env.DoltEnv, bool) {
+	cwd, err := os.Getwd()
+
+	if err != nil {
+		return nil, false
+	}
+
+	err = os.Chdir(dir)
+
+	if err != nil {
+		return nil, false
+	}
+
+	dEnv := env.Load(ctx, env.GetCurrentUserHomeDir, fs, doltdb.LocalDirDoltDB, version)
+
+	if dEnv.RSLoadErr == nil && dEnv.DBLoadError == nil && dEnv.RepoState.CloneInProgress {
+		if cloned, ok := dEnv.RepoState.Remotes[r.Name]; ok && cloned.Url == r.Url {
+			return dEnv, true
+		}
+	}
+
+	_ = os.Chdir(cwd)
+	return nil, false
+}
+
+// cloneInterruptedError is returned when a clone fails while it downloads data. The data which was already downloaded
+// is kept, and running the clone again resumes it.
+type cloneInterruptedError struct {
+	errhand.VerboseError
+}
+
+func newCloneInterruptedError(err error) errhand.VerboseError {
+	return cloneInterruptedError{errhand.BuildDError("error: clone failed").
+		AddDetails("The data which was already downloaded was kept. Run the same clone command again to resume the clone.").
+		AddCause(err).Build()}
+}
+
 func createRemote(ctx context.Context, remoteName, remoteUrl string, params map[string]string) (env.Remote, *doltdb.DoltDB, errhand.VerboseError) {
 	cli.Printf("cloning %s\n", remoteUrl)
 
@@ -275,7 +345,11 @@ func cloneProg(eventCh <-chan datas.TableFileEvent) {
 	cli.Println()
 }
 
-func cloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch string, dEnv *env.DoltEnv) errhand.VerboseError {
+func cloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch string, depth int, tables []string, dEnv *env.DoltEnv) errhand.VerboseError {
+	if depth > 0 || len(tables) > 0 {
+		return fetchCloneRemote(ctx, srcDB, remoteName, branch, depth, tables, dEnv)
+	}
+
 	eventCh := make(chan datas.TableFileEvent, 128)
 
 	wg := &sync.WaitGroup{}
@@ -293,9 +367,10 @@ func cloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch s
 	if err != nil {
 		if err == datas.ErrNoData {
 			err = errors.New("remote at that url contains no Dolt data")
+			return errhand.BuildDError("error: clone failed").AddCause(err).Build()
 		}
 
-		return errhand.BuildDError("error: clone failed").AddCause(err).Build()
+		return newCloneInterruptedError(err)
 	}
 
 	branches, err := dEnv.DoltDB.GetBranches(ctx)
@@ -363,8 +438,132 @@ func cloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch s
 		}
 	}
 
-	if performPull {
-		err = actions.SaveDocsFromRoot(ctx, rootVal, dEnv)
+	return checkoutClonedBranch(ctx, dEnv, remoteName, branch, rootVal, performPull)
+}
+
+// fetchCloneRemote clones the remote by fetching its branches, instead of copying its table files, which allows
+// limiting what is cloned. If |depth| is not 0, only |branch|, or the master branch if no branch is given, is cloned,
+// with only the last |depth| commits of its history. If |tables| is not empty, only those tables are cloned, along
+// with the dolt system tables.
+func fetchCloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch string, depth int, tables []string, dEnv *env.DoltEnv) errhand.VerboseError {
+	branches, err := srcDB.GetBranches(ctx)
+	if err != nil {
+		return errhand.BuildDError("error: failed to list branches").AddCause(err).Build()
+	}
+
+	if branch == "" {
+		for _, brnch := range branches {
+			branch = brnch.GetPath()
+			if branch == doltdb.MasterBranch {
+				break
+			}
+		}
+	}
+
+	// An empty remote has nothing to leave out.
+	if branch == "" {
+		return cloneRemote(ctx, srcDB, remoteName, branch, 0, nil, dEnv)
+	}
+
+	if depth > 0 {
+		branches = []ref.DoltRef{ref.NewBranchRef(branch)}
+	}
+
+	if len(tables) > 0 {
+		verr := checkSparseTables(ctx, srcDB, branch, tables)
+		if verr != nil {
+			return verr
+		}
+
+		dEnv.RepoState.Sparse = tables
+		dEnv.DoltDB.SetEnforceCompleteness(false)
+		dEnv.DoltDB.SetSparse(tables)
+	}
+
+	for _, brnch := range branches {
+		cs, _ := doltdb.NewCommitSpec(brnch.GetPath())
+		srcDBCommit, err := srcDB.Resolve(ctx, cs, nil)
+		if err != nil {
+			return errhand.BuildDError("error: could not get " + brnch.GetPath()).AddCause(err).Build()
+		}
+
+		wg, progChan, pullerEventCh := runProgFuncs()
+		if depth > 0 {
+			err = actions.FetchCommitShallow(ctx, dEnv, srcDB, dEnv.DoltDB, srcDBCommit, depth, progChan, pullerEventCh)
+		} else {
+			err = actions.FetchCommit(ctx, dEnv, srcDB, dEnv.DoltDB, srcDBCommit, progChan, pullerEventCh)
+		}
+		stopProgFuncs(wg, progChan, pullerEventCh)
+
+		if err != nil {
+			return newCloneInterruptedError(err)
+		}
+
+		remoteRef := ref.NewRemoteRef(remoteName, brnch.GetPath())
+		err = dEnv.DoltDB.SetHeadToCommit(ctx, remoteRef, srcDBCommit)
+		if err != nil {
+			return errhand.BuildDError("error: could not create remote ref at " + remoteRef.String()).AddCause(err).Build()
+		}
+
+		if brnch.GetPath() == branch {
+			err = dEnv.DoltDB.SetHeadToCommit(ctx, ref.NewBranchRef(branch), srcDBCommit)
+			if err != nil {
+				return errhand.BuildDError("error: could not create branch " + branch).AddCause(err).Build()
+			}
+		}
+	}
+
+	verr := fetchFollowTags(ctx, dEnv, srcDB, dEnv.DoltDB)
+	if verr != nil {
+		return verr
+	}
+
+	cm, err := dEnv.DoltDB.ResolveRef(ctx, ref.NewBranchRef(branch))
+	if err != nil {
+		return errhand.BuildDError("error: could not get " + branch).AddCause(err).Build()
+	}
+
+	rootVal, err := cm.GetRootValue()
+	if err != nil {
+		return errhand.BuildDError("error: could not get the root value of " + branch).AddCause(err).Build()
+	}
+
+	return checkoutClonedBranch(ctx, dEnv, remoteName, branch, rootVal, true)
+}
+
+// checkSparseTables checks that the |tables| of a sparse clone exist on |branch| of the remote.
+func checkSparseTables(ctx context.Context, srcDB *doltdb.DoltDB, branch string, tables []string) errhand.VerboseError {
+	cs, _ := doltdb.NewCommitSpec(branch)
+	cm, err := srcDB.Resolve(ctx, cs, nil)
+	if err != nil {
+		return errhand.BuildDError("error: could not get " + branch).AddCause(err).Build()
+	}
+
+	rootVal, err := cm.GetRootValue()
+	if err != nil {
+		return errhand.BuildDError("error: could not get the root value of " + branch).AddCause(err).Build()
+	}
+
+	names, err := rootVal.GetTableNames(ctx)
+	if err != nil {
+		return errhand.BuildDError("error: could not list the tables of " + branch).AddCause(err).Build()
+	}
+
+	nameSet := set.NewCaseInsensitiveStrSet(names)
+	for _, tbl := range tables {
+		if !nameSet.Contains(tbl) {
+			return errhand.BuildDError("error: table '%s' not found on branch '%s'", tbl, branch).Build()
+		}
+	}
+
+	return nil
+}
+
+// checkoutClonedBranch checks out the |branch| cloned from the remote |remoteName|, whose root value is |rootVal|, and
+// makes it track the remote branch.
+func checkoutClonedBranch(ctx context.Context, dEnv *env.DoltEnv, remoteName, branch string, rootVal *doltdb.RootValue, saveDocs bool) errhand.VerboseError {
+	if saveDocs {
+		err := actions.SaveDocsFromRoot(ctx, rootVal, dEnv)
 		if err != nil {
 			return errhand.BuildDError("error: failed to update docs on the filesystem").AddCause(err).Build()
 		}
@@ -378,6 +577,13 @@ func cloneRemote(ctx context.Context, srcDB *doltdb.DoltDB, remoteName, branch s
 	dEnv.RepoState.Head = ref.MarshalableRef{Ref: ref.NewBranchRef(branch)}
 	dEnv.RepoState.Staged = h.String()
 	dEnv.RepoState.Working = h.String()
+	dEnv.RepoState.CloneInProgress = false
+
+	// the checked out branch tracks the branch it was cloned from
+	dEnv.RepoState.Branches[branch] = env.BranchConfig{
+		Merge:  ref.MarshalableRef{Ref: ref.NewBranchRef(branch)},
+		Remote: remoteName,
+	}
 
 	err = dEnv.RepoState.Save(dEnv.FS)
 	if err != nil {
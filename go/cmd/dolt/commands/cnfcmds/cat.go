@@ -16,6 +16,7 @@ package cnfcmds
 
 import (
 	"context"
+	"strings"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands"
@@ -155,6 +156,10 @@ func printConflicts(ctx context.Context, root *doltdb.RootValue, tblNames []stri
 				return errhand.BuildDError("error: unable to read database").AddCause(err).Build()
 			}
 
+			if tblName == doltdb.DocTableName {
+				return printDocConflicts(ctx, tbl)
+			}
+
 			cnfRd, err := merge.NewConflictReader(ctx, tbl)
 
 			if err == doltdb.ErrNoConflicts {
@@ -220,3 +225,23 @@ func printConflicts(ctx context.Context, root *doltdb.RootValue, tblNames []stri
 
 	return nil
 }
+
+// printDocConflicts prints each conflicting doc with conflict markers around its overlapping changes.
+func printDocConflicts(ctx context.Context, docTbl *doltdb.Table) errhand.VerboseError {
+	docCnfs, err := merge.GetDocConflicts(ctx, docTbl)
+
+	if err != nil {
+		return errhand.BuildDError("failed to read conflicts").AddCause(err).Build()
+	}
+
+	for _, docCnf := range docCnfs {
+		cli.Println("doc:", docCnf.DocName)
+		cli.Print(docCnf.Text)
+
+		if !strings.HasSuffix(docCnf.Text, "\n") {
+			cli.Println()
+		}
+	}
+
+	return nil
+}
@@ -41,6 +41,8 @@ When a merge operation finds conflicting changes, the rows with the conflicts ar
 In its first form {{.EmphasisLeft}}dolt conflicts resolve <table> <key>...{{.EmphasisRight}}, resolve runs in manual merge mode resolving the conflicts whose keys are provided.
 
 In its second form {{.EmphasisLeft}}dolt conflicts resolve --ours|--theirs <table>...{{.EmphasisRight}}, resolve runs in auto resolve mode. Where conflicts are resolved using a rule to determine which version of a row should be used.
+
+When the schemas of a table conflict, none of its rows have been merged. Resolving such a table with {{.EmphasisLeft}}--ours{{.EmphasisRight}} keeps the table as it is in the working set, while {{.EmphasisLeft}}--theirs{{.EmphasisRight}} replaces it with their version of the table. To merge both versions instead, alter the table so that its schema no longer conflicts and delete its row from the {{.EmphasisLeft}}dolt_schema_conflicts{{.EmphasisRight}} system table.
 `,
 	Synopsis: []string{
 		`{{.LessThan}}table{{.GreaterThan}} [{{.LessThan}}key_definition{{.GreaterThan}}] {{.LessThan}}key{{.GreaterThan}}...`,
@@ -48,21 +50,11 @@ In its second form {{.EmphasisLeft}}dolt conflicts resolve --ours|--theirs <tabl
 	},
 }
 
-const (
-	oursFlag   = "ours"
-	theirsFlag = "theirs"
-)
-
-var autoResolvers = map[string]merge.AutoResolver{
-	oursFlag:   merge.Ours,
-	theirsFlag: merge.Theirs,
-}
-
 var autoResolverParams []string
 
 func init() {
-	autoResolverParams = make([]string, 0, len(autoResolvers))
-	for k := range autoResolvers {
+	autoResolverParams = make([]string, 0, len(merge.AutoResolvers))
+	for k := range merge.AutoResolvers {
 		autoResolverParams = append(autoResolverParams, k)
 	}
 }
@@ -91,13 +83,7 @@ func (cmd ResolveCmd) EventType() eventsapi.ClientEventType {
 }
 
 func (cmd ResolveCmd) createArgParser() *argparser.ArgParser {
-	ap := argparser.NewArgParser()
-	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"table", "List of tables to be printed. When in auto-resolve mode, '.' can be used to resolve all tables."})
-	ap.ArgListHelp = append(ap.ArgListHelp, [2]string{"key", "key(s) of rows within a table whose conflicts have been resolved"})
-	ap.SupportsFlag("ours", "", "For all conflicts, take the version from our branch and resolve the conflict")
-	ap.SupportsFlag("theirs", "", "For all conflicts, take the version from their branch and resolve the conflict")
-
-	return ap
+	return cli.CreateConflictsResolveArgParser()
 }
 
 // Exec executes the command
@@ -127,14 +113,15 @@ func autoResolve(ctx context.Context, apr *argparser.ArgParseResults, dEnv *env.
 	}
 
 	autoResolveFlag := funcFlags.AsSlice()[0]
-	autoResolveFunc := autoResolvers[autoResolveFlag]
+	autoResolveFunc := merge.AutoResolvers[autoResolveFlag]
+	schResolveFunc := merge.SchemaConflictResolvers[autoResolveFlag]
 
 	var err error
 	tbls := apr.Args()
 	if len(tbls) == 1 && tbls[0] == "." {
-		err = actions.AutoResolveAll(ctx, dEnv, autoResolveFunc)
+		err = actions.AutoResolveAll(ctx, dEnv, autoResolveFunc, schResolveFunc)
 	} else {
-		err = actions.AutoResolveTables(ctx, dEnv, autoResolveFunc, tbls)
+		err = actions.AutoResolveTables(ctx, dEnv, autoResolveFunc, schResolveFunc, tbls)
 	}
 
 	if err != nil {
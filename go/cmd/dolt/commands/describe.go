@@ -0,0 +1,112 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+var describeDocs = cli.CommandDocumentationContent{
+	ShortDesc: `Give a commit a human readable name based on an available tag`,
+	LongDesc: `Finds the most recent tag that is reachable from a commit. If the tag points to the commit, then only the tag is shown. Otherwise, the tag name is suffixed with the number of additional commits on top of the tagged commit and the hash of the commit, in the format {{.LessThan}}tag{{.GreaterThan}}-{{.LessThan}}n{{.GreaterThan}}-g{{.LessThan}}hash{{.GreaterThan}}.
+
+By default only annotated tags, which were created with a message, are used. With {{.EmphasisLeft}}--tags{{.EmphasisRight}} any tag is used.
+
+When no commit is given, HEAD is described. With {{.EmphasisLeft}}--dirty{{.EmphasisRight}} the suffix "-dirty" is appended if the working set has changes which are not in HEAD.`,
+	Synopsis: []string{
+		`[--tags] [--long] [{{.LessThan}}commit{{.GreaterThan}}]`,
+		`[--tags] [--long] --dirty`,
+	},
+}
+
+type DescribeCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd DescribeCmd) Name() string {
+	return "describe"
+}
+
+// Description returns a description of the command
+func (cmd DescribeCmd) Description() string {
+	return "Name a commit after the nearest tag."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd DescribeCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cli.CreateDescribeArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, describeDocs, ap))
+}
+
+// Exec executes the command
+func (cmd DescribeCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cli.CreateDescribeArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, describeDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() > 1 {
+		return HandleVErrAndExitCode(errhand.BuildDError("dolt describe takes at most one commit").SetPrintUsage().Build(), usage)
+	}
+
+	if apr.Contains(cli.DirtyFlag) && apr.NArg() > 0 {
+		return HandleVErrAndExitCode(errhand.BuildDError("--%s can only be used when describing HEAD", cli.DirtyFlag).SetPrintUsage().Build(), usage)
+	}
+
+	cSpecStr := "HEAD"
+	if apr.NArg() == 1 {
+		cSpecStr = apr.Arg(0)
+	}
+
+	cm, verr := ResolveCommitWithVErr(dEnv, cSpecStr)
+
+	if verr != nil {
+		return HandleVErrAndExitCode(verr, usage)
+	}
+
+	opts := actions.DescribeOpts{AllTags: apr.Contains(cli.TagsFlag), Long: apr.Contains(cli.LongFlag)}
+	name, err := actions.Describe(ctx, dEnv.DoltDB, cm, opts)
+
+	if err == actions.ErrNoTagsToDescribe || err == actions.ErrNoAnnotatedTagsToDescribe {
+		return HandleVErrAndExitCode(errhand.BuildDError("fatal: %s", err.Error()).Build(), usage)
+	} else if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to describe '%s'", cSpecStr).AddCause(err).Build(), usage)
+	}
+
+	if apr.Contains(cli.DirtyFlag) {
+		working, err := dEnv.WorkingRoot(ctx)
+
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("Unable to get working.").AddCause(err).Build(), usage)
+		}
+
+		dirty, err := actions.IsWorkingSetDirty(working, cm)
+
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to compare the working set to HEAD").AddCause(err).Build(), usage)
+		}
+
+		if dirty {
+			name += actions.DirtySuffix
+		}
+	}
+
+	cli.Println(name)
+	return 0
+}
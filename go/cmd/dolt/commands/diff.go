@@ -63,6 +63,8 @@ const (
 
 	TabularDiffOutput diffOutput = 1
 	SQLDiffOutput     diffOutput = 2
+	JSONDiffOutput    diffOutput = 3
+	CSVDiffOutput     diffOutput = 4
 
 	DataFlag    = "data"
 	SchemaFlag  = "schema"
@@ -71,6 +73,7 @@ const (
 	limitParam  = "limit"
 	SQLFlag     = "sql"
 	CachedFlag  = "cached"
+	InlineFlag  = "inline"
 )
 
 type DiffSink interface {
@@ -96,6 +99,10 @@ Show changes between the working and staged tables, changes between the working
 The diffs displayed can be limited to show the first N by providing the parameter {{.EmphasisLeft}}--limit N{{.EmphasisRight}} where {{.EmphasisLeft}}N{{.EmphasisRight}} is the number of diffs to display.
 
 In order to filter which diffs are displayed {{.EmphasisLeft}}--where key=value{{.EmphasisRight}} can be used.  The key in this case would be either {{.EmphasisLeft}}to_COLUMN_NAME{{.EmphasisRight}} or {{.EmphasisLeft}}from_COLUMN_NAME{{.EmphasisRight}}. where {{.EmphasisLeft}}from_COLUMN_NAME=value{{.EmphasisRight}} would filter based on the original value and {{.EmphasisLeft}}to_COLUMN_NAME{{.EmphasisRight}} would select based on its updated value.
+
+Modified cells are highlighted in their entirety by default. Use {{.EmphasisLeft}}--inline{{.EmphasisRight}} to highlight only the words that changed within each modified cell, which is helpful for long text values.
+
+The output format can be changed with {{.EmphasisLeft}}--result-format{{.EmphasisRight}}. {{.EmphasisLeft}}json{{.EmphasisRight}} outputs a single JSON document with the schema changes and row diffs of each table. {{.EmphasisLeft}}csv{{.EmphasisRight}} outputs the row diffs of each table as CSV with a {{.EmphasisLeft}}diff_type{{.EmphasisRight}} column followed by a {{.EmphasisLeft}}from_COLUMN_NAME{{.EmphasisRight}} and {{.EmphasisLeft}}to_COLUMN_NAME{{.EmphasisRight}} column for each column in the table. When more than one table has changed, the CSV for each table is separated by an empty line.
 `,
 	Synopsis: []string{
 		`[options] [{{.LessThan}}commit{{.GreaterThan}}] [{{.LessThan}}tables{{.GreaterThan}}...]`,
@@ -106,6 +113,7 @@ In order to filter which diffs are displayed {{.EmphasisLeft}}--where key=value{
 type diffArgs struct {
 	diffParts  diffPart
 	diffOutput diffOutput
+	inline     bool
 	tableSet   *set.StrSet
 	docSet     *set.StrSet
 	limit      int
@@ -141,10 +149,11 @@ func (cmd DiffCmd) createArgParser() *argparser.ArgParser {
 	ap.SupportsFlag(DataFlag, "d", "Show only the data changes, do not show the schema changes (Both shown by default).")
 	ap.SupportsFlag(SchemaFlag, "s", "Show only the schema changes, do not show the data changes (Both shown by default).")
 	ap.SupportsFlag(SummaryFlag, "", "Show summary of data changes")
-	ap.SupportsString(FormatFlag, "r", "result output format", "How to format diff output. Valid values are tabular & sql. Defaults to tabular. ")
+	ap.SupportsString(FormatFlag, "r", "result output format", "How to format diff output. Valid values are tabular, sql, json & csv. Defaults to tabular. ")
 	ap.SupportsString(whereParam, "", "column", "filters columns based on values in the diff.  See {{.EmphasisLeft}}dolt diff --help{{.EmphasisRight}} for details.")
 	ap.SupportsInt(limitParam, "", "record_count", "limits to the first N diffs.")
 	ap.SupportsFlag(CachedFlag, "c", "Show only the unstaged data changes.")
+	ap.SupportsFlag(InlineFlag, "", "Highlight only the changed words within modified cells, rather than the entire cell. Only applies to tabular output.")
 	return ap
 }
 
@@ -166,6 +175,11 @@ func (cmd DiffCmd) Exec(ctx context.Context, commandStr string, args []string, d
 		return HandleVErrAndExitCode(verr, usage)
 	}
 
+	// docs diffs are printed as text, which would corrupt json and csv output
+	if dArgs.diffOutput == JSONDiffOutput || dArgs.diffOutput == CSVDiffOutput {
+		return 0
+	}
+
 	err = diffDoltDocs(ctx, dEnv, fromRoot, toRoot, dArgs)
 
 	if err != nil {
@@ -213,6 +227,10 @@ func parseDiffArgs(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPar
 		dArgs.diffOutput = TabularDiffOutput
 	case "sql":
 		dArgs.diffOutput = SQLDiffOutput
+	case "json":
+		dArgs.diffOutput = JSONDiffOutput
+	case "csv":
+		dArgs.diffOutput = CSVDiffOutput
 	case "":
 		dArgs.diffOutput = TabularDiffOutput
 	default:
@@ -223,9 +241,19 @@ func parseDiffArgs(ctx context.Context, dEnv *env.DoltEnv, apr *argparser.ArgPar
 		if apr.Contains(SchemaFlag) || apr.Contains(DataFlag) {
 			return nil, nil, nil, fmt.Errorf("invalid Arguments: --summary cannot be combined with --schema or --data")
 		}
+		if dArgs.diffOutput == JSONDiffOutput || dArgs.diffOutput == CSVDiffOutput {
+			return nil, nil, nil, fmt.Errorf("invalid Arguments: --summary cannot be combined with --%s %s", FormatFlag, strings.ToLower(f))
+		}
 		dArgs.diffParts = Summary
 	}
 
+	if apr.Contains(InlineFlag) {
+		if dArgs.diffOutput != TabularDiffOutput {
+			return nil, nil, nil, fmt.Errorf("invalid Arguments: --%s can only be used with tabular output", InlineFlag)
+		}
+		dArgs.inline = true
+	}
+
 	dArgs.limit, _ = apr.GetInt(limitParam)
 	dArgs.where = apr.GetValueOrDefault(whereParam, "")
 
@@ -310,9 +338,11 @@ func getDiffRoots(ctx context.Context, dEnv *env.DoltEnv, args []string, isCache
 		return from, to, nil, nil
 	}
 
-	from, ok := maybeResolve(ctx, dEnv, args[0])
+	from, ok, err := maybeResolve(ctx, dEnv, args[0])
 
-	if !ok {
+	if err != nil {
+		return nil, nil, nil, err
+	} else if !ok {
 		// `dolt diff ...tables`
 		from = stagedRoot
 		to = workingRoot
@@ -333,9 +363,11 @@ func getDiffRoots(ctx context.Context, dEnv *env.DoltEnv, args []string, isCache
 		return from, to, nil, nil
 	}
 
-	to, ok = maybeResolve(ctx, dEnv, args[1])
+	to, ok, err = maybeResolve(ctx, dEnv, args[1])
 
-	if !ok {
+	if err != nil {
+		return nil, nil, nil, err
+	} else if !ok {
 		// `dolt diff from_commit ...tables`
 		to = workingRoot
 		if isCached {
@@ -351,23 +383,26 @@ func getDiffRoots(ctx context.Context, dEnv *env.DoltEnv, args []string, isCache
 }
 
 // todo: distinguish between non-existent CommitSpec and other errors, don't assume non-existent
-func maybeResolve(ctx context.Context, dEnv *env.DoltEnv, spec string) (*doltdb.RootValue, bool) {
+// An error is only returned for a commit spec which reaches past the boundary of a shallow clone.
+func maybeResolve(ctx context.Context, dEnv *env.DoltEnv, spec string) (*doltdb.RootValue, bool, error) {
 	cs, err := doltdb.NewCommitSpec(spec)
 	if err != nil {
-		return nil, false
+		return nil, false, nil
 	}
 
 	cm, err := dEnv.DoltDB.Resolve(ctx, cs, dEnv.RepoState.CWBHeadRef())
-	if err != nil {
-		return nil, false
+	if err == doltdb.ErrShallowBoundary {
+		return nil, false, fmt.Errorf("cannot resolve %s: %w", spec, err)
+	} else if err != nil {
+		return nil, false, nil
 	}
 
 	root, err := cm.GetRootValue()
 	if err != nil {
-		return nil, false
+		return nil, false, nil
 	}
 
-	return root, true
+	return root, true, nil
 }
 
 func diffUserTables(ctx context.Context, fromRoot, toRoot *doltdb.RootValue, dArgs *diffArgs) (verr errhand.VerboseError) {
@@ -378,6 +413,15 @@ func diffUserTables(ctx context.Context, fromRoot, toRoot *doltdb.RootValue, dAr
 		return errhand.BuildDError("error: unable to diff tables").AddCause(err).Build()
 	}
 
+	var jsonWr *diff.JSONDiffWriter
+	if dArgs.diffOutput == JSONDiffOutput {
+		jsonWr, err = diff.NewJSONDiffWriter(iohelp.NopWrCloser(cli.CliOut))
+		if err != nil {
+			return errhand.BuildDError("error: unable to write diff").AddCause(err).Build()
+		}
+	}
+
+	csvTablesWritten := 0
 	for _, td := range tableDeltas {
 
 		if dArgs.diffOutput == SQLDiffOutput {
@@ -422,13 +466,30 @@ func diffUserTables(ctx context.Context, fromRoot, toRoot *doltdb.RootValue, dAr
 			return errhand.BuildDError("cannot retrieve schema for table %s", td.ToName).AddCause(err).Build()
 		}
 
+		if jsonWr != nil {
+			err = jsonWr.BeginTable(td)
+			if err != nil {
+				return errhand.BuildDError("error: unable to write diff for table %s", td.CurName()).AddCause(err).Build()
+			}
+		}
+
 		if dArgs.diffParts&Summary != 0 {
 			numCols := fromSch.GetAllCols().Size()
 			verr = diffSummary(ctx, td, numCols)
 		}
 
 		if dArgs.diffParts&SchemaOnlyDiff != 0 {
-			verr = diffSchemas(ctx, fromRoot, toRoot, td, dArgs)
+			switch dArgs.diffOutput {
+			case JSONDiffOutput:
+				err = jsonWr.WriteSchemaDiff(fromSch, toSch)
+				if err != nil {
+					verr = errhand.BuildDError("error: unable to write schema diff for table %s", td.CurName()).AddCause(err).Build()
+				}
+			case CSVDiffOutput:
+				// csv output only contains row data
+			default:
+				verr = diffSchemas(ctx, fromRoot, toRoot, td, dArgs)
+			}
 		}
 
 		if dArgs.diffParts&DataOnlyDiff != 0 {
@@ -437,12 +498,34 @@ func diffUserTables(ctx context.Context, fromRoot, toRoot *doltdb.RootValue, dAr
 			} else if td.IsAdd() {
 				fromSch = toSch
 			}
-			verr = diffRows(ctx, td, dArgs)
+
+			if dArgs.diffOutput == CSVDiffOutput {
+				if csvTablesWritten != 0 {
+					cli.Println()
+				}
+				csvTablesWritten++
+			}
+
+			verr = diffRows(ctx, fromRoot, toRoot, td, dArgs, jsonWr)
 		}
 
 		if verr != nil {
 			return verr
 		}
+
+		if jsonWr != nil {
+			err = jsonWr.EndTable()
+			if err != nil {
+				return errhand.BuildDError("error: unable to write diff for table %s", td.CurName()).AddCause(err).Build()
+			}
+		}
+	}
+
+	if jsonWr != nil {
+		err = jsonWr.Close()
+		if err != nil {
+			return errhand.BuildDError("error: unable to write diff").AddCause(err).Build()
+		}
 	}
 
 	return nil
@@ -662,7 +745,7 @@ func fromNamer(name string) string {
 	return diff.From + "_" + name
 }
 
-func diffRows(ctx context.Context, td diff.TableDelta, dArgs *diffArgs) errhand.VerboseError {
+func diffRows(ctx context.Context, fromRoot, toRoot *doltdb.RootValue, td diff.TableDelta, dArgs *diffArgs, jsonWr *diff.JSONDiffWriter) errhand.VerboseError {
 	fromSch, toSch, err := td.GetSchemas(ctx)
 	if err != nil {
 		return errhand.BuildDError("cannot retrieve schema for table %s", td.ToName).AddCause(err).Build()
@@ -676,9 +759,22 @@ func diffRows(ctx context.Context, td diff.TableDelta, dArgs *diffArgs) errhand.
 		return errhand.BuildDError("could not get row data for table %s", td.ToName).AddCause(err).Build()
 	}
 
+	ss, err := diffSuperSchema(ctx, fromRoot, toRoot, td)
+	if err != nil {
+		return errhand.BuildDError("cannot retrieve super schema for table %s", td.CurName()).AddCause(err).Build()
+	}
+
+	// rows of the from table are aligned with the columns of the to table, so that columns which were given new tags
+	// when their type changed are diffed cell by cell
+	vrw := types.NewMemoryValueStore() // We don't want to persist anything, so we use an internal store
+	alignedFromSch, fromConv, err := diff.AlignFromSchema(ctx, vrw, ss, fromSch, toSch, fromRows)
+	if err != nil {
+		return errhand.BuildDError("cannot align columns of table %s", td.CurName()).AddCause(err).Build()
+	}
+
 	joiner, err := rowconv.NewJoiner(
 		[]rowconv.NamedSchema{
-			{Name: diff.From, Sch: fromSch},
+			{Name: diff.From, Sch: alignedFromSch},
 			{Name: diff.To, Sch: toSch},
 		},
 		map[string]rowconv.ColNamingFunc{diff.To: toNamer, diff.From: fromNamer},
@@ -688,8 +784,7 @@ func diffRows(ctx context.Context, td diff.TableDelta, dArgs *diffArgs) errhand.
 		return errhand.BuildDError("").AddCause(err).Build()
 	}
 
-	vrw := types.NewMemoryValueStore() // We don't want to persist anything, so we use an internal store
-	unionSch, ds, verr := createSplitter(ctx, vrw, fromSch, toSch, joiner, dArgs)
+	unionSch, ds, verr := createSplitter(ctx, vrw, alignedFromSch, toSch, joiner, dArgs)
 	if verr != nil {
 		return verr
 	}
@@ -700,9 +795,10 @@ func diffRows(ctx context.Context, td diff.TableDelta, dArgs *diffArgs) errhand.
 	defer rd.Close()
 
 	src := diff.NewRowDiffSource(rd, joiner)
+	src.AddInputRowConversion(fromConv, rowconv.IdentityConverter)
 	defer src.Close()
 
-	oldColNames, verr := mapTagToColName(fromSch, unionSch)
+	oldColNames, verr := mapTagToColName(alignedFromSch, unionSch)
 
 	if verr != nil {
 		return verr
@@ -721,9 +817,18 @@ func diffRows(ctx context.Context, td diff.TableDelta, dArgs *diffArgs) errhand.
 	}
 
 	var sink DiffSink
-	if dArgs.diffOutput == TabularDiffOutput {
-		sink, err = diff.NewColorDiffSink(iohelp.NopWrCloser(cli.CliOut), unionSch, numHeaderRows)
-	} else {
+	switch dArgs.diffOutput {
+	case TabularDiffOutput:
+		if dArgs.inline {
+			sink, err = diff.NewInlineColorDiffSink(iohelp.NopWrCloser(cli.CliOut), unionSch, numHeaderRows)
+		} else {
+			sink, err = diff.NewColorDiffSink(iohelp.NopWrCloser(cli.CliOut), unionSch, numHeaderRows)
+		}
+	case JSONDiffOutput:
+		sink, err = jsonWr.BeginDataDiff(joiner)
+	case CSVDiffOutput:
+		sink, err = diff.NewCSVDiffSink(iohelp.NopWrCloser(cli.CliOut), joiner)
+	default:
 		sink, err = diff.NewSQLDiffSink(iohelp.NopWrCloser(cli.CliOut), unionSch, td.CurName())
 	}
 
@@ -744,7 +849,7 @@ func diffRows(ctx context.Context, td diff.TableDelta, dArgs *diffArgs) errhand.
 		return verr
 	}
 
-	if dArgs.diffOutput != SQLDiffOutput {
+	if dArgs.diffOutput == TabularDiffOutput {
 		if schemasEqual {
 			schRow, err := untyped.NewRowFromTaggedStrings(toRows.Format(), unionSch, newColNames)
 
@@ -783,6 +888,31 @@ func diffRows(ctx context.Context, td diff.TableDelta, dArgs *diffArgs) errhand.
 	return nil
 }
 
+// diffSuperSchema returns the union of the super schemas of the table in |fromRoot| and |toRoot|, which contains every
+// name each column of the table has had in the history of either root.
+func diffSuperSchema(ctx context.Context, fromRoot, toRoot *doltdb.RootValue, td diff.TableDelta) (*schema.SuperSchema, error) {
+	var superSchemas []*schema.SuperSchema
+	for _, tr := range []struct {
+		root    *doltdb.RootValue
+		tblName string
+	}{{fromRoot, td.FromName}, {toRoot, td.ToName}} {
+		if tr.root == nil || tr.tblName == "" {
+			continue
+		}
+
+		ss, ok, err := tr.root.GetSuperSchema(ctx, tr.tblName)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			superSchemas = append(superSchemas, ss)
+		}
+	}
+
+	return schema.SuperSchemaUnion(superSchemas...)
+}
+
 func buildPipeline(dArgs *diffArgs, joiner *rowconv.Joiner, ds *diff.DiffSplitter, untypedUnionSch schema.Schema, src *diff.RowDiffSource, sink DiffSink, badRowCB pipeline.BadRowCallback) (*pipeline.Pipeline, errhand.VerboseError) {
 	var where FilterFn
 	var selTrans *SelectTransform
@@ -805,9 +935,12 @@ func buildPipeline(dArgs *diffArgs, joiner *rowconv.Joiner, ds *diff.DiffSplitte
 		transforms.AppendTransforms(pipeline.NewNamedTransform("select", selTrans.LimitAndFilter))
 	}
 
-	transforms.AppendTransforms(
-		pipeline.NewNamedTransform("split_diffs", ds.SplitDiffIntoOldAndNew),
-	)
+	// json and csv sinks consume joined rows, writing the from and to values of a diff together
+	if dArgs.diffOutput != JSONDiffOutput && dArgs.diffOutput != CSVDiffOutput {
+		transforms.AppendTransforms(
+			pipeline.NewNamedTransform("split_diffs", ds.SplitDiffIntoOldAndNew),
+		)
+	}
 
 	if dArgs.diffOutput == TabularDiffOutput {
 		nullPrinter := nullprinter.NewNullPrinter(untypedUnionSch)
@@ -32,6 +32,8 @@ import (
 
 const (
 	ForceFetchFlag = "force"
+	DepthParam     = "depth"
+	UnshallowFlag  = "unshallow"
 )
 
 var fetchDocs = cli.CommandDocumentationContent{
@@ -41,10 +43,13 @@ var fetchDocs = cli.CommandDocumentationContent{
 By default dolt will attempt to fetch from a remote named {{.EmphasisLeft}}origin{{.EmphasisRight}}.  The {{.LessThan}}remote{{.GreaterThan}} parameter allows you to specify the name of a different remote you wish to pull from by the remote's name.
 
 When no refspec(s) are specified on the command line, the fetch_specs for the default remote are used.
+
+With {{.EmphasisLeft}}--depth{{.EmphasisRight}}, only the last {{.LessThan}}depth{{.GreaterThan}} commits of the history of each remote branch are fetched, and the repository becomes shallow. The history below the shallow commits is not shown by {{.EmphasisLeft}}dolt log{{.EmphasisRight}}, and can be fetched later with {{.EmphasisLeft}}--unshallow{{.EmphasisRight}}.
 `,
 
 	Synopsis: []string{
-		"[{{.LessThan}}remote{{.GreaterThan}}] [{{.LessThan}}refspec{{.GreaterThan}} ...]",
+		"[--depth {{.LessThan}}depth{{.GreaterThan}}] [{{.LessThan}}remote{{.GreaterThan}}] [{{.LessThan}}refspec{{.GreaterThan}} ...]",
+		"--unshallow [{{.LessThan}}remote{{.GreaterThan}}] [{{.LessThan}}refspec{{.GreaterThan}} ...]",
 	},
 }
 
@@ -74,6 +79,8 @@ func (cmd FetchCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string)
 func (cmd FetchCmd) createArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParser()
 	ap.SupportsFlag(ForceFetchFlag, "f", "Update refs to remote branches with the current state of the remote, overwriting any conflicting history.")
+	ap.SupportsInt(DepthParam, "", "depth", "Limit fetching to the specified number of commits from the tip of each remote branch history.")
+	ap.SupportsFlag(UnshallowFlag, "", "Fetch the history which is missing below the shallow commits of a shallow repository, making it complete.")
 	return ap
 }
 
@@ -88,8 +95,27 @@ func (cmd FetchCmd) Exec(ctx context.Context, commandStr string, args []string,
 
 	updateMode := ref.RefUpdateMode{Force: apr.Contains(ForceFetchFlag)}
 
+	unshallow := apr.Contains(UnshallowFlag)
+
+	var depth int
+	if verr == nil {
+		depth, verr = parseDepth(apr)
+	}
+
+	if verr == nil && unshallow {
+		if depth > 0 {
+			verr = errhand.BuildDError("error: --depth and --unshallow cannot be used together").SetPrintUsage().Build()
+		} else if !dEnv.RepoState.IsShallow() {
+			verr = errhand.BuildDError("error: --unshallow on a complete repository does not make sense").Build()
+		}
+	}
+
 	if verr == nil {
-		verr = fetchRefSpecs(ctx, updateMode, dEnv, r, refSpecs)
+		verr = fetchRefSpecs(ctx, updateMode, dEnv, r, refSpecs, depth)
+	}
+
+	if verr == nil && unshallow {
+		verr = fetchUnshallow(ctx, dEnv, r)
 	}
 
 	return HandleVErrAndExitCode(verr, usage)
@@ -178,7 +204,22 @@ func mapRefspecsToRemotes(refSpecs []ref.RemoteRefSpec, dEnv *env.DoltEnv) (map[
 	return rsToRem, nil
 }
 
-func fetchRefSpecs(ctx context.Context, mode ref.RefUpdateMode, dEnv *env.DoltEnv, rem env.Remote, refSpecs []ref.RemoteRefSpec) errhand.VerboseError {
+// parseDepth returns the value of the --depth option, or 0 if it was not given.
+func parseDepth(apr *argparser.ArgParseResults) (int, errhand.VerboseError) {
+	depth, ok := apr.GetInt(DepthParam)
+
+	if !ok {
+		return 0, nil
+	} else if depth <= 0 {
+		return 0, errhand.BuildDError("error: depth %d is not a positive number", depth).Build()
+	}
+
+	return depth, nil
+}
+
+// fetchRefSpecs fetches the branches of the remote |rem| matching |refSpecs|. If |depth| is not 0, only the last
+// |depth| commits of the history of each branch are fetched.
+func fetchRefSpecs(ctx context.Context, mode ref.RefUpdateMode, dEnv *env.DoltEnv, rem env.Remote, refSpecs []ref.RemoteRefSpec, depth int) errhand.VerboseError {
 	srcDB, err := rem.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format())
 
 	if err != nil {
@@ -197,7 +238,7 @@ func fetchRefSpecs(ctx context.Context, mode ref.RefUpdateMode, dEnv *env.DoltEn
 			remoteTrackRef := rs.DestRef(branchRef)
 
 			if remoteTrackRef != nil {
-				srcDBCommit, verr := fetchRemoteBranch(ctx, dEnv, rem, srcDB, dEnv.DoltDB, branchRef, remoteTrackRef)
+				srcDBCommit, verr := fetchRemoteBranch(ctx, dEnv, rem, srcDB, dEnv.DoltDB, branchRef, remoteTrackRef, depth)
 
 				if verr != nil {
 					return verr
@@ -232,7 +273,7 @@ func fetchRefSpecs(ctx context.Context, mode ref.RefUpdateMode, dEnv *env.DoltEn
 	return nil
 }
 
-func fetchRemoteBranch(ctx context.Context, dEnv *env.DoltEnv, rem env.Remote, srcDB, destDB *doltdb.DoltDB, srcRef, destRef ref.DoltRef) (*doltdb.Commit, errhand.VerboseError) {
+func fetchRemoteBranch(ctx context.Context, dEnv *env.DoltEnv, rem env.Remote, srcDB, destDB *doltdb.DoltDB, srcRef, destRef ref.DoltRef, depth int) (*doltdb.Commit, errhand.VerboseError) {
 	evt := events.GetEventFromContext(ctx)
 
 	u, err := earl.Parse(rem.Url)
@@ -250,7 +291,11 @@ func fetchRemoteBranch(ctx context.Context, dEnv *env.DoltEnv, rem env.Remote, s
 		return nil, errhand.BuildDError("error: unable to find '%s' on '%s'", srcRef.GetPath(), rem.Name).Build()
 	} else {
 		wg, progChan, pullerEventCh := runProgFuncs()
-		err = actions.FetchCommit(ctx, dEnv, srcDB, destDB, srcDBCommit, progChan, pullerEventCh)
+		if depth > 0 {
+			err = actions.FetchCommitShallow(ctx, dEnv, srcDB, destDB, srcDBCommit, depth, progChan, pullerEventCh)
+		} else {
+			err = actions.FetchCommit(ctx, dEnv, srcDB, destDB, srcDBCommit, progChan, pullerEventCh)
+		}
 		stopProgFuncs(wg, progChan, pullerEventCh)
 
 		if err != nil {
@@ -261,6 +306,26 @@ func fetchRemoteBranch(ctx context.Context, dEnv *env.DoltEnv, rem env.Remote, s
 	return srcDBCommit, nil
 }
 
+// fetchUnshallow fetches the history which is missing below the shallow commits of the repository from the remote
+// |rem|.
+func fetchUnshallow(ctx context.Context, dEnv *env.DoltEnv, rem env.Remote) errhand.VerboseError {
+	srcDB, err := rem.GetRemoteDB(ctx, dEnv.DoltDB.ValueReadWriter().Format())
+
+	if err != nil {
+		return errhand.BuildDError("error: failed to get remote db").AddCause(err).Build()
+	}
+
+	wg, progChan, pullerEventCh := runProgFuncs()
+	err = actions.FetchUnshallow(ctx, dEnv, srcDB, dEnv.DoltDB, progChan, pullerEventCh)
+	stopProgFuncs(wg, progChan, pullerEventCh)
+
+	if err != nil {
+		return errhand.BuildDError("error: fetch failed").AddCause(err).Build()
+	}
+
+	return nil
+}
+
 // fetchFollowTags fetches all tags from the source DB whose commits have already
 // been fetched into the destination DB.
 // todo: potentially too expensive to iterate over all srcDB tags
@@ -153,7 +153,10 @@ func parseCommitSpec(dEnv *env.DoltEnv, apr *argparser.ArgParseResults) (*doltdb
 func logCommits(ctx context.Context, dEnv *env.DoltEnv, cs *doltdb.CommitSpec, loggerFunc commitLoggerFunc, numLines int) int {
 	commit, err := dEnv.DoltDB.Resolve(ctx, cs, dEnv.RepoState.CWBHeadRef())
 
-	if err != nil {
+	if err == doltdb.ErrShallowBoundary {
+		cli.PrintErrln(color.HiRedString("Fatal error: " + err.Error()))
+		return 1
+	} else if err != nil {
 		cli.PrintErrln(color.HiRedString("Fatal error: cannot get HEAD commit for current branch."))
 		return 1
 	}
@@ -410,38 +410,25 @@ func fkConstraintWarning(ctx context.Context, cm1, cm2 *doltdb.Commit) errhand.V
 
 	var warnTables []string
 	for _, name := range allNames.AsSlice() {
-		tbl1, ok1, err := r1.GetTable(ctx, name)
+		h1, ok1, err := r1.GetTableHash(ctx, name)
 
 		if err != nil {
 			return verrBuild.AddCause(err).Build()
 		}
 
-		tbl2, ok2, err := r2.GetTable(ctx, name)
+		h2, ok2, err := r2.GetTableHash(ctx, name)
 
 		if err != nil {
 			return verrBuild.AddCause(err).Build()
 		}
 
-		var h1, h2 hash.Hash
 		var fkOnTbl1, fkOnTbl2 bool
 		if ok1 {
-			h1, err = tbl1.HashOf()
-
-			if err != nil {
-				return verrBuild.AddCause(err).Build()
-			}
-
 			decl, refd := fks1.KeysForTable(name)
 			fkOnTbl1 = (len(decl) + len(refd)) > 0
 		}
 
 		if ok2 {
-			h2, err = tbl2.HashOf()
-
-			if err != nil {
-				return verrBuild.AddCause(err).Build()
-			}
-
 			decl, refd := fks2.KeysForTable(name)
 			fkOnTbl2 = (len(decl) + len(refd)) > 0
 		}
@@ -495,13 +482,15 @@ func mergedRootToWorking(ctx context.Context, squash bool, dEnv *env.DoltEnv, me
 	if verr == nil {
 		hasConflicts := printSuccessStats(tblToStats)
 
+		// docs are written even if there are conflicts, so that conflict markers in them can be resolved on disk
+		err = actions.SaveDocsFromWorkingExcludingFSChanges(ctx, dEnv, unstagedDocs)
+		if err != nil {
+			return errhand.BuildDError("error: failed to update docs to the new working root").AddCause(err).Build()
+		}
+
 		if hasConflicts {
 			cli.Println("Automatic merge failed; fix conflicts and then commit the result.")
 		} else {
-			err = actions.SaveDocsFromWorkingExcludingFSChanges(ctx, dEnv, unstagedDocs)
-			if err != nil {
-				return errhand.BuildDError("error: failed to update docs to the new working root").AddCause(err).Build()
-			}
 			verr = UpdateStagedWithVErr(dEnv.DoltDB, dEnv.RepoStateWriter(), mergedRoot)
 			if verr != nil {
 				// Log a new message here to indicate that merge was successful, only staging failed.
@@ -522,7 +511,7 @@ func printSuccessStats(tblToStats map[string]*merge.MergeStats) bool {
 
 func printAdditions(tblToStats map[string]*merge.MergeStats) {
 	for tblName, stats := range tblToStats {
-		if stats.Operation == merge.TableRemoved {
+		if stats.Operation == merge.TableAdded {
 			cli.Println(tblName, "added")
 		}
 	}
@@ -539,7 +528,11 @@ func printDeletions(tblToStats map[string]*merge.MergeStats) {
 func printConflicts(tblToStats map[string]*merge.MergeStats) bool {
 	hasConflicts := false
 	for tblName, stats := range tblToStats {
-		if stats.Operation == merge.TableModified && stats.Conflicts > 0 {
+		if stats.Operation == merge.TableModified && stats.SchemaConflicts > 0 {
+			cli.Println("CONFLICT (schema): Merge conflict in", tblName)
+
+			hasConflicts = true
+		} else if stats.Operation == merge.TableModified && stats.Conflicts > 0 {
 			cli.Println("Auto-merging", tblName)
 			cli.Println("CONFLICT (content): Merge conflict in", tblName)
 
@@ -558,7 +551,7 @@ func printModifications(tblToStats map[string]*merge.MergeStats) {
 	rowsChanged := 0
 	var tbls []string
 	for tblName, stats := range tblToStats {
-		if stats.Operation == merge.TableModified && stats.Conflicts == 0 {
+		if stats.Operation == merge.TableModified && stats.Conflicts == 0 && stats.SchemaConflicts == 0 {
 			tbls = append(tbls, tblName)
 			nameLen := len(tblName)
 			modCount := stats.Adds + stats.Modifications + stats.Deletes + stats.Conflicts
@@ -0,0 +1,158 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+const (
+	isAncestorFlag = "is-ancestor"
+	octopusFlag    = "octopus"
+
+	// mergeBaseErrExitCode is the exit code for errors, which must be distinguishable from the exit code of 1 used when
+	// there is no merge base or a commit is not an ancestor.
+	mergeBaseErrExitCode = 128
+)
+
+var mergeBaseDocs = cli.CommandDocumentationContent{
+	ShortDesc: `Find as good common ancestors as possible for a merge`,
+	LongDesc: `{{.EmphasisLeft}}dolt merge-base{{.EmphasisRight}} finds the best common ancestor between two commits to use in a three-way merge. A common ancestor is better than another common ancestor if the latter is an ancestor of the former. A common ancestor that does not have any better common ancestor is a best common ancestor, i.e. a merge base. The hash of the merge base is printed. If the commits have no common ancestor nothing is printed and the exit status is 1.
+
+Histories with criss-cross merges can have more than one merge base. In that case one of them is printed, unless {{.EmphasisLeft}}--all{{.EmphasisRight}} is given.
+
+With {{.EmphasisLeft}}--octopus{{.EmphasisRight}} the best common ancestors of all the given commits are computed, as would be used for a merge of all of them at once.
+
+With {{.EmphasisLeft}}--is-ancestor{{.EmphasisRight}} nothing is printed. Instead the exit status is 0 if the first commit is an ancestor of the second commit, and 1 if it is not. A commit is an ancestor of itself. Errors are signaled by an exit status of 128.`,
+	Synopsis: []string{
+		`[--all] {{.LessThan}}commit{{.GreaterThan}} {{.LessThan}}commit{{.GreaterThan}}`,
+		`[--all] --octopus {{.LessThan}}commit{{.GreaterThan}}...`,
+		`--is-ancestor {{.LessThan}}commit{{.GreaterThan}} {{.LessThan}}commit{{.GreaterThan}}`,
+	},
+}
+
+type MergeBaseCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd MergeBaseCmd) Name() string {
+	return "merge-base"
+}
+
+// Description returns a description of the command
+func (cmd MergeBaseCmd) Description() string {
+	return "Find the common ancestors of commits."
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd MergeBaseCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, mergeBaseDocs, ap))
+}
+
+func (cmd MergeBaseCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.SupportsFlag(cli.AllFlag, "a", "Output all merge bases for the commits, instead of just one.")
+	ap.SupportsFlag(octopusFlag, "", "Compute the best common ancestors of all supplied commits, in preparation for an n-way merge.")
+	ap.SupportsFlag(isAncestorFlag, "", "Check if the first commit is an ancestor of the second commit, and exit with status 0 if true, or with status 1 if not.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd MergeBaseCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, mergeBaseDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.Contains(isAncestorFlag) {
+		if apr.Contains(cli.AllFlag) || apr.Contains(octopusFlag) {
+			return handleMergeBaseErr(errhand.BuildDError("--%s cannot be combined with other options", isAncestorFlag).SetPrintUsage().Build(), usage)
+		}
+		if apr.NArg() != 2 {
+			return handleMergeBaseErr(errhand.BuildDError("--%s takes exactly two commits", isAncestorFlag).SetPrintUsage().Build(), usage)
+		}
+	} else if apr.Contains(octopusFlag) {
+		if apr.NArg() < 1 {
+			return handleMergeBaseErr(errhand.BuildDError("--%s requires at least one commit", octopusFlag).SetPrintUsage().Build(), usage)
+		}
+	} else if apr.NArg() != 2 {
+		return handleMergeBaseErr(errhand.BuildDError("dolt merge-base takes exactly two commits").SetPrintUsage().Build(), usage)
+	}
+
+	commits := make([]*doltdb.Commit, apr.NArg())
+	for i, cSpecStr := range apr.Args() {
+		cm, verr := ResolveCommitWithVErr(dEnv, cSpecStr)
+
+		if verr != nil {
+			return handleMergeBaseErr(verr, usage)
+		}
+
+		commits[i] = cm
+	}
+
+	if apr.Contains(isAncestorFlag) {
+		isAncestor, err := doltdb.IsAncestor(ctx, commits[0], commits[1])
+
+		if err != nil {
+			return handleMergeBaseErr(errhand.BuildDError("error: failed to walk the commit graph").AddCause(err).Build(), usage)
+		}
+
+		if !isAncestor {
+			return 1
+		}
+		return 0
+	}
+
+	var mergeBases []*doltdb.Commit
+	var err error
+	if apr.Contains(octopusFlag) {
+		mergeBases, err = doltdb.GetOctopusMergeBases(ctx, commits)
+	} else {
+		mergeBases, err = doltdb.GetMergeBases(ctx, commits[0], commits[1])
+	}
+
+	if err == doltdb.ErrNoCommonAncestor {
+		return 1
+	} else if err != nil {
+		return handleMergeBaseErr(errhand.BuildDError("error: failed to find the merge base").AddCause(err).Build(), usage)
+	}
+
+	if !apr.Contains(cli.AllFlag) {
+		mergeBases = mergeBases[:1]
+	}
+
+	for _, cm := range mergeBases {
+		h, err := cm.HashOf()
+
+		if err != nil {
+			return handleMergeBaseErr(errhand.BuildDError("error: failed to get the hash of a commit").AddCause(err).Build(), usage)
+		}
+
+		cli.Println(h.String())
+	}
+
+	return 0
+}
+
+func handleMergeBaseErr(verr errhand.VerboseError, usage cli.UsagePrinter) int {
+	HandleVErrAndExitCode(verr, usage)
+	return mergeBaseErrExitCode
+}
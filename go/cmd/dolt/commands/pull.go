@@ -134,7 +134,7 @@ func pullRemoteBranch(ctx context.Context, apr *argparser.ArgParseResults, dEnv
 		return errhand.BuildDError("error: failed to get remote db").AddCause(err).Build()
 	}
 
-	srcDBCommit, verr := fetchRemoteBranch(ctx, dEnv, r, srcDB, dEnv.DoltDB, srcRef, destRef)
+	srcDBCommit, verr := fetchRemoteBranch(ctx, dEnv, r, srcDB, dEnv.DoltDB, srcRef, destRef, 0)
 
 	if verr != nil {
 		return verr
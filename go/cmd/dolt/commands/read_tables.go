@@ -18,6 +18,7 @@ import (
 	"context"
 	"path"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/utils/earl"
 	"github.com/dolthub/dolt/go/store/types"
@@ -73,6 +74,7 @@ func (cmd ReadTablesCmd) createArgParser() *argparser.ArgParser {
 		{"table", " Optional tables to retrieve.  If omitted, all tables are retrieved."},
 	}
 	ap.SupportsString(dirParamName, "d", "directory", "directory to create and put retrieved table data.")
+	ap.SupportsString(dbfactory.CABundleParam, "", "file", "A file of PEM encoded certificates of certificate authorities which are trusted to sign the certificate of an https remote, in addition to the system's certificate authorities.")
 	return ap
 }
 
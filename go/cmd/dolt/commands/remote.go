@@ -55,13 +55,15 @@ aws-creds-type specifies the means by which credentials should be retrieved in o
 	
 GCP remote urls should be of the form gs://gcs-bucket/database and will use the credentials setup using the gcloud command line available from Google +
 
+The certificates of https remotes must be signed by a certificate authority trusted by the system, or by one of the certificate authorities in the file given with the optional parameter {{.EmphasisLeft}}ca-bundle{{.EmphasisRight}}.
+
 The local filesystem can be used as a remote by providing a repository url in the format file://absolute path. See https://en.wikipedia.org/wiki/File_URI_schemethi
 {{.EmphasisLeft}}remove{{.EmphasisRight}}, {{.EmphasisLeft}}rm{{.EmphasisRight}}, 
 Remove the remote named {{.LessThan}}name{{.GreaterThan}}. All remote-tracking branches and configuration settings for the remote are removed.`,
 
 	Synopsis: []string{
 		"[-v | --verbose]",
-		"add [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}url{{.GreaterThan}}",
+		"add [--aws-region {{.LessThan}}region{{.GreaterThan}}] [--aws-creds-type {{.LessThan}}creds-type{{.GreaterThan}}] [--aws-creds-file {{.LessThan}}file{{.GreaterThan}}] [--aws-creds-profile {{.LessThan}}profile{{.GreaterThan}}] [--ca-bundle {{.LessThan}}file{{.GreaterThan}}] {{.LessThan}}name{{.GreaterThan}} {{.LessThan}}url{{.GreaterThan}}",
 		"remove {{.LessThan}}name{{.GreaterThan}}",
 	},
 }
@@ -103,6 +105,7 @@ func (cmd RemoteCmd) createArgParser() *argparser.ArgParser {
 	ap.SupportsValidatedString(dbfactory.AWSCredsTypeParam, "", "creds-type", "", argparser.ValidatorFromStrList(dbfactory.AWSCredsTypeParam, credTypes))
 	ap.SupportsString(dbfactory.AWSCredsFileParam, "", "file", "AWS credentials file")
 	ap.SupportsString(dbfactory.AWSCredsProfile, "", "profile", "AWS profile to use")
+	ap.SupportsString(dbfactory.CABundleParam, "", "file", "A file of PEM encoded certificates of certificate authorities which are trusted to sign the certificate of an https remote, in addition to the system's certificate authorities.")
 	return ap
 }
 
@@ -291,9 +294,35 @@ func parseRemoteArgs(apr *argparser.ArgParseResults, scheme, remoteUrl string) (
 		verr = verifyNoAwsParams(apr)
 	}
 
+	if verr == nil {
+		verr = addCABundleParam(apr, scheme, params)
+	}
+
 	return params, verr
 }
 
+func addCABundleParam(apr *argparser.ArgParseResults, scheme string, params map[string]string) errhand.VerboseError {
+	caBundle, ok := apr.GetValue(dbfactory.CABundleParam)
+
+	if !ok {
+		return nil
+	}
+
+	if scheme != dbfactory.HTTPSScheme {
+		return errhand.BuildDError("The parameter %s is only valid for https remotes", dbfactory.CABundleParam).SetPrintUsage().Build()
+	}
+
+	// the remote is used from the root of the repository, so a relative path would not be found later
+	absPath, err := filepath.Abs(caBundle)
+
+	if err != nil {
+		return errhand.BuildDError("error: '%s' is not a valid path", caBundle).AddCause(err).Build()
+	}
+
+	params[dbfactory.CABundleParam] = absPath
+	return nil
+}
+
 func addAWSParams(remoteUrl string, apr *argparser.ArgParseResults, params map[string]string) errhand.VerboseError {
 	isAWS := strings.HasPrefix(remoteUrl, "aws")
 
@@ -0,0 +1,222 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"context"
+	"crypto/tls"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/cmd/dolt/errhand"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
+	"github.com/dolthub/dolt/go/libraries/utils/argparser"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+const (
+	grpcPortFlag = "grpc-port"
+	httpPortFlag = "http-port"
+	httpHostFlag = "http-host"
+	authFileFlag = "auth-file"
+	readOnlyFlag = "read-only"
+	tlsCertFlag  = "tls-cert"
+	tlsKeyFlag   = "tls-key"
+
+	defaultRemoteServerGrpcPort = 50051
+	defaultRemoteServerHttpPort = 8080
+)
+
+var remoteServerDocs = cli.CommandDocumentationContent{
+	ShortDesc: "Serve repositories as remotes",
+	LongDesc: `{{.EmphasisLeft}}dolt remote-server{{.EmphasisRight}} serves the current repository so that other dolt clients can clone, fetch from and push to it. Clients use a url with the grpc port and any org and repository name, for example {{.EmphasisLeft}}dolt clone http://myhost:50051/org/repo{{.EmphasisRight}}.
+
+With {{.EmphasisLeft}}--multi-db-dir{{.EmphasisRight}}, every subdirectory of the given directory which is a dolt repository is served instead, and the repository name in a url selects the subdirectory. The org is ignored. The server never creates repositories.
+
+Table files are transferred over http on a second port. The urls of table files use the host that clients used to reach the grpc server, unless {{.EmphasisLeft}}--http-host{{.EmphasisRight}} is given.
+
+Without {{.EmphasisLeft}}--auth-file{{.EmphasisRight}} any client can read and write the served repositories. The auth file has the same format as the one used by remotesrv.
+
+With {{.EmphasisLeft}}--tls-cert{{.EmphasisRight}} and {{.EmphasisLeft}}--tls-key{{.EmphasisRight}} both grpc and http are served over TLS, and clients use https urls.
+
+Pushing to a branch changes the branch without updating the working set of the served repository, so teammates should usually push to branches that are not checked out, or the server should be started with {{.EmphasisLeft}}--read-only{{.EmphasisRight}}.`,
+	Synopsis: []string{
+		"[--multi-db-dir {{.LessThan}}directory{{.GreaterThan}}] [--grpc-port {{.LessThan}}port{{.GreaterThan}}] [--http-port {{.LessThan}}port{{.GreaterThan}}] [--http-host {{.LessThan}}host:port{{.GreaterThan}}] [--auth-file {{.LessThan}}file{{.GreaterThan}}] [--tls-cert {{.LessThan}}file{{.GreaterThan}} --tls-key {{.LessThan}}file{{.GreaterThan}}] [--read-only]",
+	},
+}
+
+type RemoteServerCmd struct{}
+
+// Name is returns the name of the Dolt cli command. This is what is used on the command line to invoke the command
+func (cmd RemoteServerCmd) Name() string {
+	return "remote-server"
+}
+
+// Description returns a description of the command
+func (cmd RemoteServerCmd) Description() string {
+	return "Serve repositories as remotes."
+}
+
+// RequiresRepo indicates that this command does not have to be run from within a dolt data repository directory, as
+// it supports the multiDBDirFlag. When that flag is not provided the environment is checked in Exec.
+func (cmd RemoteServerCmd) RequiresRepo() bool {
+	return false
+}
+
+// CreateMarkdown creates a markdown file containing the helptext for the command at the given path
+func (cmd RemoteServerCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string) error {
+	ap := cmd.createArgParser()
+	return CreateMarkdown(fs, path, cli.GetCommandDocumentation(commandStr, remoteServerDocs, ap))
+}
+
+func (cmd RemoteServerCmd) createArgParser() *argparser.ArgParser {
+	ap := argparser.NewArgParser()
+	ap.SupportsString(multiDBDirFlag, "", "directory", "Defines a directory whose subdirectories which are dolt data repositories should all be served.")
+	ap.SupportsInt(grpcPortFlag, "", "port", "The port the grpc remotesapi server listens on. Defaults to 50051.")
+	ap.SupportsInt(httpPortFlag, "", "port", "The port the http table file server listens on. Defaults to 8080.")
+	ap.SupportsString(httpHostFlag, "", "host:port", "The host and port clients use to reach the http table file server, if it differs from the host of the grpc server and --http-port.")
+	ap.SupportsString(authFileFlag, "", "file", "A json file of the users allowed to access the server, and their permissions.")
+	ap.SupportsFlag(readOnlyFlag, "", "Reject pushes, so that clients can only clone and fetch.")
+	ap.SupportsString(tlsCertFlag, "", "file", "A PEM encoded certificate chain used to serve grpc and http over TLS.")
+	ap.SupportsString(tlsKeyFlag, "", "file", "The PEM encoded private key of the certificate given with --tls-cert.")
+	return ap
+}
+
+// Exec executes the command
+func (cmd RemoteServerCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
+	ap := cmd.createArgParser()
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, remoteServerDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	if apr.NArg() != 0 {
+		return HandleVErrAndExitCode(errhand.BuildDError("dolt remote-server does not take any arguments").SetPrintUsage().Build(), usage)
+	}
+
+	var dbCache remotesrv.DBCache
+	if multiDir, ok := apr.GetValue(multiDBDirFlag); ok {
+		dirs, verr := remoteServerRepoDirs(dEnv.FS, multiDir)
+
+		if verr != nil {
+			return HandleVErrAndExitCode(verr, usage)
+		}
+
+		names := make([]string, 0, len(dirs))
+		for name := range dirs {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+		cli.Println("serving repositories:", strings.Join(names, ", "))
+
+		dbCache = remotesrv.NewMultiRepoCSCache(dirs)
+	} else {
+		if !cli.CheckEnvIsValid(dEnv) {
+			return 2
+		}
+
+		dir, err := dEnv.FS.Abs(dbfactory.DoltDataDir)
+
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to get the path of the repository").AddCause(err).Build(), usage)
+		}
+
+		dbCache = remotesrv.NewSingleRepoCSCache(dir)
+	}
+
+	var auth *remotesrv.Authenticator
+	if authFile, ok := apr.GetValue(authFileFlag); ok {
+		var err error
+		auth, err = remotesrv.LoadAuthFile(authFile)
+
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to load auth file '%s'", authFile).AddCause(err).Build(), usage)
+		}
+	}
+
+	var tlsConfig *tls.Config
+	if apr.Contains(tlsCertFlag) || apr.Contains(tlsKeyFlag) {
+		if !apr.ContainsAll(tlsCertFlag, tlsKeyFlag) {
+			return HandleVErrAndExitCode(errhand.BuildDError("--%s and --%s must be provided together", tlsCertFlag, tlsKeyFlag).SetPrintUsage().Build(), usage)
+		}
+
+		var err error
+		tlsConfig, err = remotesrv.LoadTLSConfig(apr.MustGetValue(tlsCertFlag), apr.MustGetValue(tlsKeyFlag))
+
+		if err != nil {
+			return HandleVErrAndExitCode(errhand.BuildDError("error: failed to load TLS certificate").AddCause(err).Build(), usage)
+		}
+	}
+
+	httpHost, _ := apr.GetValue(httpHostFlag)
+	server, err := remotesrv.NewServer(remotesrv.ServerArgs{
+		HttpHost:  httpHost,
+		HttpPort:  apr.GetIntOrDefault(httpPortFlag, defaultRemoteServerHttpPort),
+		GrpcPort:  apr.GetIntOrDefault(grpcPortFlag, defaultRemoteServerGrpcPort),
+		DBCache:   dbCache,
+		Auth:      auth,
+		ReadOnly:  apr.Contains(readOnlyFlag),
+		TLSConfig: tlsConfig,
+	})
+
+	if err != nil {
+		return HandleVErrAndExitCode(errhand.BuildDError("error: failed to start the remote server").AddCause(err).Build(), usage)
+	}
+
+	go func() {
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, os.Interrupt)
+		<-c
+		server.GracefulStop()
+	}()
+
+	server.Serve()
+
+	return 0
+}
+
+// remoteServerRepoDirs returns the chunk store directories of the dolt repositories in |multiDir|, by the name of the
+// repository's directory. Subdirectories which are not dolt repositories are skipped.
+func remoteServerRepoDirs(fs filesys.Filesys, multiDir string) (map[string]string, errhand.VerboseError) {
+	namesAndPaths, err := env.DBNamesAndPathsFromDir(fs, multiDir)
+
+	if err != nil {
+		return nil, errhand.BuildDError("error: failed to read the directory '%s'", multiDir).AddCause(err).Build()
+	}
+
+	dirs := make(map[string]string)
+	for _, nameAndPath := range namesAndPaths {
+		dir, err := fs.Abs(filepath.Join(nameAndPath.Path, dbfactory.DoltDataDir))
+
+		if err != nil {
+			return nil, errhand.BuildDError("error: failed to get the path of '%s'", nameAndPath.Path).AddCause(err).Build()
+		}
+
+		if exists, isDir := fs.Exists(dir); exists && isDir {
+			dirs[filepath.Base(nameAndPath.Path)] = dir
+		}
+	}
+
+	if len(dirs) == 0 {
+		return nil, errhand.BuildDError("error: '%s' does not contain any dolt repositories", multiDir).Build()
+	}
+
+	return dirs, nil
+}
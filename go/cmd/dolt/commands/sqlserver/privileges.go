@@ -0,0 +1,487 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dolthub/go-mysql-server/auth"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/information_schema"
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+
+	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+const (
+	// ExecutePerm allows a user to call the dolt functions which change the commit graph of a database, such as
+	// DOLT_COMMIT, DOLT_MERGE and DOLT_RESET.
+	ExecutePerm = auth.WritePerm << 1
+	// GrantPerm allows a user to create and drop users, and to grant and revoke privileges. It can only be given on
+	// every database.
+	GrantPerm = auth.WritePerm << 2
+
+	allPerms = auth.ReadPerm | auth.WritePerm | ExecutePerm | GrantPerm
+
+	// allDatabases is the database name whose privileges apply to every database
+	allDatabases = "*"
+
+	// PrivilegeFileName is the name of the file in the .dolt directory which stores the users created, and the
+	// privileges granted, with sql statements.
+	PrivilegeFileName = "privileges.json"
+)
+
+// privilegeNames are the names of the privileges in the server config and the privilege file.
+var privilegeNames = map[string]auth.Permission{
+	"read":    auth.ReadPerm,
+	"write":   auth.WritePerm,
+	"execute": ExecutePerm,
+	"grant":   GrantPerm,
+}
+
+var nativePasswordRegex = regexp.MustCompile(`^\*[0-9A-F]{40}$`)
+
+// UserAccountConfig is a user which may connect to the server. Privileges maps the name of a database, or "*" for
+// every database, to the privileges the user has on it: read, write, execute and grant. Password is either the plain
+// text password or its mysql_native_password hash.
+type UserAccountConfig struct {
+	Name       string              `yaml:"name" json:"name"`
+	Password   string              `yaml:"password" json:"password"`
+	Privileges map[string][]string `yaml:"privileges,omitempty" json:"privileges,omitempty"`
+}
+
+// userAccount is a user which may connect to the server, with its password hash and privileges.
+type userAccount struct {
+	name         string
+	passwordHash string
+	// privileges are keyed by the lower case database name
+	privileges map[string]auth.Permission
+	// fromConfig is true for the users defined in the server config, which cannot be changed with sql statements
+	fromConfig bool
+}
+
+func newUserAccount(cfg UserAccountConfig, fromConfig bool) (*userAccount, error) {
+	if cfg.Name == "" {
+		return nil, errors.New("user name cannot be empty")
+	}
+
+	passwordHash := cfg.Password
+	if !nativePasswordRegex.MatchString(passwordHash) {
+		passwordHash = auth.NativePassword(passwordHash)
+	}
+
+	privileges := make(map[string]auth.Permission)
+	for db, names := range cfg.Privileges {
+		var perm auth.Permission
+		for _, name := range names {
+			p, ok := privilegeNames[strings.ToLower(name)]
+
+			if !ok {
+				return nil, fmt.Errorf("user %s has unknown privilege '%s'", cfg.Name, name)
+			}
+
+			perm |= p
+		}
+
+		if perm&GrantPerm != 0 && db != allDatabases {
+			return nil, fmt.Errorf("user %s: the grant privilege can only be given on every database", cfg.Name)
+		}
+
+		privileges[strings.ToLower(db)] |= perm
+	}
+
+	return &userAccount{name: cfg.Name, passwordHash: passwordHash, privileges: privileges, fromConfig: fromConfig}, nil
+}
+
+func (u *userAccount) privilegesOn(db string) auth.Permission {
+	return u.privileges[allDatabases] | u.privileges[strings.ToLower(db)]
+}
+
+func (u *userAccount) config() UserAccountConfig {
+	privileges := make(map[string][]string)
+	for db, perm := range u.privileges {
+		if perm != 0 {
+			privileges[db] = permissionNames(perm)
+		}
+	}
+
+	return UserAccountConfig{Name: u.name, Password: u.passwordHash, Privileges: privileges}
+}
+
+func (u *userAccount) clone() *userAccount {
+	privileges := make(map[string]auth.Permission, len(u.privileges))
+	for db, perm := range u.privileges {
+		privileges[db] = perm
+	}
+
+	return &userAccount{name: u.name, passwordHash: u.passwordHash, privileges: privileges, fromConfig: u.fromConfig}
+}
+
+// permissionNames returns the sorted names of the privileges in |perm|.
+func permissionNames(perm auth.Permission) []string {
+	var names []string
+	for name, p := range privilegeNames {
+		if perm&p != 0 {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// configUserAccounts returns the users of |config|. The user of the config's user section has every privilege on
+// every database.
+func configUserAccounts(config ServerConfig) (map[string]*userAccount, error) {
+	superuser, err := newUserAccount(UserAccountConfig{Name: config.User(), Password: config.Password()}, true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	superuser.privileges[allDatabases] = allPerms
+	users := map[string]*userAccount{superuser.name: superuser}
+
+	for _, cfg := range config.Users() {
+		user, err := newUserAccount(cfg, true)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := users[user.name]; ok {
+			return nil, fmt.Errorf("user %s is defined more than once", user.name)
+		}
+
+		users[user.name] = user
+	}
+
+	return users, nil
+}
+
+// Privileges is an auth.Auth which authenticates the users of a server, and checks their privileges on every
+// database used by a query. The users created with sql statements are persisted to a privilege file.
+type Privileges struct {
+	mu    *sync.RWMutex
+	users map[string]*userAccount
+
+	fs filesys.Filesys
+	// filePath is the privilege file, or "" if users can only be defined in the server config
+	filePath string
+	readOnly bool
+	// replicas are the lower case names of the databases which are read replicas, which no user may write to
+	replicas map[string]bool
+}
+
+var _ auth.Auth = (*Privileges)(nil)
+var _ dsqle.PrivilegeChecker = (*Privileges)(nil)
+
+// NewPrivileges returns the Privileges of a server using |config|. The users created with sql statements are loaded
+// from, and saved to, the file |filePath| in |fs|. If |filePath| is empty users can only be defined in |config|.
+func NewPrivileges(config ServerConfig, fs filesys.Filesys, filePath string) (*Privileges, error) {
+	users, err := configUserAccounts(config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if filePath != "" {
+		if exists, _ := fs.Exists(filePath); exists {
+			data, err := fs.ReadFile(filePath)
+
+			if err != nil {
+				return nil, err
+			}
+
+			var cfgs []UserAccountConfig
+			err = json.Unmarshal(data, &cfgs)
+
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse privilege file '%s': %w", filePath, err)
+			}
+
+			for _, cfg := range cfgs {
+				user, err := newUserAccount(cfg, false)
+
+				if err != nil {
+					return nil, fmt.Errorf("invalid privilege file '%s': %w", filePath, err)
+				}
+
+				if _, ok := users[user.name]; ok {
+					return nil, fmt.Errorf("user %s of the privilege file '%s' is also defined in the server config", user.name, filePath)
+				}
+
+				users[user.name] = user
+			}
+		}
+	}
+
+	return &Privileges{
+		mu:       &sync.RWMutex{},
+		users:    users,
+		fs:       fs,
+		filePath: filePath,
+		readOnly: config.ReadOnly(),
+		replicas: make(map[string]bool),
+	}, nil
+}
+
+// setReadReplicas sets the databases which are read replicas. Only their read privileges are granted to the users.
+func (p *Privileges) setReadReplicas(dbNames []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, dbName := range dbNames {
+		p.replicas[strings.ToLower(dbName)] = true
+	}
+}
+
+// Mysql implements auth.Auth. The returned server authenticates the users of |p| as they are when a client connects,
+// including users created after the server was started.
+func (p *Privileges) Mysql() mysql.AuthServer {
+	return &privilegesAuthServer{p}
+}
+
+// Allowed implements auth.Auth. Go-mysql-server only asks for the write permission for some of the statements that
+// write, so the query is classified again, and the user must have the permission on every database it uses.
+func (p *Privileges) Allowed(ctx *sql.Context, permission auth.Permission) error {
+	dbs, write := queryDatabases(ctx.GetCurrentDatabase(), ctx.Query())
+	if write {
+		permission |= auth.WritePerm
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	user, ok := p.users[ctx.Client().User]
+
+	if !ok {
+		return auth.ErrNotAuthorized.Wrap(auth.ErrNoPermission.New(permission))
+	}
+
+	if len(dbs) == 0 && permission&auth.WritePerm != 0 {
+		dbs = []string{allDatabases}
+	}
+
+	for _, db := range dbs {
+		if err := p.allowed(user, db, permission); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CheckExecute implements dsqle.PrivilegeChecker.
+func (p *Privileges) CheckExecute(userName, dbName string) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	user, ok := p.users[userName]
+
+	if !ok {
+		return auth.ErrNotAuthorized.Wrap(auth.ErrNoPermission.New(ExecutePerm))
+	}
+
+	return p.allowed(user, dbName, ExecutePerm)
+}
+
+// CheckWrite implements dsqle.PrivilegeChecker.
+func (p *Privileges) CheckWrite(userName, dbName string) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	user, ok := p.users[userName]
+
+	if !ok {
+		return auth.ErrNotAuthorized.Wrap(auth.ErrNoPermission.New(auth.WritePerm))
+	}
+
+	return p.allowed(user, dbName, auth.WritePerm)
+}
+
+func (p *Privileges) allowed(user *userAccount, db string, permission auth.Permission) error {
+	if permission == auth.ReadPerm && strings.EqualFold(db, information_schema.InformationSchemaDatabaseName) {
+		return nil
+	}
+
+	if p.replicas[strings.ToLower(db)] && permission&^auth.ReadPerm != 0 {
+		return auth.ErrNotAuthorized.Wrap(fmt.Errorf("database %s is a read replica, and cannot be changed", db))
+	}
+
+	granted := user.privilegesOn(db)
+	if p.readOnly {
+		granted &= auth.ReadPerm
+	}
+
+	if missing := permission &^ granted; missing != 0 {
+		if db == allDatabases {
+			return auth.ErrNotAuthorized.Wrap(fmt.Errorf("user %s does not have the %s privilege on every database", user.name, strings.Join(permissionNames(missing), ", ")))
+		}
+
+		return auth.ErrNotAuthorized.Wrap(fmt.Errorf("user %s does not have the %s privilege on database %s", user.name, strings.Join(permissionNames(missing), ", "), db))
+	}
+
+	return nil
+}
+
+// update applies |f| to a copy of the users, and saves the users which were not defined in the server config to the
+// privilege file. The users are only changed if saving succeeds.
+func (p *Privileges) update(f func(users map[string]*userAccount) error) error {
+	if p.filePath == "" {
+		return errors.New("users can only be defined in the server config, as the server has no privilege file")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	users := make(map[string]*userAccount, len(p.users))
+	for name, user := range p.users {
+		users[name] = user.clone()
+	}
+
+	err := f(users)
+
+	if err != nil {
+		return err
+	}
+
+	var cfgs []UserAccountConfig
+	for _, user := range users {
+		if !user.fromConfig {
+			cfgs = append(cfgs, user.config())
+		}
+	}
+
+	sort.Slice(cfgs, func(i, j int) bool {
+		return cfgs[i].Name < cfgs[j].Name
+	})
+
+	data, err := json.MarshalIndent(cfgs, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	err = p.fs.WriteFile(p.filePath, data)
+
+	if err != nil {
+		return fmt.Errorf("failed to write privilege file '%s': %w", p.filePath, err)
+	}
+
+	p.users = users
+	return nil
+}
+
+// staticAuthServer returns a vitess auth server for the current users.
+func (p *Privileges) staticAuthServer() *mysql.AuthServerStatic {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	authServer := mysql.NewAuthServerStatic()
+	for name, user := range p.users {
+		authServer.Entries[name] = []*mysql.AuthServerStaticEntry{
+			{
+				MysqlNativePassword: user.passwordHash,
+				Password:            user.passwordHash,
+			},
+		}
+	}
+
+	return authServer
+}
+
+// privilegesAuthServer is a mysql.AuthServer which authenticates the current users of a Privileges.
+type privilegesAuthServer struct {
+	privileges *Privileges
+}
+
+func (s *privilegesAuthServer) AuthMethod(user string) (string, error) {
+	return s.privileges.staticAuthServer().AuthMethod(user)
+}
+
+func (s *privilegesAuthServer) Salt() ([]byte, error) {
+	return mysql.NewSalt()
+}
+
+func (s *privilegesAuthServer) ValidateHash(salt []byte, user string, authResponse []byte, remoteAddr net.Addr) (mysql.Getter, error) {
+	return s.privileges.staticAuthServer().ValidateHash(salt, user, authResponse, remoteAddr)
+}
+
+func (s *privilegesAuthServer) Negotiate(c *mysql.Conn, user string, remoteAddr net.Addr) (mysql.Getter, error) {
+	return s.privileges.staticAuthServer().Negotiate(c, user, remoteAddr)
+}
+
+// queryDatabases returns the databases used by |query|, and whether it writes to them. Tables which are not qualified
+// with a database belong to |currentDB|.
+func queryDatabases(currentDB, query string) ([]string, bool) {
+	stmt, err := sqlparser.Parse(query)
+
+	if err != nil {
+		// statements which go-mysql-server parses itself, such as SHOW VARIABLES, don't use any tables
+		if currentDB == "" {
+			return nil, false
+		}
+
+		return []string{currentDB}, false
+	}
+
+	write := true
+	switch stmt.(type) {
+	case *sqlparser.Select, *sqlparser.Union, *sqlparser.ParenSelect, *sqlparser.Show, *sqlparser.Explain,
+		*sqlparser.OtherRead, *sqlparser.Use, *sqlparser.Set, *sqlparser.Begin, *sqlparser.Commit, *sqlparser.Rollback:
+		write = false
+	}
+
+	seen := make(map[string]bool)
+	var dbs []string
+	add := func(db string) {
+		if db != "" && !seen[strings.ToLower(db)] {
+			seen[strings.ToLower(db)] = true
+			dbs = append(dbs, db)
+		}
+	}
+
+	if use, ok := stmt.(*sqlparser.Use); ok {
+		add(use.DBName.String())
+	}
+
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		if tn, ok := node.(sqlparser.TableName); ok && !tn.IsEmpty() {
+			if tn.Qualifier.IsEmpty() {
+				add(currentDB)
+			} else {
+				add(tn.Qualifier.String())
+			}
+		}
+
+		return true, nil
+	}, stmt)
+
+	if len(dbs) == 0 {
+		add(currentDB)
+	}
+
+	return dbs, write
+}
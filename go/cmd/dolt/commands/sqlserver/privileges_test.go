@@ -0,0 +1,180 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+func TestQueryDatabases(t *testing.T) {
+	tests := []struct {
+		query string
+		dbs   []string
+		write bool
+	}{
+		{"SELECT * FROM t", []string{"cur"}, false},
+		{"SELECT * FROM other.t JOIN t ON other.t.a = t.a", []string{"other", "cur"}, false},
+		{"SELECT 1", []string{"cur"}, false},
+		{"INSERT INTO other.t VALUES (1)", []string{"other"}, true},
+		{"UPDATE t SET a = 1", []string{"cur"}, true},
+		{"CREATE TABLE other.t (a int primary key)", []string{"other"}, true},
+		{"DROP TABLE t", []string{"cur"}, true},
+		{"USE other", []string{"other"}, false},
+		{"SHOW TABLES", []string{"cur"}, false},
+		{"SELECT DOLT_COMMIT('-m', 'message')", []string{"cur"}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			dbs, write := queryDatabases("cur", test.query)
+			assert.Equal(t, test.dbs, dbs)
+			assert.Equal(t, test.write, write)
+		})
+	}
+
+	dbs, write := queryDatabases("", "SELECT 1")
+	assert.Empty(t, dbs)
+	assert.False(t, write)
+}
+
+func TestPrivilegesFromConfig(t *testing.T) {
+	config := DefaultServerConfig().withUsers([]UserAccountConfig{
+		{Name: "reader", Password: "pass", Privileges: map[string][]string{"*": {"read"}}},
+		{Name: "writer", Password: "pass", Privileges: map[string][]string{"mydb": {"read", "write"}, "other": {"read"}}},
+		{Name: "committer", Password: "pass", Privileges: map[string][]string{"MyDB": {"read", "write", "execute"}}},
+	})
+	require.NoError(t, ValidateConfig(config))
+
+	fs := filesys.NewInMemFS([]string{"/.dolt"}, nil, "/")
+	privileges, err := NewPrivileges(config, fs, "")
+	require.NoError(t, err)
+
+	check := func(user, db string, perm auth.Permission) error {
+		privileges.mu.RLock()
+		defer privileges.mu.RUnlock()
+		return privileges.allowed(privileges.users[user], db, perm)
+	}
+
+	assert.NoError(t, check("root", "mydb", allPerms))
+	assert.NoError(t, check("reader", "mydb", auth.ReadPerm))
+	assert.Error(t, check("reader", "mydb", auth.WritePerm))
+	assert.NoError(t, check("writer", "MYDB", auth.ReadPerm|auth.WritePerm))
+	assert.Error(t, check("writer", "other", auth.WritePerm))
+	assert.Error(t, check("writer", "third", auth.ReadPerm))
+	assert.NoError(t, check("writer", "information_schema", auth.ReadPerm))
+
+	assert.Error(t, privileges.CheckExecute("writer", "mydb"))
+	assert.NoError(t, privileges.CheckExecute("committer", "mydb"))
+	assert.Error(t, privileges.CheckExecute("unknown", "mydb"))
+	assert.Error(t, privileges.CheckWrite("reader", "mydb"))
+	assert.NoError(t, privileges.CheckWrite("writer", "mydb"))
+	assert.Error(t, privileges.CheckWrite("writer", "other"))
+	assert.Error(t, privileges.CheckWrite("unknown", "mydb"))
+
+	// users of the config cannot be changed with sql statements, and there is no privilege file
+	_, ok, err := privileges.execUserStatement("root", "CREATE USER someone IDENTIFIED BY 'pass'")
+	assert.True(t, ok)
+	assert.Error(t, err)
+
+	readOnly, err := NewPrivileges(config.withReadOnly(true), fs, "")
+	require.NoError(t, err)
+	assert.Error(t, readOnly.CheckExecute("committer", "mydb"))
+	readOnly.mu.RLock()
+	assert.Error(t, readOnly.allowed(readOnly.users["root"], "mydb", auth.WritePerm))
+	readOnly.mu.RUnlock()
+}
+
+func TestBadUsersConfig(t *testing.T) {
+	tests := [][]UserAccountConfig{
+		{{Name: "", Password: "pass"}},
+		{{Name: "root", Password: "pass"}},
+		{{Name: "a"}, {Name: "a"}},
+		{{Name: "a", Privileges: map[string][]string{"*": {"delete"}}}},
+		{{Name: "a", Privileges: map[string][]string{"mydb": {"grant"}}}},
+	}
+
+	for _, test := range tests {
+		assert.Error(t, ValidateConfig(DefaultServerConfig().withUsers(test)))
+	}
+}
+
+func TestUserStatements(t *testing.T) {
+	fs := filesys.NewInMemFS([]string{"/.dolt"}, nil, "/")
+	const privilegeFile = "/.dolt/privileges.json"
+
+	privileges, err := NewPrivileges(DefaultServerConfig(), fs, privilegeFile)
+	require.NoError(t, err)
+
+	exec := func(user, query string) error {
+		_, ok, err := privileges.execUserStatement(user, query)
+		require.True(t, ok, query)
+		return err
+	}
+
+	_, ok, _ := privileges.execUserStatement("root", "SELECT * FROM users")
+	assert.False(t, ok)
+
+	require.NoError(t, exec("root", "CREATE USER 'analyst'@'%' IDENTIFIED BY 'secret';"))
+	assert.Error(t, exec("root", "CREATE USER analyst"))
+	require.NoError(t, exec("root", "CREATE USER IF NOT EXISTS analyst"))
+	require.NoError(t, exec("root", "create user `admin` identified by \"pw\""))
+	require.NoError(t, exec("root", "GRANT SELECT ON mydb.* TO analyst"))
+	require.NoError(t, exec("root", "GRANT SELECT, INSERT, EXECUTE ON `other`.* TO 'analyst'@'localhost'"))
+	require.NoError(t, exec("root", "GRANT ALL PRIVILEGES ON *.* TO admin WITH GRANT OPTION"))
+	assert.Error(t, exec("root", "GRANT SELECT ON mydb.t TO analyst"))
+	assert.Error(t, exec("root", "GRANT SELECT ON mydb.* TO nobody"))
+	assert.Error(t, exec("root", "GRANT SUPER ON *.* TO analyst"))
+	assert.Error(t, exec("root", "GRANT SELECT ON mydb.* TO analyst WITH GRANT OPTION"))
+	assert.Error(t, exec("root", "GRANT SELECT ON mydb.* TO root"))
+
+	// only users with the grant option can manage users
+	assert.Error(t, exec("analyst", "CREATE USER other"))
+	require.NoError(t, exec("admin", "CREATE USER other"))
+	require.NoError(t, exec("admin", "DROP USER other"))
+	assert.Error(t, exec("admin", "DROP USER other"))
+	require.NoError(t, exec("admin", "DROP USER IF EXISTS other"))
+
+	result, _, err := privileges.execUserStatement("analyst", "SHOW GRANTS")
+	require.NoError(t, err)
+	require.Len(t, result.Rows, 2)
+	assert.Equal(t, "GRANT SELECT ON `mydb`.* TO `analyst`", result.Rows[0][0].ToString())
+	assert.Equal(t, "GRANT ALL PRIVILEGES ON `other`.* TO `analyst`", result.Rows[1][0].ToString())
+	_, _, err = privileges.execUserStatement("analyst", "SHOW GRANTS FOR admin")
+	assert.Error(t, err)
+	result, _, err = privileges.execUserStatement("root", "SHOW GRANTS FOR admin")
+	require.NoError(t, err)
+	assert.Equal(t, "GRANT ALL PRIVILEGES ON *.* TO `admin` WITH GRANT OPTION", result.Rows[0][0].ToString())
+
+	require.NoError(t, exec("root", "REVOKE INSERT ON other.* FROM analyst"))
+	assert.NoError(t, privileges.CheckExecute("analyst", "other"))
+	require.NoError(t, exec("root", "REVOKE ALL ON other.* FROM analyst"))
+	assert.Error(t, privileges.CheckExecute("analyst", "other"))
+
+	// the users created with statements are loaded from the privilege file
+	reloaded, err := NewPrivileges(DefaultServerConfig(), fs, privilegeFile)
+	require.NoError(t, err)
+	assert.Equal(t, privileges.users, reloaded.users)
+	assert.Equal(t, auth.NativePassword("secret"), reloaded.users["analyst"].passwordHash)
+
+	// a user of the config cannot also be in the privilege file
+	_, err = NewPrivileges(DefaultServerConfig().withUser("analyst"), fs, privilegeFile)
+	assert.Error(t, err)
+}
@@ -0,0 +1,362 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dolthub/go-mysql-server/server"
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/sqlparser"
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+// ReadReplica keeps the databases of a server which are read replicas up to date with their remote. A database is a
+// read replica of the remote given by the server config, or by the sqlserver.read_replica_remote key of its repo
+// config. Its checked out branch is pulled from the remote either at the start of every transaction, or on an
+// interval, and the branch is fast-forwarded to the commit of the remote. Read replicas cannot be written to.
+type ReadReplica struct {
+	dbs      map[string]*replicaDB
+	interval time.Duration
+
+	// mu serializes pulls, so that concurrent transactions do not pull the same commits.
+	mu sync.Mutex
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// replicaDB is a database of a ReadReplica.
+type replicaDB struct {
+	dEnv   *env.DoltEnv
+	remote env.Remote
+	srcDB  *doltdb.DoltDB
+}
+
+// NewReadReplica returns a ReadReplica for the databases of |mrEnv| which are read replicas, or nil if none of them
+// are. Every read replica is pulled before it returns, so the server starts with the latest commit of each remote.
+func NewReadReplica(ctx context.Context, serverConfig ServerConfig, mrEnv env.MultiRepoEnv) (*ReadReplica, error) {
+	r := &ReadReplica{
+		dbs:      make(map[string]*replicaDB),
+		interval: time.Duration(serverConfig.ReadReplicaInterval()) * time.Millisecond,
+		stop:     make(chan struct{}),
+	}
+
+	err := mrEnv.Iter(func(name string, dEnv *env.DoltEnv) (stop bool, err error) {
+		remoteName := serverConfig.ReadReplicaRemote()
+		if remoteName == "" {
+			remoteName = *dEnv.Config.GetStringOrDefault(env.ReadReplicaRemoteKey, "")
+		}
+
+		if remoteName == "" {
+			return false, nil
+		}
+
+		remotes, err := dEnv.GetRemotes()
+
+		if err != nil {
+			return true, err
+		}
+
+		remote, ok := remotes[remoteName]
+
+		if !ok {
+			return true, fmt.Errorf("database %s cannot be a read replica of the remote %s, as it does not exist", name, remoteName)
+		}
+
+		r.dbs[name] = &replicaDB{dEnv: dEnv, remote: remote}
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r.dbs) == 0 {
+		return nil, nil
+	}
+
+	err = r.Pull(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if r.interval > 0 {
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			r.run()
+		}()
+	}
+
+	return r, nil
+}
+
+// DatabaseNames returns the names of the databases which are read replicas.
+func (r *ReadReplica) DatabaseNames() []string {
+	names := make([]string, 0, len(r.dbs))
+	for name := range r.dbs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// Pull pulls the checked out branch of every read replica from its remote.
+func (r *ReadReplica) Pull(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, name := range r.DatabaseNames() {
+		err := r.dbs[name].pull(ctx)
+
+		if err != nil {
+			return fmt.Errorf("failed to pull read replica %s from remote %s: %w", name, r.dbs[name].remote.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// StartTransaction starts a transaction of the session |sess|, in which it sees the latest commit of the checked
+// out branch of every read replica. The read replicas are pulled first, unless they are pulled on an interval. If the
+// pull fails the transaction sees the commits which were last pulled.
+func (r *ReadReplica) StartTransaction(ctx *sql.Context, sess *dsqle.DoltSession) error {
+	if r.interval == 0 {
+		err := r.Pull(ctx)
+
+		if err != nil {
+			logrus.Warn(err)
+		}
+	}
+
+	for _, name := range r.DatabaseNames() {
+		err := sess.LoadHead(ctx, name)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close stops pulling the read replicas on an interval.
+func (r *ReadReplica) Close() {
+	close(r.stop)
+	r.wg.Wait()
+}
+
+// run pulls the read replicas every interval until |r| is closed.
+func (r *ReadReplica) run() {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			err := r.Pull(context.Background())
+
+			if err != nil {
+				logrus.Warn(err)
+			}
+		}
+	}
+}
+
+// pull fetches the checked out branch of |db| from its remote, and sets the branch to it. The branch is not
+// fast-forwarded, as the primary may have reset it, and the working set of the database is always set to the root of the
+// latest commit, as a read replica has no changes of its own.
+func (db *replicaDB) pull(ctx context.Context) error {
+	destDB := db.dEnv.DoltDB
+	branch := db.dEnv.RepoStateReader().CWBHeadRef()
+
+	if db.srcDB == nil {
+		srcDB, err := db.remote.GetRemoteDB(ctx, destDB.Format())
+
+		if err != nil {
+			return err
+		}
+
+		db.srcDB = srcDB
+	} else if err := db.srcDB.Rebase(ctx); err != nil {
+		return err
+	}
+
+	cm, err := db.srcDB.ResolveRef(ctx, branch)
+
+	if err != nil {
+		return err
+	}
+
+	pullerEventCh := make(chan datas.PullerEvent, 128)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range pullerEventCh {
+		}
+	}()
+
+	err = actions.FetchCommit(ctx, db.dEnv, db.srcDB, destDB, cm, nil, pullerEventCh)
+
+	close(pullerEventCh)
+	<-done
+
+	if err != nil {
+		return err
+	}
+
+	err = destDB.SetHeadToCommit(ctx, ref.NewRemoteRef(db.remote.Name, branch.GetPath()), cm)
+
+	if err != nil {
+		return err
+	}
+
+	err = destDB.SetHeadToCommit(ctx, branch, cm)
+
+	if err != nil {
+		return err
+	}
+
+	root, err := cm.GetRootValue()
+
+	if err != nil {
+		return err
+	}
+
+	h, err := root.HashOf()
+
+	if err != nil {
+		return err
+	}
+
+	rsr, rsw := db.dEnv.RepoStateReader(), db.dEnv.RepoStateWriter()
+	if rsr.WorkingHash() == h && rsr.StagedHash() == h {
+		return nil
+	}
+
+	err = rsw.SetWorkingHash(ctx, h)
+
+	if err != nil {
+		return err
+	}
+
+	return rsw.SetStagedHash(ctx, h)
+}
+
+// readReplicaHandler is a mysql.Handler which starts a transaction of a ReadReplica whenever a connection runs a
+// statement outside of a transaction. With autocommit enabled every statement is its own transaction, otherwise a
+// transaction lasts until it is committed or rolled back.
+type readReplicaHandler struct {
+	mysql.Handler
+	sm      *server.SessionManager
+	replica *ReadReplica
+
+	mu sync.Mutex
+	// inTransaction is the set of the ids of the connections which are in a transaction
+	inTransaction map[uint32]bool
+}
+
+var _ mysql.Handler = (*readReplicaHandler)(nil)
+
+func newReadReplicaHandler(h mysql.Handler, sm *server.SessionManager, replica *ReadReplica) *readReplicaHandler {
+	return &readReplicaHandler{Handler: h, sm: sm, replica: replica, inTransaction: make(map[uint32]bool)}
+}
+
+// ComQuery implements mysql.Handler.
+func (h *readReplicaHandler) ComQuery(c *mysql.Conn, query string, callback func(*sqltypes.Result) error) error {
+	ctx, err := h.sm.NewContextWithQuery(c, query)
+
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	inTransaction := h.inTransaction[c.ConnectionID]
+	h.mu.Unlock()
+
+	if !inTransaction {
+		err = h.replica.StartTransaction(ctx, dsqle.DSessFromSess(ctx.Session))
+
+		if err != nil {
+			return err
+		}
+	}
+
+	err = h.Handler.ComQuery(c, query, callback)
+
+	h.mu.Lock()
+	h.inTransaction[c.ConnectionID] = !isAutoCommit(ctx) && !endsTransaction(query)
+	h.mu.Unlock()
+
+	return err
+}
+
+// ConnectionClosed implements mysql.Handler.
+func (h *readReplicaHandler) ConnectionClosed(c *mysql.Conn) {
+	h.mu.Lock()
+	delete(h.inTransaction, c.ConnectionID)
+	h.mu.Unlock()
+
+	h.Handler.ConnectionClosed(c)
+}
+
+// isAutoCommit returns whether autocommit is enabled for the session of |ctx|.
+func isAutoCommit(ctx *sql.Context) bool {
+	_, val := ctx.Get(sql.AutoCommitSessionVar)
+
+	switch val := val.(type) {
+	case int64:
+		return val == 1
+	case nil:
+		return false
+	default:
+		autoCommit, _ := sql.ConvertToBool(val)
+		return autoCommit
+	}
+}
+
+// endsTransaction returns whether |query| commits or rolls back the transaction.
+func endsTransaction(query string) bool {
+	stmt, err := sqlparser.Parse(query)
+
+	if err != nil {
+		return false
+	}
+
+	switch stmt.(type) {
+	case *sqlparser.Commit, *sqlparser.Rollback:
+		return true
+	default:
+		return false
+	}
+}
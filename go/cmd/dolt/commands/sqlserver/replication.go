@@ -0,0 +1,346 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+const (
+	// replicationQueueSize is the number of branches of a database which may wait to be pushed to its remote.
+	replicationQueueSize = 128
+	// replicationAttempts is the number of times pushing a branch is attempted before giving up until the branch
+	// changes again.
+	replicationAttempts = 5
+	// replicationBackoff is how long the first retry of a failed push waits. Each further retry waits twice as long.
+	replicationBackoff = time.Second
+)
+
+var errReplicationQueueFull = errors.New("the replication queue is full")
+
+var _ dsqle.Replicator = (*Replicator)(nil)
+
+// Replicator is a dsqle.Replicator which asynchronously pushes the branches changed by the server to the remote each
+// database is replicated to. A database is replicated to the remote given by the server config, or by the
+// sqlserver.replicate_to_remote key of its repo config.
+type Replicator struct {
+	dbs  map[string]*replicatedDB
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// replicatedDB is a database of a Replicator, whose branches are pushed one at a time by a single goroutine.
+type replicatedDB struct {
+	dEnv   *env.DoltEnv
+	remote env.Remote
+	queue  chan ref.BranchRef
+
+	mu       sync.Mutex
+	closed   bool
+	branches map[string]*branchReplication
+	destDB   *doltdb.DoltDB
+}
+
+// branchReplication is the state of the replication of a single branch.
+type branchReplication struct {
+	status dtables.ReplicationStatus
+	// queued is true while the branch is in the queue, so it is queued at most once.
+	queued bool
+	// queuedAt is when the branch was last queued.
+	queuedAt time.Time
+	// pendingSince is when the branch was first changed after it was last pushed. It is zero if the remote is up to
+	// date.
+	pendingSince time.Time
+}
+
+// NewReplicator returns a Replicator for the databases of |mrEnv| which are replicated to a remote, or nil if none of
+// them are. The checked out branch of each replicated database is queued to be pushed right away, so that changes
+// made while the server was not running are replicated.
+func NewReplicator(serverConfig ServerConfig, mrEnv env.MultiRepoEnv) (*Replicator, error) {
+	r := &Replicator{dbs: make(map[string]*replicatedDB), stop: make(chan struct{})}
+
+	err := mrEnv.Iter(func(name string, dEnv *env.DoltEnv) (stop bool, err error) {
+		remoteName := serverConfig.ReplicateToRemote()
+		if remoteName == "" {
+			remoteName = *dEnv.Config.GetStringOrDefault(env.ReplicateToRemoteKey, "")
+		}
+
+		if remoteName == "" {
+			return false, nil
+		}
+
+		remotes, err := dEnv.GetRemotes()
+
+		if err != nil {
+			return true, err
+		}
+
+		remote, ok := remotes[remoteName]
+
+		if !ok {
+			return true, fmt.Errorf("database %s cannot be replicated to the remote %s, as it does not exist", name, remoteName)
+		}
+
+		r.dbs[name] = &replicatedDB{
+			dEnv:     dEnv,
+			remote:   remote,
+			queue:    make(chan ref.BranchRef, replicationQueueSize),
+			branches: make(map[string]*branchReplication),
+		}
+
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r.dbs) == 0 {
+		return nil, nil
+	}
+
+	for name, db := range r.dbs {
+		r.wg.Add(1)
+		go func(name string, db *replicatedDB) {
+			defer r.wg.Done()
+			db.run(name, r.stop)
+		}(name, db)
+
+		r.Replicate(name, db.dEnv.RepoStateReader().CWBHeadRef())
+	}
+
+	return r, nil
+}
+
+// Replicate implements dsqle.Replicator.
+func (r *Replicator) Replicate(dbName string, branch ref.DoltRef) {
+	db, ok := r.dbs[dbName]
+
+	if !ok || branch.GetType() != ref.BranchRefType {
+		return
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.closed {
+		return
+	}
+
+	br := db.branch(branch.GetPath())
+	if br.pendingSince.IsZero() {
+		br.pendingSince = time.Now()
+	}
+
+	if br.queued {
+		return
+	}
+
+	select {
+	case db.queue <- ref.NewBranchRef(branch.GetPath()):
+		br.queued = true
+		br.queuedAt = time.Now()
+	default:
+		br.status.LastError = errReplicationQueueFull.Error()
+		logrus.Warnf("branch %s of database %s was not replicated: %v", branch.GetPath(), dbName, errReplicationQueueFull)
+	}
+}
+
+// ReplicationStatus implements dtables.ReplicationStatusReader.
+func (r *Replicator) ReplicationStatus(dbName string) []dtables.ReplicationStatus {
+	db, ok := r.dbs[dbName]
+
+	if !ok {
+		return nil
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	now := time.Now()
+	statuses := make([]dtables.ReplicationStatus, 0, len(db.branches))
+	for _, br := range db.branches {
+		status := br.status
+		if !br.pendingSince.IsZero() {
+			status.Lag = now.Sub(br.pendingSince)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Branch < statuses[j].Branch
+	})
+
+	return statuses
+}
+
+// Close stops queueing changed branches, and waits for the branches which are already queued to be pushed. Failed
+// pushes are not retried once Close is called.
+func (r *Replicator) Close() {
+	close(r.stop)
+
+	for _, db := range r.dbs {
+		db.mu.Lock()
+		db.closed = true
+		close(db.queue)
+		db.mu.Unlock()
+	}
+
+	r.wg.Wait()
+}
+
+// branch returns the replication state of the branch |name|. The caller must hold the lock of |db|.
+func (db *replicatedDB) branch(name string) *branchReplication {
+	br, ok := db.branches[name]
+
+	if !ok {
+		br = &branchReplication{status: dtables.ReplicationStatus{Branch: name, Remote: db.remote.Name}}
+		db.branches[name] = br
+	}
+
+	return br
+}
+
+// run pushes the branches of the queue of |db| until it is closed.
+func (db *replicatedDB) run(name string, stop <-chan struct{}) {
+	for branch := range db.queue {
+		db.mu.Lock()
+		db.branch(branch.GetPath()).queued = false
+		db.mu.Unlock()
+
+		backoff := replicationBackoff
+		for attempt := 1; ; attempt++ {
+			err := db.push(context.Background(), branch)
+
+			if err == nil {
+				break
+			}
+
+			logrus.Warnf("failed to replicate branch %s of database %s to remote %s (attempt %d of %d): %v", branch.GetPath(), name, db.remote.Name, attempt, replicationAttempts, err)
+
+			if attempt == replicationAttempts || !wait(stop, backoff) {
+				break
+			}
+
+			backoff *= 2
+		}
+	}
+}
+
+// wait waits for |d|, and returns false if |stop| is closed first.
+func wait(stop <-chan struct{}, d time.Duration) bool {
+	select {
+	case <-stop:
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+// push pushes the latest commit of |branch| to the remote of |db|, and records the result in the status of the
+// branch. The branch of the remote is always set to the commit, so the remote follows the branch even if it is reset.
+func (db *replicatedDB) push(ctx context.Context, branch ref.BranchRef) (err error) {
+	var h string
+	defer func() {
+		db.mu.Lock()
+		defer db.mu.Unlock()
+
+		br := db.branch(branch.GetPath())
+		if err != nil {
+			br.status.LastError = err.Error()
+			return
+		}
+
+		br.status.ReplicatedHash = h
+		br.status.LastReplicated = time.Now()
+		br.status.LastError = ""
+
+		// if the branch was queued again while it was pushed, its remote is behind since it was queued
+		br.pendingSince = time.Time{}
+		if br.queued {
+			br.pendingSince = br.queuedAt
+		}
+	}()
+
+	srcDB := db.dEnv.DoltDB
+	cm, err := srcDB.ResolveRef(ctx, branch)
+
+	if err != nil {
+		return err
+	}
+
+	hash, err := cm.HashOf()
+
+	if err != nil {
+		return err
+	}
+
+	h = hash.String()
+
+	db.mu.Lock()
+	br := db.branch(branch.GetPath())
+	br.status.HeadHash = h
+	upToDate := br.status.ReplicatedHash == h
+	destDB := db.destDB
+	db.mu.Unlock()
+
+	if upToDate {
+		return nil
+	}
+
+	if destDB == nil {
+		destDB, err = db.remote.GetRemoteDB(ctx, srcDB.Format())
+
+		if err != nil {
+			return err
+		}
+
+		db.mu.Lock()
+		db.destDB = destDB
+		db.mu.Unlock()
+	}
+
+	remoteRef := ref.NewRemoteRef(db.remote.Name, branch.GetPath())
+	pullerEventCh := make(chan datas.PullerEvent, 128)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range pullerEventCh {
+		}
+	}()
+
+	err = actions.Push(ctx, db.dEnv, ref.ForceUpdate, branch, remoteRef, srcDB, destDB, cm, nil, pullerEventCh)
+
+	close(pullerEventCh)
+	<-done
+
+	return err
+}
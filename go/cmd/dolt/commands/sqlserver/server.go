@@ -17,6 +17,7 @@ package sqlserver
 import (
 	"context"
 	"net"
+	"path/filepath"
 	"strconv"
 	"time"
 
@@ -27,10 +28,12 @@ import (
 	"github.com/dolthub/go-mysql-server/sql/analyzer"
 	"github.com/dolthub/go-mysql-server/sql/information_schema"
 	"github.com/dolthub/vitess/go/mysql"
+	"github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
 
 	"github.com/dolthub/dolt/go/cmd/dolt/cli"
 	"github.com/dolthub/dolt/go/cmd/dolt/commands"
+	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	dsqle "github.com/dolthub/dolt/go/libraries/doltcore/sqle"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dfunctions"
@@ -76,16 +79,22 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 		logrus.SetLevel(level)
 	}
 
-	permissions := auth.AllPermissions
-	if serverConfig.ReadOnly() {
-		permissions = auth.ReadPerm
+	privilegeFile := serverConfig.PrivilegeFile()
+	if privilegeFile == "" && dEnv.HasDoltDir() {
+		privilegeFile = filepath.Join(dbfactory.DoltDir, PrivilegeFileName)
 	}
 
-	userAuth := auth.NewAudit(auth.NewNativeSingle(serverConfig.User(), serverConfig.Password(), permissions), auth.NewAuditLog(logrus.StandardLogger()))
+	var privileges *Privileges
+	privileges, startError = NewPrivileges(serverConfig, dEnv.FS, privilegeFile)
+	if startError != nil {
+		return
+	}
+
+	userAuth := auth.NewAudit(privileges, auth.NewAuditLog(logrus.StandardLogger()))
 
 	c := sql.NewCatalog()
 	a := analyzer.NewBuilder(c).WithParallelism(serverConfig.QueryParallelism()).Build()
-	sqlEngine := sqle.New(c, a, nil)
+	sqlEngine := sqle.New(c, a, &sqle.Config{Auth: userAuth})
 
 	err := sqlEngine.Catalog.Register(dfunctions.DoltFunctions...)
 
@@ -116,6 +125,27 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 		}
 	}
 
+	var readReplica *ReadReplica
+	readReplica, startError = NewReadReplica(ctx, serverConfig, mrEnv)
+	if startError != nil {
+		return
+	}
+
+	if readReplica != nil {
+		defer readReplica.Close()
+		privileges.setReadReplicas(readReplica.DatabaseNames())
+	}
+
+	var replicator *Replicator
+	replicator, startError = NewReplicator(serverConfig, mrEnv)
+	if startError != nil {
+		return
+	}
+
+	if replicator != nil {
+		defer replicator.Close()
+	}
+
 	dbs := commands.CollectDBs(mrEnv, newDatabase)
 
 	for _, db := range dbs {
@@ -124,10 +154,16 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 
 	sqlEngine.AddDatabase(information_schema.NewInformationSchemaDatabase(sqlEngine.Catalog))
 
+	tlsConfig, startError := LoadTLSConfig(serverConfig)
+	if startError != nil {
+		cli.PrintErr(startError)
+		return
+	}
+
 	hostPort := net.JoinHostPort(serverConfig.Host(), strconv.Itoa(serverConfig.Port()))
 	readTimeout := time.Duration(serverConfig.ReadTimeout()) * time.Millisecond
 	writeTimeout := time.Duration(serverConfig.WriteTimeout()) * time.Millisecond
-	mySQLServer, startError = server.NewServer(
+	mySQLServer, startError = newServer(
 		server.Config{
 			Protocol:         "tcp",
 			Address:          hostPort,
@@ -139,7 +175,9 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 			// to the value of mysql that we support.
 		},
 		sqlEngine,
-		newSessionBuilder(sqlEngine, username, email, serverConfig.AutoCommit()),
+		newSessionBuilder(sqlEngine, privileges, replicator, username, email, serverConfig.AutoCommit()),
+		privileges,
+		readReplica,
 	)
 
 	if startError != nil {
@@ -147,6 +185,9 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 		return
 	}
 
+	mySQLServer.Listener.TLSConfig = tlsConfig
+	mySQLServer.Listener.RequireSecureTransport = serverConfig.RequireSecureTransport()
+
 	serverController.registerCloseFunction(startError, mySQLServer.Close)
 	closeError = mySQLServer.Start()
 	if closeError != nil {
@@ -156,7 +197,48 @@ func Serve(ctx context.Context, version string, serverConfig ServerConfig, serve
 	return
 }
 
-func newSessionBuilder(sqlEngine *sqle.Engine, username, email string, autocommit bool) server.SessionBuilder {
+// newServer returns a server like server.NewServer, whose handler also executes the statements which manage the users
+// of |privileges|, and starts the transactions of |readReplica| if it is not nil.
+func newServer(cfg server.Config, e *sqle.Engine, sb server.SessionBuilder, privileges *Privileges, readReplica *ReadReplica) (*server.Server, error) {
+	if cfg.ConnReadTimeout < 0 {
+		cfg.ConnReadTimeout = 0
+	}
+
+	if cfg.ConnWriteTimeout < 0 {
+		cfg.ConnWriteTimeout = 0
+	}
+
+	sm := server.NewSessionManager(sb, opentracing.NoopTracer{}, e.Catalog.HasDB, e.Catalog.MemoryManager, cfg.Address)
+	handler := server.NewHandler(e, sm, cfg.ConnReadTimeout)
+	l, err := server.NewListener(cfg.Protocol, cfg.Address, handler)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var h mysql.Handler = privilegesHandler{Handler: handler, privileges: privileges}
+	if readReplica != nil {
+		h = newReadReplicaHandler(h, sm, readReplica)
+	}
+
+	vtListener, err := mysql.NewListenerWithConfig(mysql.ListenerConfig{
+		Listener:           l,
+		AuthServer:         cfg.Auth.Mysql(),
+		Handler:            h,
+		ConnReadTimeout:    cfg.ConnReadTimeout,
+		ConnWriteTimeout:   cfg.ConnWriteTimeout,
+		MaxConns:           cfg.MaxConnections,
+		ConnReadBufferSize: mysql.DefaultConnBufferSize,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &server.Server{Listener: vtListener}, nil
+}
+
+func newSessionBuilder(sqlEngine *sqle.Engine, privileges *Privileges, replicator *Replicator, username, email string, autocommit bool) server.SessionBuilder {
 	return func(ctx context.Context, conn *mysql.Conn, host string) (sql.Session, *sql.IndexRegistry, *sql.ViewRegistry, error) {
 		mysqlSess := sql.NewSession(host, conn.RemoteAddr().String(), conn.User, conn.ConnectionID)
 		doltSess, err := dsqle.NewDoltSession(ctx, mysqlSess, username, email, dbsAsDSQLDBs(sqlEngine.Catalog.AllDatabases())...)
@@ -165,6 +247,11 @@ func newSessionBuilder(sqlEngine *sqle.Engine, username, email string, autocommi
 			return nil, nil, nil, err
 		}
 
+		doltSess.Privileges = privileges
+		if replicator != nil {
+			doltSess.Replicator = replicator
+		}
+
 		err = doltSess.Set(ctx, sql.AutoCommitSessionVar, sql.Boolean, autocommit)
 
 		if err != nil {
@@ -15,16 +15,34 @@
 package sqlserver
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"database/sql"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 	"github.com/gocraft/dbr/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
 
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/dtestutils"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 )
 
 type testPerson struct {
@@ -216,3 +234,309 @@ func TestServerSelect(t *testing.T) {
 		})
 	}
 }
+
+func TestServerTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sql-server-tls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile, pool := writeTestCertificate(t, dir)
+	err = mysql.RegisterTLSConfig("sqlservertest", &tls.Config{RootCAs: pool, ServerName: "localhost"})
+	require.NoError(t, err)
+	defer mysql.DeregisterTLSConfig("sqlservertest")
+
+	env := dtestutils.CreateEnvWithSeedData(t)
+	tests := []ServerConfig{
+		DefaultServerConfig().withPort(15500).withTLS(keyFile, certFile, false),
+		DefaultServerConfig().withPort(15501).withTLS(keyFile, certFile, true),
+	}
+
+	for _, test := range tests {
+		t.Run(ConfigInfo(test), func(t *testing.T) {
+			sc := CreateServerController()
+			go func(config ServerConfig, sc *ServerController) {
+				_, _ = Serve(context.Background(), "", config, sc, env)
+			}(test, sc)
+			err := sc.WaitForStart()
+			require.NoError(t, err)
+
+			conn, err := dbr.Open("mysql", ConnectionString(test)+"?tls=sqlservertest", nil)
+			require.NoError(t, err)
+			assert.NoError(t, conn.Ping())
+			require.NoError(t, conn.Close())
+
+			conn, err = dbr.Open("mysql", ConnectionString(test), nil)
+			require.NoError(t, err)
+			if test.RequireSecureTransport() {
+				assert.Error(t, conn.Ping())
+			} else {
+				assert.NoError(t, conn.Ping())
+			}
+			require.NoError(t, conn.Close())
+
+			sc.StopServer()
+			err = sc.WaitForClose()
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestServerBadTLSConfig(t *testing.T) {
+	tests := []ServerConfig{
+		DefaultServerConfig().withTLS("key.pem", "", false),
+		DefaultServerConfig().withTLS("", "cert.pem", false),
+		DefaultServerConfig().withTLS("", "", true),
+	}
+
+	for _, test := range tests {
+		assert.Error(t, ValidateConfig(test))
+	}
+}
+
+// writeTestCertificate writes a self signed certificate for localhost and its key to |dir|, and returns their paths
+// and a pool trusting the certificate.
+func writeTestCertificate(t *testing.T, dir string) (string, string, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	err = ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	require.NoError(t, err)
+	err = ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	return certFile, keyFile, pool
+}
+
+func TestServerPrivileges(t *testing.T) {
+	env := dtestutils.CreateEnvWithSeedData(t)
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15302).withMaxConnections(10).withUsers([]UserAccountConfig{
+		{Name: "reader", Password: "pass", Privileges: map[string][]string{"dolt": {"read"}}},
+		{Name: "writer", Password: "pass", Privileges: map[string][]string{"dolt": {"read", "write"}}},
+		{Name: "committer", Password: "pass", Privileges: map[string][]string{"*": {"read", "write", "execute"}}},
+	})
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(context.Background(), "", serverConfig, sc, env)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	connect := func(user string) *dbr.Connection {
+		dsn := fmt.Sprintf("%s:pass@tcp(%s:%d)/dolt", user, serverConfig.Host(), serverConfig.Port())
+		conn, err := dbr.Open("mysql", dsn, nil)
+		require.NoError(t, err)
+		return conn
+	}
+
+	tests := []struct {
+		user  string
+		query string
+		// expectedErr is a substring of the expected error, or "" if the query succeeds
+		expectedErr string
+	}{
+		{"reader", "SELECT * FROM people", ""},
+		{"reader", "INSERT INTO people (id, name, age, is_married) VALUES ('00000000-0000-0000-0000-000000000010', 'Reader', 1, false)", "does not have the write privilege on database dolt"},
+		{"reader", "CREATE TABLE reader_table (pk int primary key)", "does not have the write privilege on database dolt"},
+		{"reader", "SELECT DOLT_ADD('-A')", "does not have the write privilege on database dolt"},
+		{"reader", "SELECT DOLT_CHECKOUT('-b', 'reader_branch')", "does not have the write privilege on database dolt"},
+		{"reader", "SELECT DOLT_CONFLICTS_RESOLVE('--theirs', 'people')", "does not have the write privilege on database dolt"},
+		{"writer", "INSERT INTO people (id, name, age, is_married) VALUES ('00000000-0000-0000-0000-000000000011', 'Writer', 1, false)", ""},
+		{"writer", "SELECT DOLT_ADD('-A')", ""},
+		{"writer", "SELECT DOLT_COMMIT('-a', '-m', 'from writer')", "does not have the execute privilege on database dolt"},
+		{"committer", "SELECT DOLT_COMMIT('-a', '-m', 'from committer')", ""},
+		{"unknown", "SELECT * FROM people", "Access denied"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.user+": "+test.query, func(t *testing.T) {
+			conn := connect(test.user)
+			defer conn.Close()
+
+			rows, err := conn.Query(test.query)
+			if err == nil {
+				require.NoError(t, rows.Close())
+			}
+
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), test.expectedErr)
+			}
+		})
+	}
+}
+
+func TestServerReplication(t *testing.T) {
+	dEnv := dtestutils.CreateEnvWithSeedData(t)
+	dEnv.RepoState.AddRemote(env.NewRemote("backup", "mem://backup", nil))
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15303).withReplicateToRemote("backup")
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(context.Background(), "", serverConfig, sc, dEnv)
+	}()
+	err := sc.WaitForStart()
+	require.NoError(t, err)
+
+	conn, err := dbr.Open("mysql", ConnectionString(serverConfig)+"dolt", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Exec("INSERT INTO people (id, name, age, is_married) VALUES ('00000000-0000-0000-0000-000000000020', 'Replicated', 1, false)")
+	require.NoError(t, err)
+
+	var commitHash string
+	err = conn.QueryRow("SELECT DOLT_COMMIT('-a', '-m', 'replicated commit')").Scan(&commitHash)
+	require.NoError(t, err)
+
+	var branch, remote string
+	var replicatedHash sql.NullString
+	var lag float64
+	require.Eventually(t, func() bool {
+		err := conn.QueryRow("SELECT branch, remote, replicated_hash, lag_seconds FROM dolt_replication_status").Scan(&branch, &remote, &replicatedHash, &lag)
+		return err == nil && replicatedHash.String == commitHash
+	}, 10*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, "master", branch)
+	assert.Equal(t, "backup", remote)
+	assert.Equal(t, float64(0), lag)
+
+	cm, err := dEnv.DoltDB.ResolveRef(context.Background(), ref.NewRemoteRef("backup", "master"))
+	require.NoError(t, err)
+	h, err := cm.HashOf()
+	require.NoError(t, err)
+	assert.Equal(t, commitHash, h.String())
+}
+
+func TestBadReplicationConfig(t *testing.T) {
+	dEnv := dtestutils.CreateEnvWithSeedData(t)
+	mrEnv := env.DoltEnvAsMultiEnv(dEnv)
+
+	replicator, err := NewReplicator(DefaultServerConfig(), mrEnv)
+	require.NoError(t, err)
+	assert.Nil(t, replicator)
+
+	_, err = NewReplicator(DefaultServerConfig().withReplicateToRemote("backup"), mrEnv)
+	assert.Error(t, err)
+}
+
+func TestServerReadReplica(t *testing.T) {
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "sql-server-read-replica")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	dEnv := dtestutils.CreateEnvWithSeedData(t)
+	remoteURL := "file://" + filepath.ToSlash(dir)
+	primaryDB, err := doltdb.LoadDoltDB(ctx, dEnv.DoltDB.Format(), remoteURL)
+	require.NoError(t, err)
+
+	// the seed data is committed, and the primary starts with the commits of the replica
+	master := ref.NewBranchRef("master")
+	parent, err := dEnv.DoltDB.ResolveRef(ctx, master)
+	require.NoError(t, err)
+	root, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
+	h, err := dEnv.DoltDB.WriteRootValue(ctx, root)
+	require.NoError(t, err)
+	meta, err := doltdb.NewCommitMeta("replica", "replica@dolthub.com", "seed data")
+	require.NoError(t, err)
+	cm, err := dEnv.DoltDB.CommitWithParentCommits(ctx, h, master, []*doltdb.Commit{parent}, meta)
+	require.NoError(t, err)
+	stRef, err := cm.GetStRef()
+	require.NoError(t, err)
+	require.NoError(t, primaryDB.PushChunks(ctx, dir, dEnv.DoltDB, stRef, nil, nil))
+	require.NoError(t, primaryDB.SetHeadToCommit(ctx, master, cm))
+	dEnv.RepoState.AddRemote(env.NewRemote("primary", remoteURL, nil))
+
+	serverConfig := DefaultServerConfig().withLogLevel(LogLevel_Fatal).withPort(15304).withReadReplica("primary", 0)
+
+	sc := CreateServerController()
+	defer sc.StopServer()
+	go func() {
+		_, _ = Serve(ctx, "", serverConfig, sc, dEnv)
+	}()
+	err = sc.WaitForStart()
+	require.NoError(t, err)
+
+	conn, err := dbr.Open("mysql", ConnectionString(serverConfig)+"dolt", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var count int
+	require.NoError(t, conn.QueryRow("SELECT COUNT(*) FROM people").Scan(&count))
+	assert.Equal(t, 3, count)
+
+	_, err = conn.Exec("INSERT INTO people (id, name, age, is_married) VALUES ('00000000-0000-0000-0000-000000000030', 'Replica', 1, false)")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "read replica")
+	}
+
+	// a commit on the primary is seen by the next transaction of the replica
+	tbl, _, err := root.GetTable(ctx, "people")
+	require.NoError(t, err)
+	root, err = root.PutTable(ctx, "people_copy", tbl)
+	require.NoError(t, err)
+	h, err = primaryDB.WriteRootValue(ctx, root)
+	require.NoError(t, err)
+	meta, err = doltdb.NewCommitMeta("primary", "primary@dolthub.com", "copied people")
+	require.NoError(t, err)
+	_, err = primaryDB.CommitWithParentCommits(ctx, h, master, []*doltdb.Commit{cm}, meta)
+	require.NoError(t, err)
+
+	require.NoError(t, conn.QueryRow("SELECT COUNT(*) FROM people_copy").Scan(&count))
+	assert.Equal(t, 3, count)
+
+	var message string
+	require.NoError(t, conn.QueryRow("SELECT message FROM dolt_log LIMIT 1").Scan(&message))
+	assert.Equal(t, "copied people", message)
+
+	// a reset of the primary is followed by the replica, though it is not a fast-forward
+	require.NoError(t, primaryDB.SetHeadToCommit(ctx, master, cm))
+
+	require.NoError(t, conn.QueryRow("SELECT message FROM dolt_log LIMIT 1").Scan(&message))
+	assert.Equal(t, "seed data", message)
+	_, err = conn.Query("SELECT COUNT(*) FROM people_copy")
+	assert.Error(t, err)
+}
+
+func TestBadReadReplicaConfig(t *testing.T) {
+	dEnv := dtestutils.CreateEnvWithSeedData(t)
+	mrEnv := env.DoltEnvAsMultiEnv(dEnv)
+
+	readReplica, err := NewReadReplica(context.Background(), DefaultServerConfig(), mrEnv)
+	require.NoError(t, err)
+	assert.Nil(t, readReplica)
+
+	_, err = NewReadReplica(context.Background(), DefaultServerConfig().withReadReplica("primary", 0), mrEnv)
+	assert.Error(t, err)
+}
@@ -15,6 +15,7 @@
 package sqlserver
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 
@@ -94,6 +95,26 @@ type ServerConfig interface {
 	MaxConnections() uint64
 	// QueryParallelism returns the parallelism that should be used by the go-mysql-server analyzer
 	QueryParallelism() int
+	// TLSKey returns a path to the servers PEM-encoded private TLS key. "" if there is none.
+	TLSKey() string
+	// TLSCert returns a path to the servers PEM-encoded TLS certificate chain. "" if there is none.
+	TLSCert() string
+	// RequireSecureTransport is true if the server should reject non-TLS connections.
+	RequireSecureTransport() bool
+	// Users returns the users, other than User, which may connect to the server, and their privileges.
+	Users() []UserAccountConfig
+	// PrivilegeFile returns the path of the file storing the users created with sql statements. "" if the server should
+	// use the privilege file in the .dolt directory of the current directory.
+	PrivilegeFile() string
+	// ReplicateToRemote returns the name of the remote which the changes to every database are pushed to. "" if the
+	// remote of each database is given by its repo config.
+	ReplicateToRemote() string
+	// ReadReplicaRemote returns the name of the remote which every database follows as a read replica. "" if the
+	// remote of each database is given by its repo config.
+	ReadReplicaRemote() string
+	// ReadReplicaInterval returns the interval in milliseconds at which read replicas pull from their remote. If it is
+	// 0 they pull at the start of every transaction.
+	ReadReplicaInterval() uint64
 }
 
 type commandLineServerConfig struct {
@@ -108,6 +129,14 @@ type commandLineServerConfig struct {
 	autoCommit       bool
 	maxConnections   uint64
 	queryParallelism int
+	tlsKey           string
+	tlsCert          string
+	requireSecure    bool
+	users            []UserAccountConfig
+	privilegeFile    string
+	replicateRemote  string
+	replicaRemote    string
+	replicaInterval  uint64
 }
 
 // Host returns the domain that the server will run on. Accepts an IPv4 or IPv6 address, in addition to localhost.
@@ -172,6 +201,46 @@ func (cfg *commandLineServerConfig) DatabaseNamesAndPaths() []env.EnvNameAndPath
 	return cfg.dbNamesAndPaths
 }
 
+// TLSKey returns a path to the servers PEM-encoded private TLS key. "" if there is none.
+func (cfg *commandLineServerConfig) TLSKey() string {
+	return cfg.tlsKey
+}
+
+// TLSCert returns a path to the servers PEM-encoded TLS certificate chain. "" if there is none.
+func (cfg *commandLineServerConfig) TLSCert() string {
+	return cfg.tlsCert
+}
+
+// RequireSecureTransport is true if the server should reject non-TLS connections.
+func (cfg *commandLineServerConfig) RequireSecureTransport() bool {
+	return cfg.requireSecure
+}
+
+// Users returns the users, other than User, which may connect to the server, and their privileges.
+func (cfg *commandLineServerConfig) Users() []UserAccountConfig {
+	return cfg.users
+}
+
+// PrivilegeFile returns the path of the file storing the users created with sql statements.
+func (cfg *commandLineServerConfig) PrivilegeFile() string {
+	return cfg.privilegeFile
+}
+
+// ReplicateToRemote returns the name of the remote which the changes to every database are pushed to.
+func (cfg *commandLineServerConfig) ReplicateToRemote() string {
+	return cfg.replicateRemote
+}
+
+// ReadReplicaRemote returns the name of the remote which every database follows as a read replica.
+func (cfg *commandLineServerConfig) ReadReplicaRemote() string {
+	return cfg.replicaRemote
+}
+
+// ReadReplicaInterval returns the interval in milliseconds at which read replicas pull from their remote.
+func (cfg *commandLineServerConfig) ReadReplicaInterval() uint64 {
+	return cfg.replicaInterval
+}
+
 // withHost updates the host and returns the called `*commandLineServerConfig`, which is useful for chaining calls.
 func (cfg *commandLineServerConfig) withHost(host string) *commandLineServerConfig {
 	cfg.host = host
@@ -227,6 +296,44 @@ func (cfg *commandLineServerConfig) withQueryParallelism(queryParallelism int) *
 	return cfg
 }
 
+// withTLS updates the TLS key, certificate and whether secure transport is required, and returns the called
+// `*commandLineServerConfig`, which is useful for chaining calls.
+func (cfg *commandLineServerConfig) withTLS(key, cert string, requireSecure bool) *commandLineServerConfig {
+	cfg.tlsKey = key
+	cfg.tlsCert = cert
+	cfg.requireSecure = requireSecure
+	return cfg
+}
+
+// withUsers updates the users which may connect to the server in addition to the configured user, and returns the
+// called `*commandLineServerConfig`, which is useful for chaining calls.
+func (cfg *commandLineServerConfig) withUsers(users []UserAccountConfig) *commandLineServerConfig {
+	cfg.users = users
+	return cfg
+}
+
+// withPrivilegeFile updates the path of the privilege file and returns the called `*commandLineServerConfig`, which
+// is useful for chaining calls.
+func (cfg *commandLineServerConfig) withPrivilegeFile(privilegeFile string) *commandLineServerConfig {
+	cfg.privilegeFile = privilegeFile
+	return cfg
+}
+
+// withReplicateToRemote updates the remote which the changes to every database are pushed to, and returns the called
+// `*commandLineServerConfig`, which is useful for chaining calls.
+func (cfg *commandLineServerConfig) withReplicateToRemote(remote string) *commandLineServerConfig {
+	cfg.replicateRemote = remote
+	return cfg
+}
+
+// withReadReplica updates the remote which every database follows as a read replica and the interval at which it is
+// pulled, and returns the called `*commandLineServerConfig`, which is useful for chaining calls.
+func (cfg *commandLineServerConfig) withReadReplica(remote string, interval uint64) *commandLineServerConfig {
+	cfg.replicaRemote = remote
+	cfg.replicaInterval = interval
+	return cfg
+}
+
 func (cfg *commandLineServerConfig) withDBNamesAndPaths(dbNamesAndPaths []env.EnvNameAndPath) *commandLineServerConfig {
 	cfg.dbNamesAndPaths = dbNamesAndPaths
 	return cfg
@@ -265,9 +372,32 @@ func ValidateConfig(config ServerConfig) error {
 	if config.LogLevel().String() == "unknown" {
 		return fmt.Errorf("loglevel is invalid: %v\n", string(config.LogLevel()))
 	}
+	if (config.TLSKey() == "") != (config.TLSCert() == "") {
+		return fmt.Errorf("tls_key and tls_cert must both be set or both be unset")
+	}
+	if config.RequireSecureTransport() && config.TLSKey() == "" {
+		return fmt.Errorf("require_secure_transport requires tls_key and tls_cert to be set")
+	}
+	if _, err := configUserAccounts(config); err != nil {
+		return err
+	}
 	return nil
 }
 
+// LoadTLSConfig returns the TLS config of the server, or nil if it does not use TLS.
+func LoadTLSConfig(config ServerConfig) (*tls.Config, error) {
+	if config.TLSKey() == "" {
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(config.TLSCert(), config.TLSKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tls_cert and tls_key: %w", err)
+	}
+
+	return &tls.Config{Certificates: []tls.Certificate{cert}}, nil
+}
+
 // ConnectionString returns a Data Source Name (DSN) to be used by go clients for connecting to a running server.
 func ConnectionString(config ServerConfig) string {
 	return fmt.Sprintf("%v:%v@tcp(%v:%v)/", config.User(), config.Password(), config.Host(), config.Port())
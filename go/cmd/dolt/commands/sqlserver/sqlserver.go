@@ -63,6 +63,12 @@ SUPPORTED CONFIG FILE FIELDS:
 
 		{{.EmphasisLeft}}behavior.autocommit{{.EmphasisRight}} - If true write queries will automatically alter the working set. When working with autocommit enabled it is highly recommended that listener.max_connections be set to 1 as concurrency issues will arise otherwise
 
+		{{.EmphasisLeft}}behavior.replicate_to_remote{{.EmphasisRight}} - The name of a remote which the checked out branch is pushed to in the background, after every DOLT_COMMIT and every transaction commit. Uncommitted changes to the working set are not pushed. Each database must have a remote with this name. If it is not set, a database is replicated to the remote given by the {{.EmphasisLeft}}sqlserver.replicate_to_remote{{.EmphasisRight}} key of its repo config, if any. The {{.EmphasisLeft}}dolt_replication_status{{.EmphasisRight}} system table shows how far behind the remote is
+
+		{{.EmphasisLeft}}behavior.read_replica_remote{{.EmphasisRight}} - The name of a remote which every database follows as a read replica. The checked out branch is pulled from the remote and fast-forwarded at the start of every transaction, and the database cannot be written to. Each database must have a remote with this name. If it is not set, a database is a read replica of the remote given by the {{.EmphasisLeft}}sqlserver.read_replica_remote{{.EmphasisRight}} key of its repo config, if any
+
+		{{.EmphasisLeft}}behavior.read_replica_interval_millis{{.EmphasisRight}} - If set, read replicas are pulled on this interval rather than at the start of every transaction
+
 		{{.EmphasisLeft}}user.name{{.EmphasisRight}} - The username that connections should use for authentication
 
 		{{.EmphasisLeft}}user.password{{.EmphasisRight}} - The password that connections should use for authentication.
@@ -77,6 +83,12 @@ SUPPORTED CONFIG FILE FIELDS:
 
 		{{.EmphasisLeft}}listener.write_timeout_millis{{.EmphasisRight}} - The number of milliseconds that the server will wait for a write operation
 
+		{{.EmphasisLeft}}listener.tls_key{{.EmphasisRight}} - The path of an unencrypted PEM encoded private key used to accept TLS connections. Requires listener.tls_cert
+
+		{{.EmphasisLeft}}listener.tls_cert{{.EmphasisRight}} - The path of the PEM encoded certificate chain used to accept TLS connections. Requires listener.tls_key
+
+		{{.EmphasisLeft}}listener.require_secure_transport{{.EmphasisRight}} - If true connections which do not use TLS are rejected
+
 		{{.EmphasisLeft}}performance.query_parallelism{{.EmphasisRight}} - Amount of go routines spawned to process each query
 
 		{{.EmphasisLeft}}databases{{.EmphasisRight}} - a list of dolt data repositories to make available as SQL databases. If databases is missing or empty then the working directory must be a valid dolt data repository which will be made available as a SQL database
@@ -85,6 +97,16 @@ SUPPORTED CONFIG FILE FIELDS:
 		
 		{{.EmphasisLeft}}databases[i].name{{.EmphasisRight}} - The name that the database corresponding to the given path should be referenced via SQL
 
+		{{.EmphasisLeft}}users{{.EmphasisRight}} - a list of users, in addition to the user of the user section, which may connect to the server. The user of the user section has every privilege on every database
+
+		{{.EmphasisLeft}}users[i].name{{.EmphasisRight}} - The name of the user
+
+		{{.EmphasisLeft}}users[i].password{{.EmphasisRight}} - The password of the user, or its mysql_native_password hash
+
+		{{.EmphasisLeft}}users[i].privileges{{.EmphasisRight}} - A map from a database name, or {{.EmphasisLeft}}*{{.EmphasisRight}} for every database, to the privileges of the user on it. The privileges are {{.EmphasisLeft}}read{{.EmphasisRight}}, {{.EmphasisLeft}}write{{.EmphasisRight}}, {{.EmphasisLeft}}execute{{.EmphasisRight}}, which allows calling DOLT_COMMIT, DOLT_MERGE and DOLT_RESET, and {{.EmphasisLeft}}grant{{.EmphasisRight}}, which allows managing users and can only be given on every database
+
+		{{.EmphasisLeft}}privilege_file{{.EmphasisRight}} - The file storing the users created with {{.EmphasisLeft}}CREATE USER{{.EmphasisRight}} and their privileges given with {{.EmphasisLeft}}GRANT{{.EmphasisRight}} and {{.EmphasisLeft}}REVOKE{{.EmphasisRight}}. Defaults to .dolt/privileges.json in the working directory. Users defined in the config file cannot be changed with sql statements
+
 If a config file is not provided many of these settings may be configured on the command line.`,
 	Synopsis: []string{
 		"--config {{.LessThan}}file{{.GreaterThan}}",
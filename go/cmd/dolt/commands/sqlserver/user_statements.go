@@ -0,0 +1,374 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/auth"
+	"github.com/dolthub/go-mysql-server/server"
+	"github.com/dolthub/vitess/go/mysql"
+	"github.com/dolthub/vitess/go/sqltypes"
+	"github.com/dolthub/vitess/go/vt/proto/query"
+)
+
+// Go-mysql-server does not parse the statements which manage users, so they are matched here. A user is a quoted or
+// bare name, optionally followed by a host, which is ignored.
+const (
+	quotedNamePattern = "'[^']*'|\"[^\"]*\"|`[^`]*`"
+	userPattern       = `(?:` + quotedNamePattern + `|[\w$]+)(?:@(?:` + quotedNamePattern + `|[\w$.%]+))?`
+	passwordPattern   = `'[^']*'|"[^"]*"`
+)
+
+var (
+	createUserRegex = regexp.MustCompile(`(?is)^create\s+user\s+(if\s+not\s+exists\s+)?(` + userPattern + `)(?:\s+identified\s+by\s+(` + passwordPattern + `))?$`)
+	dropUserRegex   = regexp.MustCompile(`(?is)^drop\s+user\s+(if\s+exists\s+)?(` + userPattern + `)$`)
+	grantRegex      = regexp.MustCompile(`(?is)^grant\s+(.+?)\s+on\s+(\S+)\s+to\s+(` + userPattern + `)(\s+with\s+grant\s+option)?$`)
+	revokeRegex     = regexp.MustCompile(`(?is)^revoke\s+(.+?)\s+on\s+(\S+)\s+from\s+(` + userPattern + `)$`)
+	showGrantsRegex = regexp.MustCompile(`(?is)^show\s+grants(?:\s+for\s+(` + userPattern + `))?$`)
+	whitespaceRegex = regexp.MustCompile(`\s+`)
+)
+
+// sqlPrivileges maps the privileges of GRANT and REVOKE statements to the privileges of a Privileges. Any privilege
+// which modifies a database gives write access to all of it.
+var sqlPrivileges = map[string]auth.Permission{
+	"SELECT":         auth.ReadPerm,
+	"INSERT":         auth.WritePerm,
+	"UPDATE":         auth.WritePerm,
+	"DELETE":         auth.WritePerm,
+	"CREATE":         auth.WritePerm,
+	"DROP":           auth.WritePerm,
+	"ALTER":          auth.WritePerm,
+	"INDEX":          auth.WritePerm,
+	"CREATE VIEW":    auth.WritePerm,
+	"TRIGGER":        auth.WritePerm,
+	"EXECUTE":        ExecutePerm,
+	"ALL":            auth.ReadPerm | auth.WritePerm | ExecutePerm,
+	"ALL PRIVILEGES": auth.ReadPerm | auth.WritePerm | ExecutePerm,
+	"GRANT OPTION":   GrantPerm,
+}
+
+// privilegesHandler is a mysql.Handler which executes the statements that manage the users of a Privileges, and
+// passes every other statement to the go-mysql-server handler.
+type privilegesHandler struct {
+	*server.Handler
+	privileges *Privileges
+}
+
+var _ mysql.Handler = privilegesHandler{}
+
+// ComQuery implements mysql.Handler.
+func (h privilegesHandler) ComQuery(c *mysql.Conn, query string, callback func(*sqltypes.Result) error) error {
+	result, ok, err := h.privileges.execUserStatement(c.User, query)
+
+	if !ok {
+		return h.Handler.ComQuery(c, query, callback)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return callback(result)
+}
+
+// execUserStatement executes |query| for the user |userName|, if it is a statement which manages users. It returns
+// false if it is not.
+func (p *Privileges) execUserStatement(userName, query string) (*sqltypes.Result, bool, error) {
+	s := strings.TrimSpace(query)
+	s = strings.TrimSpace(strings.TrimSuffix(s, ";"))
+
+	if m := showGrantsRegex.FindStringSubmatch(s); m != nil {
+		name := userName
+		if m[1] != "" {
+			name = accountName(m[1])
+		}
+
+		result, err := p.showGrants(userName, name)
+		return result, true, err
+	}
+
+	var f func(users map[string]*userAccount) error
+	if m := createUserRegex.FindStringSubmatch(s); m != nil {
+		f = createUser(accountName(m[2]), unquote(m[3]), m[1] != "")
+	} else if m := dropUserRegex.FindStringSubmatch(s); m != nil {
+		f = dropUser(accountName(m[2]), m[1] != "")
+	} else if m := grantRegex.FindStringSubmatch(s); m != nil {
+		perm, db, err := parseGrant(m[1], m[2])
+
+		if err != nil {
+			return nil, true, err
+		}
+
+		if m[4] != "" {
+			perm |= GrantPerm
+		}
+
+		f = grantPrivileges(accountName(m[3]), db, perm)
+	} else if m := revokeRegex.FindStringSubmatch(s); m != nil {
+		perm, db, err := parseGrant(m[1], m[2])
+
+		if err != nil {
+			return nil, true, err
+		}
+
+		f = revokePrivileges(accountName(m[3]), db, perm)
+	} else {
+		return nil, false, nil
+	}
+
+	if p.readOnly {
+		return nil, true, errors.New("users cannot be changed, as the server is read only")
+	}
+
+	if err := p.checkGrant(userName); err != nil {
+		return nil, true, err
+	}
+
+	err := p.update(f)
+
+	if err != nil {
+		return nil, true, err
+	}
+
+	return &sqltypes.Result{}, true, nil
+}
+
+func (p *Privileges) checkGrant(userName string) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	user, ok := p.users[userName]
+
+	if !ok {
+		return auth.ErrNotAuthorized.Wrap(auth.ErrNoPermission.New(GrantPerm))
+	}
+
+	return p.allowed(user, allDatabases, GrantPerm)
+}
+
+func createUser(name, password string, ifNotExists bool) func(users map[string]*userAccount) error {
+	return func(users map[string]*userAccount) error {
+		if _, ok := users[name]; ok {
+			if ifNotExists {
+				return nil
+			}
+
+			return fmt.Errorf("user %s already exists", name)
+		}
+
+		user, err := newUserAccount(UserAccountConfig{Name: name, Password: password}, false)
+
+		if err != nil {
+			return err
+		}
+
+		users[name] = user
+		return nil
+	}
+}
+
+func dropUser(name string, ifExists bool) func(users map[string]*userAccount) error {
+	return func(users map[string]*userAccount) error {
+		if _, ok := users[name]; !ok && ifExists {
+			return nil
+		}
+
+		user, err := changeableUser(users, name)
+
+		if err != nil {
+			return err
+		}
+
+		delete(users, user.name)
+		return nil
+	}
+}
+
+func grantPrivileges(name, db string, perm auth.Permission) func(users map[string]*userAccount) error {
+	return func(users map[string]*userAccount) error {
+		user, err := changeableUser(users, name)
+
+		if err != nil {
+			return err
+		}
+
+		if perm&GrantPerm != 0 && db != allDatabases {
+			return errors.New("the grant option can only be given on *.*")
+		}
+
+		user.privileges[db] |= perm
+		return nil
+	}
+}
+
+func revokePrivileges(name, db string, perm auth.Permission) func(users map[string]*userAccount) error {
+	return func(users map[string]*userAccount) error {
+		user, err := changeableUser(users, name)
+
+		if err != nil {
+			return err
+		}
+
+		user.privileges[db] &^= perm
+		if user.privileges[db] == 0 {
+			delete(user.privileges, db)
+		}
+
+		return nil
+	}
+}
+
+// changeableUser returns the user |name| of |users|, if it can be changed with sql statements.
+func changeableUser(users map[string]*userAccount, name string) (*userAccount, error) {
+	user, ok := users[name]
+
+	if !ok {
+		return nil, fmt.Errorf("user %s does not exist", name)
+	}
+
+	if user.fromConfig {
+		return nil, fmt.Errorf("user %s is defined in the server config, and cannot be changed with sql statements", name)
+	}
+
+	return user, nil
+}
+
+// parseGrant returns the privileges and the database of the privilege list |privs| and the target |on| of a GRANT or
+// REVOKE statement. Privileges can only be given on every database, *.*, or on a single database, such as db.*.
+func parseGrant(privs, on string) (auth.Permission, string, error) {
+	var perm auth.Permission
+	for _, priv := range strings.Split(privs, ",") {
+		priv = strings.ToUpper(whitespaceRegex.ReplaceAllString(strings.TrimSpace(priv), " "))
+		p, ok := sqlPrivileges[priv]
+
+		if !ok {
+			return 0, "", fmt.Errorf("unsupported privilege '%s'", priv)
+		}
+
+		perm |= p
+	}
+
+	switch {
+	case on == "*.*":
+		return perm, allDatabases, nil
+	case strings.HasSuffix(on, ".*"):
+		return perm, strings.ToLower(unquote(strings.TrimSuffix(on, ".*"))), nil
+	default:
+		return 0, "", fmt.Errorf("privileges can only be granted on databases, such as db.*, not on '%s'", on)
+	}
+}
+
+func (p *Privileges) showGrants(userName, name string) (*sqltypes.Result, error) {
+	if name != userName {
+		if err := p.checkGrant(userName); err != nil {
+			return nil, err
+		}
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	user, ok := p.users[name]
+
+	if !ok {
+		return nil, fmt.Errorf("user %s does not exist", name)
+	}
+
+	dbs := make([]string, 0, len(user.privileges))
+	for db, perm := range user.privileges {
+		if perm != 0 {
+			dbs = append(dbs, db)
+		}
+	}
+
+	sort.Strings(dbs)
+
+	result := &sqltypes.Result{
+		Fields: []*query.Field{{Name: "Grants for " + name, Type: sqltypes.VarChar}},
+	}
+
+	if len(dbs) == 0 {
+		result.Rows = append(result.Rows, []sqltypes.Value{sqltypes.NewVarChar(fmt.Sprintf("GRANT USAGE ON *.* TO `%s`", name))})
+	}
+
+	for _, db := range dbs {
+		on := "*.*"
+		if db != allDatabases {
+			on = fmt.Sprintf("`%s`.*", db)
+		}
+
+		grant := fmt.Sprintf("GRANT %s ON %s TO `%s`", grantPrivilegeList(user.privileges[db]), on, name)
+		if user.privileges[db]&GrantPerm != 0 {
+			grant += " WITH GRANT OPTION"
+		}
+
+		result.Rows = append(result.Rows, []sqltypes.Value{sqltypes.NewVarChar(grant)})
+	}
+
+	result.RowsAffected = uint64(len(result.Rows))
+	return result, nil
+}
+
+// grantPrivilegeList returns the privileges of |perm| as they are written in a GRANT statement.
+func grantPrivilegeList(perm auth.Permission) string {
+	if perm&sqlPrivileges["ALL"] == sqlPrivileges["ALL"] {
+		return "ALL PRIVILEGES"
+	}
+
+	var privs []string
+	if perm&auth.ReadPerm != 0 {
+		privs = append(privs, "SELECT")
+	}
+	if perm&auth.WritePerm != 0 {
+		privs = append(privs, "INSERT", "UPDATE", "DELETE", "CREATE", "DROP", "ALTER", "INDEX")
+	}
+	if perm&ExecutePerm != 0 {
+		privs = append(privs, "EXECUTE")
+	}
+	if len(privs) == 0 {
+		privs = append(privs, "USAGE")
+	}
+
+	return strings.Join(privs, ", ")
+}
+
+// accountName returns the name of the user |account|, which may be quoted and followed by a host.
+func accountName(account string) string {
+	if len(account) > 0 && strings.ContainsRune("'\"`", rune(account[0])) {
+		if end := strings.IndexByte(account[1:], account[0]); end >= 0 {
+			return account[1 : end+1]
+		}
+	}
+
+	if at := strings.IndexByte(account, '@'); at >= 0 {
+		return account[:at]
+	}
+
+	return account
+}
+
+// unquote removes the quotes around |s|, if it is quoted.
+func unquote(s string) string {
+	if len(s) >= 2 && strings.ContainsRune("'\"`", rune(s[0])) && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+
+	return s
+}
@@ -40,10 +40,37 @@ func intPtr(n int) *int {
 	return &n
 }
 
+func nillableStrPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func nillableBoolPtr(b bool) *bool {
+	if !b {
+		return nil
+	}
+	return &b
+}
+
+func nillableUint64Ptr(n uint64) *uint64 {
+	if n == 0 {
+		return nil
+	}
+	return &n
+}
+
 // BehaviorYAMLConfig contains server configuration regarding how the server should behave
 type BehaviorYAMLConfig struct {
 	ReadOnly   *bool `yaml:"read_only"`
 	AutoCommit *bool
+	// ReplicateToRemote is the name of the remote which the changes to every database are pushed to.
+	ReplicateToRemote *string `yaml:"replicate_to_remote,omitempty"`
+	// ReadReplicaRemote is the name of the remote which every database follows as a read replica.
+	ReadReplicaRemote *string `yaml:"read_replica_remote,omitempty"`
+	// ReadReplicaIntervalMillis is the interval at which read replicas pull from their remote.
+	ReadReplicaIntervalMillis *uint64 `yaml:"read_replica_interval_millis,omitempty"`
 }
 
 // UserYAMLConfig contains server configuration regarding the user account clients must use to connect
@@ -65,6 +92,12 @@ type ListenerYAMLConfig struct {
 	MaxConnections     *uint64 `yaml:"max_connections"`
 	ReadTimeoutMillis  *uint64 `yaml:"read_timeout_millis"`
 	WriteTimeoutMillis *uint64 `yaml:"write_timeout_millis"`
+	// TLSKey is a file system path to an unencrypted private TLS key in PEM format.
+	TLSKey *string `yaml:"tls_key,omitempty"`
+	// TLSCert is a file system path to a TLS certificate chain in PEM format.
+	TLSCert *string `yaml:"tls_cert,omitempty"`
+	// RequireSecureTransport can enable a mode where non-TLS connections are turned away.
+	RequireSecureTransport *bool `yaml:"require_secure_transport,omitempty"`
 }
 
 // PerformanceYAMLConfig contains configuration parameters for performance tweaking
@@ -80,6 +113,8 @@ type YAMLConfig struct {
 	ListenerConfig    ListenerYAMLConfig    `yaml:"listener"`
 	DatabaseConfig    []DatabaseYAMLConfig  `yaml:"databases"`
 	PerformanceConfig PerformanceYAMLConfig `yaml:"performance"`
+	UsersConfig       []UserAccountConfig   `yaml:"users,omitempty"`
+	PrivilegeFilePath *string               `yaml:"privilege_file,omitempty"`
 }
 
 func newYamlConfig(configFileData []byte) (YAMLConfig, error) {
@@ -91,7 +126,7 @@ func newYamlConfig(configFileData []byte) (YAMLConfig, error) {
 func serverConfigAsYAMLConfig(cfg ServerConfig) YAMLConfig {
 	return YAMLConfig{
 		LogLevelStr:    strPtr(string(cfg.LogLevel())),
-		BehaviorConfig: BehaviorYAMLConfig{boolPtr(cfg.ReadOnly()), boolPtr(cfg.AutoCommit())},
+		BehaviorConfig: BehaviorYAMLConfig{boolPtr(cfg.ReadOnly()), boolPtr(cfg.AutoCommit()), nillableStrPtr(cfg.ReplicateToRemote()), nillableStrPtr(cfg.ReadReplicaRemote()), nillableUint64Ptr(cfg.ReadReplicaInterval())},
 		UserConfig:     UserYAMLConfig{strPtr(cfg.User()), strPtr(cfg.Password())},
 		ListenerConfig: ListenerYAMLConfig{
 			strPtr(cfg.Host()),
@@ -99,8 +134,13 @@ func serverConfigAsYAMLConfig(cfg ServerConfig) YAMLConfig {
 			uint64Ptr(cfg.MaxConnections()),
 			uint64Ptr(cfg.ReadTimeout()),
 			uint64Ptr(cfg.WriteTimeout()),
+			nillableStrPtr(cfg.TLSKey()),
+			nillableStrPtr(cfg.TLSCert()),
+			nillableBoolPtr(cfg.RequireSecureTransport()),
 		},
-		DatabaseConfig: nil,
+		DatabaseConfig:    nil,
+		UsersConfig:       cfg.Users(),
+		PrivilegeFilePath: nillableStrPtr(cfg.PrivilegeFile()),
 	}
 }
 
@@ -246,3 +286,75 @@ func (cfg YAMLConfig) QueryParallelism() int {
 
 	return *cfg.PerformanceConfig.QueryParallelism
 }
+
+// TLSKey returns a path to the servers PEM-encoded private TLS key. "" if there is none.
+func (cfg YAMLConfig) TLSKey() string {
+	if cfg.ListenerConfig.TLSKey == nil {
+		return ""
+	}
+
+	return *cfg.ListenerConfig.TLSKey
+}
+
+// TLSCert returns a path to the servers PEM-encoded TLS certificate chain. "" if there is none.
+func (cfg YAMLConfig) TLSCert() string {
+	if cfg.ListenerConfig.TLSCert == nil {
+		return ""
+	}
+
+	return *cfg.ListenerConfig.TLSCert
+}
+
+// RequireSecureTransport is true if the server should reject non-TLS connections.
+func (cfg YAMLConfig) RequireSecureTransport() bool {
+	if cfg.ListenerConfig.RequireSecureTransport == nil {
+		return false
+	}
+
+	return *cfg.ListenerConfig.RequireSecureTransport
+}
+
+// Users returns the users, other than User, which may connect to the server, and their privileges.
+func (cfg YAMLConfig) Users() []UserAccountConfig {
+	return cfg.UsersConfig
+}
+
+// PrivilegeFile returns the path of the file storing the users created with sql statements. "" if the server should
+// use the privilege file in the .dolt directory of the current directory.
+func (cfg YAMLConfig) PrivilegeFile() string {
+	if cfg.PrivilegeFilePath == nil {
+		return ""
+	}
+
+	return *cfg.PrivilegeFilePath
+}
+
+// ReplicateToRemote returns the name of the remote which the changes to every database are pushed to. "" if the remote
+// of each database is given by its repo config.
+func (cfg YAMLConfig) ReplicateToRemote() string {
+	if cfg.BehaviorConfig.ReplicateToRemote == nil {
+		return ""
+	}
+
+	return *cfg.BehaviorConfig.ReplicateToRemote
+}
+
+// ReadReplicaRemote returns the name of the remote which every database follows as a read replica. "" if the remote
+// of each database is given by its repo config.
+func (cfg YAMLConfig) ReadReplicaRemote() string {
+	if cfg.BehaviorConfig.ReadReplicaRemote == nil {
+		return ""
+	}
+
+	return *cfg.BehaviorConfig.ReadReplicaRemote
+}
+
+// ReadReplicaInterval returns the interval in milliseconds at which read replicas pull from their remote. If it is 0
+// they pull at the start of every transaction.
+func (cfg YAMLConfig) ReadReplicaInterval() uint64 {
+	if cfg.BehaviorConfig.ReadReplicaIntervalMillis == nil {
+		return 0
+	}
+
+	return *cfg.BehaviorConfig.ReadReplicaIntervalMillis
+}
@@ -112,3 +112,73 @@ func TestYAMLConfigDefaults(t *testing.T) {
 	assert.Equal(t, defaultAutoCommit, cfg.AutoCommit())
 	assert.Equal(t, uint64(defaultMaxConnections), cfg.MaxConnections())
 }
+
+func TestYAMLConfigTLS(t *testing.T) {
+	testStr := `
+listener:
+    tls_key: /etc/dolt/key.pem
+    tls_cert: /etc/dolt/cert.pem
+    require_secure_transport: true
+`
+
+	cfg, err := newYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	assert.Equal(t, "/etc/dolt/key.pem", cfg.TLSKey())
+	assert.Equal(t, "/etc/dolt/cert.pem", cfg.TLSCert())
+	assert.True(t, cfg.RequireSecureTransport())
+	assert.NoError(t, ValidateConfig(cfg))
+
+	var defaults YAMLConfig
+	assert.Equal(t, "", defaults.TLSKey())
+	assert.Equal(t, "", defaults.TLSCert())
+	assert.False(t, defaults.RequireSecureTransport())
+}
+
+func TestYAMLConfigUsers(t *testing.T) {
+	testStr := `
+user:
+    name: root
+users:
+    - name: analyst
+      password: secret
+      privileges:
+          "*": [read]
+          reports: [read, write, execute]
+privilege_file: /var/lib/dolt/privileges.json
+`
+
+	cfg, err := newYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	assert.Equal(t, []UserAccountConfig{{
+		Name:       "analyst",
+		Password:   "secret",
+		Privileges: map[string][]string{"*": {"read"}, "reports": {"read", "write", "execute"}},
+	}}, cfg.Users())
+	assert.Equal(t, "/var/lib/dolt/privileges.json", cfg.PrivilegeFile())
+	assert.NoError(t, ValidateConfig(cfg))
+
+	var defaults YAMLConfig
+	assert.Empty(t, defaults.Users())
+	assert.Equal(t, "", defaults.PrivilegeFile())
+}
+
+func TestYAMLConfigReplication(t *testing.T) {
+	testStr := `
+behavior:
+    replicate_to_remote: backup
+    read_replica_remote: primary
+    read_replica_interval_millis: 500
+`
+
+	cfg, err := newYamlConfig([]byte(testStr))
+	require.NoError(t, err)
+	assert.Equal(t, "backup", cfg.ReplicateToRemote())
+	assert.Equal(t, "primary", cfg.ReadReplicaRemote())
+	assert.Equal(t, uint64(500), cfg.ReadReplicaInterval())
+	assert.Equal(t, "backup", serverConfigAsYAMLConfig(DefaultServerConfig().withReplicateToRemote("backup")).ReplicateToRemote())
+
+	var defaults YAMLConfig
+	assert.Equal(t, "", defaults.ReplicateToRemote())
+	assert.Equal(t, "", defaults.ReadReplicaRemote())
+	assert.Equal(t, uint64(0), defaults.ReadReplicaInterval())
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
 	"github.com/dolthub/dolt/go/libraries/utils/argparser"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
@@ -34,10 +35,21 @@ import (
 	"github.com/dolthub/dolt/go/libraries/utils/set"
 )
 
+const (
+	PorcelainFlag    = "porcelain"
+	StatusFormatFlag = "format"
+
+	statusFormatLong      = "long"
+	statusFormatPorcelain = "porcelain"
+	statusFormatJSON      = "json"
+)
+
 var statusDocs = cli.CommandDocumentationContent{
 	ShortDesc: "Show the working status",
-	LongDesc:  `Displays working tables that differ from the current HEAD commit, tables that differ from the staged tables, and tables that are in the working tree that are not tracked by dolt. The first are what you would commit by running {{.EmphasisLeft}}dolt commit{{.EmphasisRight}}; the second and third are what you could commit by running {{.EmphasisLeft}}dolt add .{{.EmphasisRight}} before running {{.EmphasisLeft}}dolt commit{{.EmphasisRight}}.`,
-	Synopsis:  []string{""},
+	LongDesc: `Displays working tables that differ from the current HEAD commit, tables that differ from the staged tables, and tables that are in the working tree that are not tracked by dolt. The first are what you would commit by running {{.EmphasisLeft}}dolt commit{{.EmphasisRight}}; the second and third are what you could commit by running {{.EmphasisLeft}}dolt add .{{.EmphasisRight}} before running {{.EmphasisLeft}}dolt commit{{.EmphasisRight}}.
+
+The output of {{.EmphasisLeft}}--porcelain{{.EmphasisRight}} and {{.EmphasisLeft}}--format json{{.EmphasisRight}} is meant to be parsed by scripts, and does not change between releases. The porcelain format starts with header lines beginning with {{.EmphasisLeft}}# {{.EmphasisRight}}, which give the branch, its upstream and the number of commits it is ahead and behind the upstream, and whether a merge is in progress. They are followed by a line for each changed table or doc, beginning with a two character code. The first character is the staged status of the table and the second its unstaged status: {{.EmphasisLeft}}A{{.EmphasisRight}} added, {{.EmphasisLeft}}M{{.EmphasisRight}} modified, {{.EmphasisLeft}}D{{.EmphasisRight}} deleted or {{.EmphasisLeft}}R{{.EmphasisRight}} renamed. Tables and docs with merge conflicts are {{.EmphasisLeft}}UU{{.EmphasisRight}}, and untracked ones {{.EmphasisLeft}}??{{.EmphasisRight}}.`,
+	Synopsis: []string{"[--porcelain | --format {{.LessThan}}format{{.GreaterThan}}]"},
 }
 
 type StatusCmd struct{}
@@ -60,14 +72,28 @@ func (cmd StatusCmd) CreateMarkdown(fs filesys.Filesys, path, commandStr string)
 
 func (cmd StatusCmd) createArgParser() *argparser.ArgParser {
 	ap := argparser.NewArgParser()
+	ap.SupportsFlag(PorcelainFlag, "", "Give the output in an easy to parse format. Equivalent to --format porcelain.")
+	ap.SupportsString(StatusFormatFlag, "", "format", "How to format the output. Valid values are long, porcelain and json. Defaults to long.")
 	return ap
 }
 
 // Exec executes the command
 func (cmd StatusCmd) Exec(ctx context.Context, commandStr string, args []string, dEnv *env.DoltEnv) int {
 	ap := cmd.createArgParser()
-	help, _ := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, statusDocs, ap))
-	cli.ParseArgs(ap, args, help)
+	help, usage := cli.HelpAndUsagePrinters(cli.GetCommandDocumentation(commandStr, statusDocs, ap))
+	apr := cli.ParseArgs(ap, args, help)
+
+	format := apr.GetValueOrDefault(StatusFormatFlag, statusFormatLong)
+	if apr.Contains(PorcelainFlag) {
+		if apr.Contains(StatusFormatFlag) {
+			return HandleVErrAndExitCode(errhand.BuildDError("--%s and --%s are mutually exclusive", PorcelainFlag, StatusFormatFlag).SetPrintUsage().Build(), usage)
+		}
+		format = statusFormatPorcelain
+	}
+
+	if format != statusFormatLong && format != statusFormatPorcelain && format != statusFormatJSON {
+		return HandleVErrAndExitCode(errhand.BuildDError("invalid format '%s'. Valid values are long, porcelain and json", format).SetPrintUsage().Build(), usage)
+	}
 
 	staged, notStaged, err := diff.GetStagedUnstagedTableDeltas(ctx, dEnv.DoltDB, dEnv.RepoStateReader())
 
@@ -82,6 +108,13 @@ func (cmd StatusCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return 1
 	}
 
+	workingTblsWithSchConflicts, err := merge.GetTablesWithSchemaConflicts(ctx, dEnv.DoltDB, dEnv.RepoStateReader())
+
+	if err != nil {
+		cli.PrintErrln(toStatusVErr(err).Verbose())
+		return 1
+	}
+
 	stagedDocDiffs, notStagedDocDiffs, err := diff.GetDocDiffs(ctx, dEnv.DoltDB, dEnv.RepoStateReader(), dEnv.DocsReadWriter())
 
 	if err != nil {
@@ -89,14 +122,39 @@ func (cmd StatusCmd) Exec(ctx context.Context, commandStr string, args []string,
 		return 1
 	}
 
-	workingDocsInConflict, err := merge.GetDocsInConflict(ctx, dEnv.DoltDB, dEnv.RepoStateReader(), dEnv.DocsReadWriter())
+	workingDocsInConflict, err := merge.GetDocsInConflict(ctx, dEnv.DoltDB, dEnv.RepoStateReader())
+
+	if err != nil {
+		cli.PrintErrln(toStatusVErr(err).Verbose())
+		return 1
+	}
+
+	branch := dEnv.RepoState.CWBHeadRef().GetPath()
+	upstream, err := actions.GetUpstreamStatus(ctx, dEnv.DoltDB, dEnv.RepoState.Branches, branch)
+
+	if err != nil {
+		cli.PrintErrln(toStatusVErr(err).Verbose())
+		return 1
+	}
+
+	if format == statusFormatLong {
+		printStatus(ctx, dEnv, upstream, staged, notStaged, workingTblsInConflict, workingTblsWithSchConflicts, workingDocsInConflict, stagedDocDiffs, notStagedDocDiffs)
+		return 0
+	}
+
+	rs := newRepoStatus(branch, upstream, dEnv.RepoState.Merge != nil, staged, notStaged, workingTblsInConflict, workingTblsWithSchConflicts, workingDocsInConflict, stagedDocDiffs, notStagedDocDiffs)
+
+	if format == statusFormatJSON {
+		err = printStatusJSON(cli.CliOut, rs)
+	} else {
+		err = printStatusPorcelain(cli.CliOut, rs)
+	}
 
 	if err != nil {
 		cli.PrintErrln(toStatusVErr(err).Verbose())
 		return 1
 	}
 
-	printStatus(ctx, dEnv, staged, notStaged, workingTblsInConflict, workingDocsInConflict, stagedDocDiffs, notStagedDocDiffs)
 	return 0
 }
 
@@ -132,12 +190,10 @@ const (
 
 	unmergedTablesHeader = `You have unmerged tables.
   (fix conflicts and run "dolt commit")
-  (use "dolt merge --abort" to abort the merge)
-`
+  (use "dolt merge --abort" to abort the merge)`
 
 	allMergedHeader = `All conflicts fixed but you are still merging.
-  (use "dolt commit" to conclude merge)
-`
+  (use "dolt commit" to conclude merge)`
 
 	mergedTableHeader = `Unmerged paths:`
 	mergedTableHelp   = `  (use "dolt add <file>..." to mark resolution)`
@@ -152,6 +208,8 @@ const (
 	statusFmt         = "\t%-16s%s"
 	statusRenameFmt   = "\t%-16s%s -> %s"
 	bothModifiedLabel = "both modified:"
+
+	schemaConflictSuffix = " (schema conflict)"
 )
 
 func printStagedDiffs(wr io.Writer, stagedTbls []diff.TableDelta, stagedDocs *diff.DocDiffs, printHelp bool) int {
@@ -190,10 +248,12 @@ func printStagedDiffs(wr io.Writer, stagedTbls []diff.TableDelta, stagedDocs *di
 	return 0
 }
 
-func printDiffsNotStaged(ctx context.Context, dEnv *env.DoltEnv, wr io.Writer, notStagedTbls []diff.TableDelta, notStagedDocs *diff.DocDiffs, printHelp bool, linesPrinted int, workingTblsInConflict []string) int {
+func printDiffsNotStaged(ctx context.Context, dEnv *env.DoltEnv, wr io.Writer, notStagedTbls []diff.TableDelta, notStagedDocs *diff.DocDiffs, printHelp bool, linesPrinted int, workingTblsInConflict, workingTblsWithSchConflicts []string) int {
 	inCnfSet := set.NewStrSet(workingTblsInConflict)
+	inCnfSet.Add(workingTblsWithSchConflicts...)
+	docsInCnf, _ := merge.GetDocsInConflict(ctx, dEnv.DoltDB, dEnv.RepoStateReader())
 
-	if len(workingTblsInConflict) > 0 {
+	if inCnfSet.Size() > 0 {
 		if linesPrinted > 0 {
 			cli.Println()
 		}
@@ -205,8 +265,16 @@ func printDiffsNotStaged(ctx context.Context, dEnv *env.DoltEnv, wr io.Writer, n
 		}
 
 		lines := make([]string, 0, len(notStagedTbls))
+		for _, tblName := range workingTblsWithSchConflicts {
+			lines = append(lines, fmt.Sprintf(statusFmt, bothModifiedLabel, tblName+schemaConflictSuffix))
+		}
 		for _, tblName := range workingTblsInConflict {
-			lines = append(lines, fmt.Sprintf(statusFmt, bothModifiedLabel, tblName))
+			if tblName != doltdb.DocTableName {
+				lines = append(lines, fmt.Sprintf(statusFmt, bothModifiedLabel, tblName))
+			}
+		}
+		for _, docName := range docsInCnf {
+			lines = append(lines, fmt.Sprintf(statusFmt, bothModifiedLabel, docName))
 		}
 
 		iohelp.WriteLine(wr, color.RedString(strings.Join(lines, "\n")))
@@ -227,14 +295,13 @@ func printDiffsNotStaged(ctx context.Context, dEnv *env.DoltEnv, wr io.Writer, n
 	}
 
 	numRemovedOrModified := removeModified + notStagedDocs.NumRemoved + notStagedDocs.NumModified
-	docsInCnf, _ := docCnfsOnWorkingRoot(ctx, dEnv)
 
 	if numRemovedOrModified-inCnfSet.Size() > 0 {
 		if linesPrinted > 0 {
 			cli.Println()
 		}
 
-		printChanges := !(removeModified == 1 && docsInCnf)
+		printChanges := !(removeModified == 1 && len(docsInCnf) > 0)
 
 		if printChanges {
 			iohelp.WriteLine(wr, workingHeader)
@@ -256,7 +323,7 @@ func printDiffsNotStaged(ctx context.Context, dEnv *env.DoltEnv, wr io.Writer, n
 			cli.Println()
 		}
 
-		printChanges := !(added == 1 && docsInCnf)
+		printChanges := !(added == 1 && len(docsInCnf) > 0)
 
 		if printChanges {
 			iohelp.WriteLine(wr, untrackedHeader)
@@ -325,19 +392,45 @@ func getAddedNotStaged(notStagedTbls []diff.TableDelta, notStagedDocs *diff.DocD
 	return lines
 }
 
-func printStatus(ctx context.Context, dEnv *env.DoltEnv, stagedTbls, notStagedTbls []diff.TableDelta, workingTblsInConflict []string, workingDocsInConflict *diff.DocDiffs, stagedDocs, notStagedDocs *diff.DocDiffs) {
+// printUpstreamStatus prints how the current branch compares to its upstream, if it has one.
+func printUpstreamStatus(upstream *actions.UpstreamStatus) {
+	if upstream == nil {
+		return
+	}
+
+	name := upstream.RemoteRef.GetPath()
+	switch {
+	case upstream.Ahead > 0 && upstream.Behind > 0:
+		cli.Printf("Your branch and '%s' have diverged,\nand have %d and %d different commits each, respectively.\n", name, upstream.Ahead, upstream.Behind)
+		cli.Println(`  (use "dolt pull" to merge the remote branch into yours)`)
+	case upstream.Ahead > 0:
+		cli.Printf("Your branch is ahead of '%s' by %s.\n", name, pluralize("commit", "commits", uint64(upstream.Ahead)))
+		cli.Println(`  (use "dolt push" to publish your local commits)`)
+	case upstream.Behind > 0:
+		cli.Printf("Your branch is behind '%s' by %s, and can be fast-forwarded.\n", name, pluralize("commit", "commits", uint64(upstream.Behind)))
+		cli.Println(`  (use "dolt pull" to update your local branch)`)
+	default:
+		cli.Printf("Your branch is up to date with '%s'.\n", name)
+	}
+
+	cli.Println()
+}
+
+func printStatus(ctx context.Context, dEnv *env.DoltEnv, upstream *actions.UpstreamStatus, stagedTbls, notStagedTbls []diff.TableDelta, workingTblsInConflict, workingTblsWithSchConflicts, workingDocsInConflict []string, stagedDocs, notStagedDocs *diff.DocDiffs) {
 	cli.Printf(branchHeader, dEnv.RepoState.CWBHeadRef().GetPath())
+	printUpstreamStatus(upstream)
 
 	if dEnv.RepoState.Merge != nil {
-		if len(workingTblsInConflict) > 0 {
+		if len(workingTblsInConflict)+len(workingTblsWithSchConflicts)+len(workingDocsInConflict) > 0 {
 			cli.Println(unmergedTablesHeader)
 		} else {
 			cli.Println(allMergedHeader)
 		}
+		cli.Println()
 	}
 
 	n := printStagedDiffs(cli.CliOut, stagedTbls, stagedDocs, true)
-	n = printDiffsNotStaged(ctx, dEnv, cli.CliOut, notStagedTbls, notStagedDocs, true, n, workingTblsInConflict)
+	n = printDiffsNotStaged(ctx, dEnv, cli.CliOut, notStagedTbls, notStagedDocs, true, n, workingTblsInConflict, workingTblsWithSchConflicts)
 
 	if dEnv.RepoState.Merge == nil && n == 0 {
 		cli.Println("nothing to commit, working tree clean")
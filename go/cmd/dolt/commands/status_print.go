@@ -0,0 +1,245 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
+	"github.com/dolthub/dolt/go/libraries/utils/set"
+)
+
+const (
+	statusTypeTable = "table"
+	statusTypeDoc   = "doc"
+
+	statusAdded    = "added"
+	statusModified = "modified"
+	statusDeleted  = "deleted"
+	statusRenamed  = "renamed"
+
+	conflictData   = "data"
+	conflictSchema = "schema"
+)
+
+var statusToPorcelainCode = map[string]byte{
+	statusAdded:    'A',
+	statusModified: 'M',
+	statusDeleted:  'D',
+	statusRenamed:  'R',
+}
+
+var docDiffTypeToStatus = map[diff.DocDiffType]string{
+	diff.AddedDoc:    statusAdded,
+	diff.ModifiedDoc: statusModified,
+	diff.RemovedDoc:  statusDeleted,
+}
+
+// statusEntry is a table or doc which differs between two roots.
+type statusEntry struct {
+	Name string `json:"name"`
+	// OldName is the name of a renamed table before it was renamed
+	OldName string `json:"old_name,omitempty"`
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+}
+
+// statusConflict is a table or doc with unresolved merge conflicts.
+type statusConflict struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Conflict string `json:"conflict"`
+}
+
+// statusUpstream is how the current branch compares to its upstream.
+type statusUpstream struct {
+	Branch string `json:"branch"`
+	Ahead  int    `json:"ahead"`
+	Behind int    `json:"behind"`
+}
+
+// repoStatus is the machine readable status of a repository. Its json encoding is the output of
+// `dolt status --format json`, and is stable across releases.
+type repoStatus struct {
+	Branch          string           `json:"branch"`
+	Upstream        *statusUpstream  `json:"upstream"`
+	MergeInProgress bool             `json:"merge_in_progress"`
+	Staged          []statusEntry    `json:"staged"`
+	Unstaged        []statusEntry    `json:"unstaged"`
+	Untracked       []statusEntry    `json:"untracked"`
+	Conflicts       []statusConflict `json:"conflicts"`
+}
+
+func newRepoStatus(branch string, upstream *actions.UpstreamStatus, mergeActive bool, stagedTbls, notStagedTbls []diff.TableDelta, workingTblsInConflict, workingTblsWithSchConflicts, workingDocsInConflict []string, stagedDocs, notStagedDocs *diff.DocDiffs) *repoStatus {
+	rs := &repoStatus{
+		Branch:          branch,
+		MergeInProgress: mergeActive,
+		Staged:          []statusEntry{},
+		Unstaged:        []statusEntry{},
+		Untracked:       []statusEntry{},
+		Conflicts:       []statusConflict{},
+	}
+
+	if upstream != nil {
+		rs.Upstream = &statusUpstream{Branch: upstream.RemoteRef.GetPath(), Ahead: upstream.Ahead, Behind: upstream.Behind}
+	}
+
+	inCnfSet := set.NewStrSet(workingTblsInConflict)
+	inCnfSet.Add(workingTblsWithSchConflicts...)
+
+	for _, tblName := range workingTblsWithSchConflicts {
+		rs.Conflicts = append(rs.Conflicts, statusConflict{Name: tblName, Type: statusTypeTable, Conflict: conflictSchema})
+	}
+	for _, tblName := range workingTblsInConflict {
+		if tblName != doltdb.DocTableName {
+			rs.Conflicts = append(rs.Conflicts, statusConflict{Name: tblName, Type: statusTypeTable, Conflict: conflictData})
+		}
+	}
+	for _, docName := range workingDocsInConflict {
+		rs.Conflicts = append(rs.Conflicts, statusConflict{Name: docName, Type: statusTypeDoc, Conflict: conflictData})
+	}
+
+	for _, td := range stagedTbls {
+		if doltdb.IsReadOnlySystemTable(td.CurName()) || td.CurName() == doltdb.DocTableName {
+			continue
+		}
+
+		e := statusEntry{Name: td.CurName(), Type: statusTypeTable, Status: statusModified}
+		if td.IsAdd() {
+			e.Status = statusAdded
+		} else if td.IsDrop() {
+			e.Status = statusDeleted
+		} else if td.IsRename() {
+			e.Status = statusRenamed
+			e.OldName = td.FromName
+		}
+
+		rs.Staged = append(rs.Staged, e)
+	}
+
+	for _, docName := range stagedDocs.Docs {
+		rs.Staged = append(rs.Staged, statusEntry{Name: docName, Type: statusTypeDoc, Status: docDiffTypeToStatus[stagedDocs.DocToType[docName]]})
+	}
+
+	for _, td := range notStagedTbls {
+		if inCnfSet.Contains(td.CurName()) || td.CurName() == doltdb.DocTableName {
+			continue
+		}
+
+		if td.IsAdd() {
+			rs.Untracked = append(rs.Untracked, statusEntry{Name: td.CurName(), Type: statusTypeTable, Status: statusAdded})
+		} else if td.IsDrop() {
+			rs.Unstaged = append(rs.Unstaged, statusEntry{Name: td.CurName(), Type: statusTypeTable, Status: statusDeleted})
+		} else if td.IsRename() {
+			// per Git, unstaged renames are shown as drop + add
+			rs.Unstaged = append(rs.Unstaged, statusEntry{Name: td.FromName, Type: statusTypeTable, Status: statusDeleted})
+			rs.Untracked = append(rs.Untracked, statusEntry{Name: td.ToName, Type: statusTypeTable, Status: statusAdded})
+		} else {
+			rs.Unstaged = append(rs.Unstaged, statusEntry{Name: td.CurName(), Type: statusTypeTable, Status: statusModified})
+		}
+	}
+
+	docsInCnfSet := set.NewStrSet(workingDocsInConflict)
+	for _, docName := range notStagedDocs.Docs {
+		if docsInCnfSet.Contains(docName) {
+			continue
+		}
+
+		dtt := notStagedDocs.DocToType[docName]
+		if dtt == diff.AddedDoc {
+			rs.Untracked = append(rs.Untracked, statusEntry{Name: docName, Type: statusTypeDoc, Status: statusAdded})
+		} else {
+			rs.Unstaged = append(rs.Unstaged, statusEntry{Name: docName, Type: statusTypeDoc, Status: docDiffTypeToStatus[dtt]})
+		}
+	}
+
+	return rs
+}
+
+// printStatusJSON writes |rs| as a json object.
+func printStatusJSON(wr io.Writer, rs *repoStatus) error {
+	data, err := json.MarshalIndent(rs, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return iohelp.WriteLine(wr, string(data))
+}
+
+// printStatusPorcelain writes |rs| in a line oriented format which is easy to parse. Header lines start with "# " and
+// describe the branch and merge state. They are followed by a line for each table or doc with changes, which starts
+// with a two character code: the first is the staged status and the second the unstaged status of the path.
+// Conflicts are "UU", untracked paths "??", and renamed tables are shown as "<old name> -> <new name>".
+func printStatusPorcelain(wr io.Writer, rs *repoStatus) error {
+	lines := []string{"# branch.head " + rs.Branch}
+
+	if rs.Upstream != nil {
+		lines = append(lines,
+			"# branch.upstream "+rs.Upstream.Branch,
+			fmt.Sprintf("# branch.ab +%d -%d", rs.Upstream.Ahead, rs.Upstream.Behind))
+	}
+
+	if rs.MergeInProgress {
+		lines = append(lines, "# merge.in-progress")
+	}
+
+	for _, cnf := range rs.Conflicts {
+		lines = append(lines, "UU "+cnf.Name)
+	}
+
+	// a path which has both staged and unstaged changes is a single line
+	var paths []string
+	codes := make(map[string][]byte)
+	for _, e := range rs.Staged {
+		path := e.Name
+		if e.Status == statusRenamed {
+			path = e.OldName + " -> " + e.Name
+		}
+
+		paths = append(paths, path)
+		codes[path] = []byte{statusToPorcelainCode[e.Status], ' '}
+	}
+
+	for _, e := range rs.Unstaged {
+		if code, ok := codes[e.Name]; ok {
+			code[1] = statusToPorcelainCode[e.Status]
+			continue
+		}
+
+		paths = append(paths, e.Name)
+		codes[e.Name] = []byte{' ', statusToPorcelainCode[e.Status]}
+	}
+
+	for _, path := range paths {
+		lines = append(lines, string(codes[path])+" "+path)
+	}
+
+	for _, e := range rs.Untracked {
+		lines = append(lines, "?? "+e.Name)
+	}
+
+	for _, line := range lines {
+		if err := iohelp.WriteLine(wr, line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -63,14 +63,18 @@ var doltCommand = cli.NewSubCommandHandler("dolt", "it's git for data", []cli.Co
 	commands.DiffCmd{},
 	commands.BlameCmd{},
 	commands.MergeCmd{},
+	commands.MergeBaseCmd{},
 	commands.BranchCmd{},
 	commands.TagCmd{},
+	commands.DescribeCmd{},
 	commands.CheckoutCmd{},
 	commands.RemoteCmd{},
 	commands.PushCmd{},
 	commands.PullCmd{},
 	commands.FetchCmd{},
 	commands.CloneCmd{},
+	commands.BackupCmd{},
+	commands.RemoteServerCmd{},
 	credcmds.Commands,
 	commands.LoginCmd{},
 	commands.VersionCmd{VersionStr: Version},
@@ -117,6 +117,10 @@ func (dc DoltCreds) Sign(data []byte) []byte {
 	return ed25519.Sign(dc.PrivKey, data)
 }
 
+const jwtSubjectPrefix = "doltClientCredentials/"
+
+var ErrInvalidJWT = errors.New("invalid credentials token")
+
 func (dc DoltCreds) toBearerToken() (string, error) {
 	b32KIDStr := dc.KeyIDBase32Str()
 	key := jose.SigningKey{Algorithm: jose.EdDSA, Key: ed25519.PrivateKey(dc.PrivKey)}
@@ -135,7 +139,7 @@ func (dc DoltCreds) toBearerToken() (string, error) {
 	jwtBuilder = jwtBuilder.Claims(jwt.Claims{
 		Audience: []string{"dolthub-remote-api.liquidata.co"},
 		Issuer:   "dolt-client.liquidata.co",
-		Subject:  "doltClientCredentials/" + b32KIDStr,
+		Subject:  jwtSubjectPrefix + b32KIDStr,
 		Expiry:   jwt.NewNumericDate(datetime.Now().Add(30 * time.Second)),
 	})
 
@@ -150,10 +154,50 @@ func (dc DoltCreds) GetRequestMetadata(ctx context.Context, uri ...string) (map[
 	}
 
 	return map[string]string{
-		"authorization": "Bearer " + t,
+		AuthorizationHeader: "Bearer " + t,
 	}, nil
 }
 
 func (dc DoltCreds) RequireTransportSecurity() bool {
 	return false
 }
+
+// JWTKeyID returns the base32 encoded key id of the credentials which signed |token|, a bearer token created by
+// DoltCreds. The signature is not verified.
+func JWTKeyID(token string) (string, error) {
+	tok, err := jwt.ParseSigned(token)
+
+	if err != nil || len(tok.Headers) != 1 || tok.Headers[0].KeyID == "" {
+		return "", ErrInvalidJWT
+	}
+
+	return tok.Headers[0].KeyID, nil
+}
+
+// VerifyJWT checks that |token|, a bearer token created by DoltCreds, was signed by the private key matching
+// |pubKey| and has not expired.
+func VerifyJWT(token string, pubKey []byte) error {
+	tok, err := jwt.ParseSigned(token)
+
+	if err != nil || len(pubKey) != pubKeySize {
+		return ErrInvalidJWT
+	}
+
+	var claims jwt.Claims
+	err = tok.Claims(ed25519.PublicKey(pubKey), &claims)
+
+	if err != nil {
+		return ErrInvalidJWT
+	}
+
+	err = claims.Validate(jwt.Expected{
+		Subject: jwtSubjectPrefix + PubKeyToKIDStr(pubKey),
+		Time:    datetime.Now().Time,
+	})
+
+	if err != nil {
+		return ErrInvalidJWT
+	}
+
+	return nil
+}
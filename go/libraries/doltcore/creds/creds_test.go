@@ -0,0 +1,71 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package creds
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyJWT(t *testing.T) {
+	dc, err := GenerateCredentials()
+	require.NoError(t, err)
+	other, err := GenerateCredentials()
+	require.NoError(t, err)
+
+	md, err := dc.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	token := strings.TrimPrefix(md[AuthorizationHeader], "Bearer ")
+
+	kid, err := JWTKeyID(token)
+	require.NoError(t, err)
+	assert.Equal(t, dc.KeyIDBase32Str(), kid)
+
+	assert.NoError(t, VerifyJWT(token, dc.PubKey))
+	assert.Equal(t, ErrInvalidJWT, VerifyJWT(token, other.PubKey))
+	assert.Equal(t, ErrInvalidJWT, VerifyJWT(token+"x", dc.PubKey))
+
+	_, err = JWTKeyID("not a token")
+	assert.Equal(t, ErrInvalidJWT, err)
+}
+
+func TestRPCCredsFromEnv(t *testing.T) {
+	for _, envVar := range []string{RemoteTokenEnvVar, RemoteUserEnvVar, RemotePasswordEnvVar} {
+		if val, ok := os.LookupEnv(envVar); ok {
+			defer os.Setenv(envVar, val)
+		} else {
+			defer os.Unsetenv(envVar)
+		}
+		os.Unsetenv(envVar)
+	}
+
+	assert.Nil(t, RPCCredsFromEnv())
+
+	os.Setenv(RemoteUserEnvVar, "user")
+	os.Setenv(RemotePasswordEnvVar, "pass")
+	md, err := RPCCredsFromEnv().GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Basic dXNlcjpwYXNz", md[AuthorizationHeader])
+
+	os.Setenv(RemoteTokenEnvVar, "token")
+	md, err = RPCCredsFromEnv().GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token", md[AuthorizationHeader])
+}
@@ -0,0 +1,85 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package creds
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	// AuthorizationHeader is the grpc metadata key that credentials are sent in
+	AuthorizationHeader = "authorization"
+
+	// RemoteTokenEnvVar is the environment variable holding a bearer token to authenticate to remotes with
+	RemoteTokenEnvVar = "DOLT_REMOTE_TOKEN"
+	// RemoteUserEnvVar is the environment variable holding a username to authenticate to remotes with
+	RemoteUserEnvVar = "DOLT_REMOTE_USER"
+	// RemotePasswordEnvVar is the environment variable holding the password of RemoteUserEnvVar
+	RemotePasswordEnvVar = "DOLT_REMOTE_PASSWORD"
+)
+
+// BearerTokenCreds are grpc per rpc credentials which send a static bearer token.
+type BearerTokenCreds struct {
+	Token string
+}
+
+var _ credentials.PerRPCCredentials = BearerTokenCreds{}
+
+func (bc BearerTokenCreds) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{
+		AuthorizationHeader: "Bearer " + bc.Token,
+	}, nil
+}
+
+func (bc BearerTokenCreds) RequireTransportSecurity() bool {
+	return false
+}
+
+// BasicAuthCreds are grpc per rpc credentials which send a username and password using http basic authentication.
+type BasicAuthCreds struct {
+	Username string
+	Password string
+}
+
+var _ credentials.PerRPCCredentials = BasicAuthCreds{}
+
+func (bc BasicAuthCreds) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	userAndPass := base64.StdEncoding.EncodeToString([]byte(bc.Username + ":" + bc.Password))
+	return map[string]string{
+		AuthorizationHeader: "Basic " + userAndPass,
+	}, nil
+}
+
+func (bc BasicAuthCreds) RequireTransportSecurity() bool {
+	return false
+}
+
+// RPCCredsFromEnv returns the remote credentials set in the environment, or nil if there are none. A token set in
+// RemoteTokenEnvVar takes precedence over a username and password.
+func RPCCredsFromEnv() credentials.PerRPCCredentials {
+	if token := os.Getenv(RemoteTokenEnvVar); token != "" {
+		return BearerTokenCreds{Token: token}
+	}
+
+	if user := os.Getenv(RemoteUserEnvVar); user != "" {
+		return BasicAuthCreds{Username: user, Password: os.Getenv(RemotePasswordEnvVar)}
+	}
+
+	return nil
+}
@@ -16,7 +16,11 @@ package dbfactory
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"net/url"
 
 	"google.golang.org/grpc"
@@ -30,6 +34,13 @@ import (
 	"github.com/dolthub/dolt/go/store/types"
 )
 
+const (
+	// CABundleParam is a creation parameter that can be used to specify a file of PEM encoded certificates of the
+	// certificate authorities which are trusted to sign the certificates of https remotes, in addition to the
+	// system's certificate authorities.
+	CABundleParam = "ca-bundle"
+)
+
 // GRPCDialProvider is an interface for getting a *grpc.ClientConn.
 type GRPCDialProvider interface {
 	GetGRPCDialParams(grpcendpoint.Config) (string, []grpc.DialOption, error)
@@ -64,10 +75,25 @@ func (fact DoltRemoteFactory) CreateDB(ctx context.Context, nbf *types.NomsBinFo
 }
 
 func (fact DoltRemoteFactory) newChunkStore(ctx context.Context, nbf *types.NomsBinFormat, urlObj *url.URL, params map[string]string) (chunks.ChunkStore, error) {
+	var tlsConfig *tls.Config
+	if caBundle, ok := params[CABundleParam]; ok {
+		if fact.insecure {
+			return nil, fmt.Errorf("the %s parameter is only valid for https remotes", CABundleParam)
+		}
+
+		var err error
+		tlsConfig, err = loadCABundle(caBundle)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	endpoint, opts, err := fact.dp.GetGRPCDialParams(grpcendpoint.Config{
 		Endpoint:     urlObj.Host,
 		Insecure:     fact.insecure,
 		WithEnvCreds: true,
+		TLSConfig:    tlsConfig,
 	})
 	if err != nil {
 		return nil, err
@@ -86,7 +112,39 @@ func (fact DoltRemoteFactory) newChunkStore(ctx context.Context, nbf *types.Noms
 
 	if err == remotestorage.ErrInvalidDoltSpecPath {
 		return nil, fmt.Errorf("invalid dolt url '%s'", urlObj.String())
+	} else if err != nil {
+		return nil, err
+	}
+
+	if tlsConfig != nil {
+		// table files are downloaded from and uploaded to urls of the server's http endpoint, which must be trusted
+		// the same way as its grpc endpoint
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		cs = cs.WithHTTPFetcher(&http.Client{Transport: transport})
+	}
+
+	return cs, nil
+}
+
+// loadCABundle returns a TLS config trusting the system's certificate authorities and those in the PEM encoded file
+// |path|.
+func loadCABundle(path string) (*tls.Config, error) {
+	pem, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s '%s': %w", CABundleParam, path, err)
+	}
+
+	pool, err := x509.SystemCertPool()
+
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("%s '%s' does not contain any PEM encoded certificates", CABundleParam, path)
 	}
 
-	return cs, err
+	return &tls.Config{RootCAs: pool}, nil
 }
@@ -0,0 +1,435 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/diff"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var ErrBlameKeyless = errors.New("blame is not supported for keyless tables")
+
+// RowBlame contains the commit which last modified a row of a table.
+type RowBlame struct {
+	// Key is the primary key of the row
+	Key types.Tuple
+	// CommitHash is the hash of the commit which last modified the row
+	CommitHash hash.Hash
+	// Meta is the metadata of the commit which last modified the row
+	Meta *doltdb.CommitMeta
+	// Cells contains the commit which last modified each column of the row, in the column order of the table's
+	// schema. It is only populated by BlameCells.
+	Cells []CellBlame
+}
+
+// CellBlame contains the commit which last modified the value of a single column of a row.
+type CellBlame struct {
+	// Tag is the tag of the column
+	Tag uint64
+	// CommitHash is the hash of the commit which last modified the value
+	CommitHash hash.Hash
+	// Meta is the metadata of the commit which last modified the value
+	Meta *doltdb.CommitMeta
+}
+
+// blamer accumulates blame for the rows of a table as history is walked from the newest commit to the oldest.
+type blamer interface {
+	// done returns whether everything has been blamed
+	done() bool
+	// blameAll blames everything which has not yet been blamed on the commit
+	blameAll(h hash.Hash, meta *doltdb.CommitMeta)
+	// blameSchemaChange blames whatever is affected by the schema changing from |parentSch| to |sch| on the commit
+	blameSchemaChange(h hash.Hash, meta *doltdb.CommitMeta, parentSch, sch schema.Schema)
+	// blameDiff blames whatever is affected by the row difference |d| on the commit
+	blameDiff(h hash.Hash, meta *doltdb.CommitMeta, d *diff.Difference) error
+}
+
+// rowBlamer is a blamer which blames whole rows. Any change to a row, or to the schema of the table, blames the row.
+type rowBlamer struct {
+	blame    []RowBlame
+	unblamed map[hash.Hash]int
+	nbf      *types.NomsBinFormat
+}
+
+func (rb *rowBlamer) done() bool {
+	return len(rb.unblamed) == 0
+}
+
+func (rb *rowBlamer) assign(idx int, h hash.Hash, meta *doltdb.CommitMeta, keyHash hash.Hash) {
+	rb.blame[idx].CommitHash = h
+	rb.blame[idx].Meta = meta
+	delete(rb.unblamed, keyHash)
+}
+
+func (rb *rowBlamer) blameAll(h hash.Hash, meta *doltdb.CommitMeta) {
+	for keyHash, idx := range rb.unblamed {
+		rb.assign(idx, h, meta, keyHash)
+	}
+}
+
+func (rb *rowBlamer) blameSchemaChange(h hash.Hash, meta *doltdb.CommitMeta, parentSch, sch schema.Schema) {
+	if !schema.SchemasAreEqual(parentSch, sch) {
+		rb.blameAll(h, meta)
+	}
+}
+
+func (rb *rowBlamer) blameDiff(h hash.Hash, meta *doltdb.CommitMeta, d *diff.Difference) error {
+	keyHash, err := d.KeyValue.Hash(rb.nbf)
+	if err != nil {
+		return err
+	}
+
+	if idx, ok := rb.unblamed[keyHash]; ok {
+		rb.assign(idx, h, meta, keyHash)
+	}
+
+	return nil
+}
+
+// cellBlamer is a blamer which blames each column of each row separately. A column's value is blamed when the row is
+// added, when the value changes, or when the column's definition changes.
+type cellBlamer struct {
+	*rowBlamer
+	// unblamedCells maps the index of each row which is not fully blamed to the indexes of its unblamed cells by tag
+	unblamedCells map[int]map[uint64]int
+}
+
+func (cb *cellBlamer) assignCell(idx int, tag uint64, h hash.Hash, meta *doltdb.CommitMeta, keyHash hash.Hash) {
+	cells := cb.unblamedCells[idx]
+	cellIdx, ok := cells[tag]
+	if !ok {
+		return
+	}
+
+	cb.blame[idx].Cells[cellIdx].CommitHash = h
+	cb.blame[idx].Cells[cellIdx].Meta = meta
+	delete(cells, tag)
+
+	// the row is blamed on the first of its cells to be blamed, which is the most recent change to it
+	if cb.blame[idx].Meta == nil {
+		cb.blame[idx].CommitHash = h
+		cb.blame[idx].Meta = meta
+	}
+
+	if len(cells) == 0 {
+		delete(cb.unblamedCells, idx)
+		delete(cb.unblamed, keyHash)
+	}
+}
+
+func (cb *cellBlamer) blameAll(h hash.Hash, meta *doltdb.CommitMeta) {
+	for keyHash, idx := range cb.unblamed {
+		for tag := range cb.unblamedCells[idx] {
+			cb.assignCell(idx, tag, h, meta, keyHash)
+		}
+	}
+}
+
+func (cb *cellBlamer) blameSchemaChange(h hash.Hash, meta *doltdb.CommitMeta, parentSch, sch schema.Schema) {
+	if !schema.ColCollsAreEqual(parentSch.GetPKCols(), sch.GetPKCols()) {
+		cb.blameAll(h, meta)
+		return
+	}
+
+	var changedTags []uint64
+	_ = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		parentCol, ok := parentSch.GetAllCols().GetByTag(tag)
+		if !ok || !parentCol.Equals(col) {
+			changedTags = append(changedTags, tag)
+		}
+		return false, nil
+	})
+
+	for keyHash, idx := range cb.unblamed {
+		for _, tag := range changedTags {
+			cb.assignCell(idx, tag, h, meta, keyHash)
+		}
+	}
+}
+
+func (cb *cellBlamer) blameDiff(h hash.Hash, meta *doltdb.CommitMeta, d *diff.Difference) error {
+	keyHash, err := d.KeyValue.Hash(cb.nbf)
+	if err != nil {
+		return err
+	}
+
+	idx, ok := cb.unblamed[keyHash]
+	if !ok {
+		return nil
+	}
+
+	if d.OldValue == nil || d.NewValue == nil {
+		for tag := range cb.unblamedCells[idx] {
+			cb.assignCell(idx, tag, h, meta, keyHash)
+		}
+		return nil
+	}
+
+	oldVals, err := row.ParseTaggedValues(d.OldValue.(types.Tuple))
+	if err != nil {
+		return err
+	}
+
+	newVals, err := row.ParseTaggedValues(d.NewValue.(types.Tuple))
+	if err != nil {
+		return err
+	}
+
+	for tag := range cb.unblamedCells[idx] {
+		oldVal, oldOk := oldVals[tag]
+		newVal, newOk := newVals[tag]
+
+		if oldOk != newOk || (oldOk && !oldVal.Equals(newVal)) {
+			cb.assignCell(idx, tag, h, meta, keyHash)
+		}
+	}
+
+	return nil
+}
+
+// BlameRows returns the commit which last modified each row of the table named |tblName| as of |cm|, ordered by
+// primary key.
+//
+// Blame is computed by walking the history of |cm| in topological order, and diffing each commit's table against the
+// table at its first parent. Every row which is in the diff and which has not yet been blamed is blamed on the commit.
+// A commit which adds the table, or changes its schema, is blamed for every remaining row. The walk stops as soon as
+// every row has been blamed.
+func BlameRows(ctx context.Context, ddb *doltdb.DoltDB, cm *doltdb.Commit, tblName string) ([]RowBlame, error) {
+	rows, _, err := blameTable(ctx, ddb, cm, tblName, false)
+	return rows, err
+}
+
+// BlameCells returns the commit which last modified each column of each row of the table named |tblName| as of |cm|,
+// ordered by primary key, along with the table's schema at |cm|.
+//
+// History is walked as in BlameRows, but rather than blaming whole rows, the old and new values of each modified row
+// are compared column by column, and only the columns whose values differ are blamed. A commit which adds a row blames
+// all of its columns, and a commit which adds a column or changes its definition blames that column of every row.
+func BlameCells(ctx context.Context, ddb *doltdb.DoltDB, cm *doltdb.Commit, tblName string) ([]RowBlame, schema.Schema, error) {
+	return blameTable(ctx, ddb, cm, tblName, true)
+}
+
+func blameTable(ctx context.Context, ddb *doltdb.DoltDB, cm *doltdb.Commit, tblName string, byCell bool) ([]RowBlame, schema.Schema, error) {
+	tbl, sch, ok, err := tableAndSchemaAtCommit(ctx, cm, tblName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", doltdb.ErrTableNotFound, tblName)
+	}
+	if schema.IsKeyless(sch) {
+		return nil, nil, ErrBlameKeyless
+	}
+
+	rowData, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rb := &rowBlamer{
+		blame:    make([]RowBlame, 0, rowData.Len()),
+		unblamed: make(map[hash.Hash]int, rowData.Len()),
+		nbf:      rowData.Format(),
+	}
+
+	var cb *cellBlamer
+	var b blamer = rb
+	if byCell {
+		cb = &cellBlamer{rowBlamer: rb, unblamedCells: make(map[int]map[uint64]int, rowData.Len())}
+		b = cb
+	}
+
+	err = rowData.IterAll(ctx, func(key, _ types.Value) error {
+		keyHash, err := key.Hash(rowData.Format())
+		if err != nil {
+			return err
+		}
+
+		idx := len(rb.blame)
+		rb.unblamed[keyHash] = idx
+		rb.blame = append(rb.blame, RowBlame{Key: key.(types.Tuple)})
+
+		if cb != nil {
+			cells := make([]CellBlame, 0, sch.GetAllCols().Size())
+			unblamedCells := make(map[uint64]int, sch.GetAllCols().Size())
+			_ = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+				unblamedCells[tag] = len(cells)
+				cells = append(cells, CellBlame{Tag: tag})
+				return false, nil
+			})
+
+			rb.blame[idx].Cells = cells
+			cb.unblamedCells[idx] = unblamedCells
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cmHash, err := cm.HashOf()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	itr, err := commitwalk.GetTopologicalOrderIterator(ctx, ddb, cmHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for !b.done() {
+		h, c, err := itr.Next(ctx)
+		if err == io.EOF {
+			return nil, nil, fmt.Errorf("couldn't find blame for %d rows of table %s", len(rb.unblamed), tblName)
+		} else if err != nil {
+			return nil, nil, err
+		}
+
+		err = blameCommit(ctx, ddb, b, h, c, tblName)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return rb.blame, sch, nil
+}
+
+// blameCommit blames the changes between |c| and its first parent on |c|.
+func blameCommit(ctx context.Context, ddb *doltdb.DoltDB, b blamer, h hash.Hash, c *doltdb.Commit, tblName string) error {
+	tbl, sch, ok, err := tableAndSchemaAtCommit(ctx, c, tblName)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// the table doesn't exist on this branch of history
+		return nil
+	}
+
+	meta, err := c.GetCommitMeta()
+	if err != nil {
+		return err
+	}
+
+	numParents, err := c.NumParents()
+	if err != nil {
+		return err
+	}
+	if numParents == 0 {
+		b.blameAll(h, meta)
+		return nil
+	}
+
+	parent, err := ddb.ResolveParent(ctx, c, 0)
+	if err == doltdb.ErrShallowBoundary {
+		// the history before the boundary of a shallow clone is not present
+		b.blameAll(h, meta)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	parentTbl, parentSch, ok, err := tableAndSchemaAtCommit(ctx, parent, tblName)
+	if err != nil {
+		return err
+	}
+
+	// if the table is new, everything has changed
+	if !ok {
+		b.blameAll(h, meta)
+		return nil
+	}
+
+	b.blameSchemaChange(h, meta, parentSch, sch)
+	if b.done() {
+		return nil
+	}
+
+	rows, err := tbl.GetRowData(ctx)
+	if err != nil {
+		return err
+	}
+
+	parentRows, err := parentTbl.GetRowData(ctx)
+	if err != nil {
+		return err
+	}
+
+	return blameDiffs(ctx, b, h, meta, parentRows, rows)
+}
+
+func blameDiffs(ctx context.Context, b blamer, h hash.Hash, meta *doltdb.CommitMeta, from, to types.Map) (err error) {
+	if from.Equals(to) {
+		return nil
+	}
+
+	ad := NewAsyncDiffer(1024)
+	ad.Start(ctx, from, to)
+	defer func() {
+		if cerr := ad.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	for !b.done() {
+		diffs, more, err := ad.GetDiffs(100, time.Millisecond)
+		if err != nil {
+			return err
+		}
+
+		for _, d := range diffs {
+			err = b.blameDiff(h, meta, d)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	return nil
+}
+
+func tableAndSchemaAtCommit(ctx context.Context, c *doltdb.Commit, tblName string) (*doltdb.Table, schema.Schema, bool, error) {
+	root, err := c.GetRootValue()
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	tbl, ok, err := root.GetTable(ctx, tblName)
+	if err != nil || !ok {
+		return nil, nil, false, err
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	return tbl, sch, true, nil
+}
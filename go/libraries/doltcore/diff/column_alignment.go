@@ -0,0 +1,181 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// AlignFromSchema aligns the columns of |fromSch| with the columns of |toSch| so that rows of the two schemas can be
+// diffed column by column. It returns a schema with the columns of |fromSch|, and a RowConverter which converts rows of
+// |fromSch| into rows of that schema.
+//
+// Row diffs match columns by tag, so a renamed column is already aligned with its new name. Changing the type of a
+// column may give it a new tag though, in which case the column is matched by name with the column it became, using
+// every name the column has had in |ss|, the super schema of the table. Such a column takes the tag and type of the
+// column in |toSch|, and its values are converted to the new type. Primary key columns are never realigned, as
+// changing their type changes the identity of every row. A column is only realigned if each of its values in
+// |fromRows| converts to the new type and back without change, as a conversion which fails or loses information could
+// make different values compare equal. Otherwise it is diffed as a dropped column, and the new one as an added column.
+//
+// If no columns need to be realigned, or either schema is keyless, |fromSch| and an identity converter are returned.
+func AlignFromSchema(ctx context.Context, vrw types.ValueReadWriter, ss *schema.SuperSchema, fromSch, toSch schema.Schema, fromRows types.Map) (schema.Schema, *rowconv.RowConverter, error) {
+	if schema.IsKeyless(fromSch) || schema.IsKeyless(toSch) {
+		return fromSch, rowconv.IdentityConverter, nil
+	}
+
+	fromCols := fromSch.GetAllCols()
+	toCols := toSch.GetAllCols()
+
+	realigned := make(map[uint64]schema.Column)
+	claimed := make(map[uint64]bool)
+	_ = fromCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if col.IsPartOfPK {
+			return false, nil
+		}
+		if _, ok := toCols.GetByTag(tag); ok {
+			return false, nil
+		}
+
+		names := []string{col.Name}
+		if ss != nil {
+			names = append(names, ss.AllColumnNames(tag)...)
+		}
+
+		for _, name := range names {
+			toCol, ok := toCols.GetByName(name)
+			if !ok || toCol.IsPartOfPK || claimed[toCol.Tag] {
+				continue
+			}
+			if _, ok := fromCols.GetByTag(toCol.Tag); ok {
+				continue
+			}
+
+			toCol.Name = col.Name
+			realigned[tag] = toCol
+			claimed[toCol.Tag] = true
+			break
+		}
+
+		return false, nil
+	})
+
+	if len(realigned) == 0 {
+		return fromSch, rowconv.IdentityConverter, nil
+	}
+
+	alignedSch, conv, err := alignedConverter(ctx, vrw, fromSch, realigned)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inexact, err := inexactConversions(ctx, vrw, fromSch, fromRows, realigned, conv)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(inexact) == 0 {
+		return alignedSch, conv, nil
+	}
+
+	for tag := range inexact {
+		delete(realigned, tag)
+	}
+
+	if len(realigned) == 0 {
+		return fromSch, rowconv.IdentityConverter, nil
+	}
+
+	return alignedConverter(ctx, vrw, fromSch, realigned)
+}
+
+// alignedConverter returns the schema with the columns of |fromSch| in which each column of |realigned| is replaced
+// by the column it is aligned with, and a RowConverter which converts rows of |fromSch| into rows of that schema.
+func alignedConverter(ctx context.Context, vrw types.ValueReadWriter, fromSch schema.Schema, realigned map[uint64]schema.Column) (schema.Schema, *rowconv.RowConverter, error) {
+	fromCols := fromSch.GetAllCols()
+	srcToDest := make(map[uint64]uint64, fromCols.Size())
+	cols := make([]schema.Column, 0, fromCols.Size())
+	_ = fromCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if toCol, ok := realigned[tag]; ok {
+			col = toCol
+		}
+
+		srcToDest[tag] = col.Tag
+		cols = append(cols, col)
+		return false, nil
+	})
+
+	alignedSch, err := schema.SchemaFromCols(schema.NewColCollection(cols...))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fm, err := rowconv.NewFieldMapping(fromSch, alignedSch, srcToDest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conv, err := rowconv.NewRowConverter(ctx, vrw, fm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return alignedSch, conv, nil
+}
+
+// inexactConversions returns the tags of the columns of |realigned| which have a value in |fromRows| that |conv| fails
+// to convert, or that is changed by converting it back to the type of the column in |fromSch|.
+func inexactConversions(ctx context.Context, vrw types.ValueReadWriter, fromSch schema.Schema, fromRows types.Map, realigned map[uint64]schema.Column, conv *rowconv.RowConverter) (map[uint64]bool, error) {
+	inexact := make(map[uint64]bool)
+	err := fromRows.IterAll(ctx, func(key, value types.Value) error {
+		r, err := row.FromNoms(fromSch, key.(types.Tuple), value.(types.Tuple))
+		if err != nil {
+			return err
+		}
+
+		for tag, toCol := range realigned {
+			v, ok := r.GetColVal(tag)
+			if !ok || types.IsNull(v) || inexact[tag] {
+				continue
+			}
+
+			converted, err := conv.ConvFuncs[tag](v)
+			if err != nil || types.IsNull(converted) {
+				inexact[tag] = true
+				continue
+			}
+
+			fromCol, _ := fromSch.GetAllCols().GetByTag(tag)
+			back, err := typeinfo.Convert(ctx, vrw, converted, toCol.TypeInfo, fromCol.TypeInfo)
+			if err != nil || back == nil || !back.Equals(v) {
+				inexact[tag] = true
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return inexact, nil
+}
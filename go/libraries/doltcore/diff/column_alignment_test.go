@@ -0,0 +1,163 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestAlignFromSchema(t *testing.T) {
+	ctx := context.Background()
+	vrw := types.NewMemoryValueStore()
+
+	fromSch, err := schema.SchemaFromCols(schema.NewColCollection(
+		schema.NewColumn("pk", 0, types.IntKind, true),
+		schema.NewColumn("renamed", 1, types.IntKind, false),
+		schema.NewColumn("type_changed", 2, types.IntKind, false),
+		schema.NewColumn("renamed_and_type_changed", 3, types.IntKind, false),
+		schema.NewColumn("dropped", 4, types.IntKind, false),
+	))
+	require.NoError(t, err)
+
+	toSch, err := schema.SchemaFromCols(schema.NewColCollection(
+		schema.NewColumn("pk", 0, types.IntKind, true),
+		schema.NewColumn("renamed_new", 1, types.IntKind, false),
+		schema.NewColumn("type_changed", 12, types.StringKind, false),
+		schema.NewColumn("renamed_new_and_type_changed", 13, types.StringKind, false),
+	))
+	require.NoError(t, err)
+
+	emptyRows, err := types.NewMap(ctx, vrw)
+	require.NoError(t, err)
+
+	t.Run("no super schema", func(t *testing.T) {
+		alignedSch, conv, err := AlignFromSchema(ctx, vrw, nil, fromSch, toSch, emptyRows)
+		require.NoError(t, err)
+		require.False(t, conv.IdentityConverter)
+
+		assert.Equal(t, []uint64{0, 1, 12, 3, 4}, alignedSch.GetAllCols().Tags)
+		col, _ := alignedSch.GetAllCols().GetByTag(12)
+		assert.Equal(t, "type_changed", col.Name)
+		assert.Equal(t, types.StringKind, col.Kind)
+	})
+
+	t.Run("super schema", func(t *testing.T) {
+		ss, err := schema.NewSuperSchema()
+		require.NoError(t, err)
+		require.NoError(t, ss.AddColumn(schema.NewColumn("renamed_and_type_changed", 3, types.IntKind, false)))
+		require.NoError(t, ss.AddColumn(schema.NewColumn("renamed_new_and_type_changed", 3, types.IntKind, false)))
+
+		alignedSch, conv, err := AlignFromSchema(ctx, vrw, ss, fromSch, toSch, emptyRows)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{0, 1, 12, 13, 4}, alignedSch.GetAllCols().Tags)
+
+		r, err := row.New(types.Format_Default, fromSch, row.TaggedValues{
+			0: types.Int(1),
+			1: types.Int(2),
+			2: types.Int(3),
+			3: types.Int(4),
+			4: types.Int(5),
+		})
+		require.NoError(t, err)
+
+		converted, err := conv.Convert(r)
+		require.NoError(t, err)
+
+		tv, err := row.GetTaggedVals(converted)
+		require.NoError(t, err)
+		assert.Equal(t, row.TaggedValues{
+			0:  types.Int(1),
+			1:  types.Int(2),
+			12: types.String("3"),
+			13: types.String("4"),
+			4:  types.Int(5),
+		}, tv)
+	})
+
+	t.Run("inexact conversions", func(t *testing.T) {
+		fromSch, err := schema.SchemaFromCols(schema.NewColCollection(
+			schema.NewColumn("pk", 0, types.IntKind, true),
+			schema.NewColumn("unconvertible", 1, types.StringKind, false),
+			schema.NewColumn("lossy", 2, types.FloatKind, false),
+			schema.NewColumn("exact", 3, types.StringKind, false),
+		))
+		require.NoError(t, err)
+
+		toSch, err := schema.SchemaFromCols(schema.NewColCollection(
+			schema.NewColumn("pk", 0, types.IntKind, true),
+			schema.NewColumn("unconvertible", 11, types.IntKind, false),
+			schema.NewColumn("lossy", 12, types.IntKind, false),
+			schema.NewColumn("exact", 13, types.IntKind, false),
+		))
+		require.NoError(t, err)
+
+		var kvs []types.Value
+		for i, tv := range []row.TaggedValues{
+			{0: types.Int(1), 1: types.String("1"), 2: types.Float(1), 3: types.String("1")},
+			{0: types.Int(2), 1: types.String("abc"), 2: types.Float(2.5), 3: types.String("2")},
+		} {
+			r, err := row.New(types.Format_Default, fromSch, tv)
+			require.NoError(t, err, i)
+			k, err := r.NomsMapKey(fromSch).Value(ctx)
+			require.NoError(t, err)
+			v, err := r.NomsMapValue(fromSch).Value(ctx)
+			require.NoError(t, err)
+			kvs = append(kvs, k, v)
+		}
+
+		fromRows, err := types.NewMap(ctx, vrw, kvs...)
+		require.NoError(t, err)
+
+		// only the column whose values all convert exactly is realigned, the others are diffed as dropped and added
+		alignedSch, conv, err := AlignFromSchema(ctx, vrw, nil, fromSch, toSch, fromRows)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{0, 1, 2, 13}, alignedSch.GetAllCols().Tags)
+
+		r, err := row.New(types.Format_Default, fromSch, row.TaggedValues{
+			0: types.Int(2),
+			1: types.String("abc"),
+			2: types.Float(2.5),
+			3: types.String("2"),
+		})
+		require.NoError(t, err)
+
+		converted, err := conv.Convert(r)
+		require.NoError(t, err)
+
+		tv, err := row.GetTaggedVals(converted)
+		require.NoError(t, err)
+		assert.Equal(t, row.TaggedValues{
+			0:  types.Int(2),
+			1:  types.String("abc"),
+			2:  types.Float(2.5),
+			13: types.Int(2),
+		}, tv)
+	})
+
+	t.Run("unchanged schema", func(t *testing.T) {
+		alignedSch, conv, err := AlignFromSchema(ctx, vrw, nil, fromSch, fromSch, emptyRows)
+		require.NoError(t, err)
+		assert.True(t, conv.IdentityConverter)
+		assert.Equal(t, fromSch, alignedSch)
+	})
+}
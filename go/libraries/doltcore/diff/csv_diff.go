@@ -0,0 +1,139 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"context"
+	"io"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/pipeline"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/untyped/csv"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const csvDiffTypeColName = "diff_type"
+
+// CSVDiffSink is a DiffSink which writes each joined diff row as a line of CSV. The first column of the output is the
+// diff type, and it is followed by a from_ and to_ column for each column in the union of the from and to schemas.
+type CSVDiffSink struct {
+	csvw    *csv.CSVWriter
+	joiner  *rowconv.Joiner
+	outSch  schema.Schema
+	fromMap map[uint64]uint64
+	toMap   map[uint64]uint64
+}
+
+// NewCSVDiffSink creates a CSVDiffSink which writes a header line, followed by the diff rows, to |wr|. Rows given to
+// the sink must have the schema of |joiner|.
+func NewCSVDiffSink(wr io.WriteCloser, joiner *rowconv.Joiner) (*CSVDiffSink, error) {
+	fromSch, toSch := joiner.SchemaForName(From), joiner.SchemaForName(To)
+	colPairs, unionTags := pairColumns(fromSch, toSch)
+
+	colNames := []string{csvDiffTypeColName}
+	fromMap := make(map[uint64]uint64, len(unionTags))
+	toMap := make(map[uint64]uint64, len(unionTags))
+	for _, tag := range unionTags {
+		fromCol, toCol := colPairs[tag][0], colPairs[tag][1]
+
+		fromName, toName := fromCol, toCol
+		if fromName == nil {
+			fromName = toCol
+		}
+		if toName == nil {
+			toName = fromCol
+		}
+
+		fromMap[tag] = uint64(len(colNames))
+		colNames = append(colNames, From+"_"+fromName.Name)
+		toMap[tag] = uint64(len(colNames))
+		colNames = append(colNames, To+"_"+toName.Name)
+	}
+
+	_, outSch := untyped.NewUntypedSchema(colNames...)
+	csvw, err := csv.NewCSVWriter(wr, outSch, csv.NewCSVInfo())
+	if err != nil {
+		return nil, err
+	}
+
+	return &CSVDiffSink{csvw, joiner, outSch, fromMap, toMap}, nil
+}
+
+// GetSchema gets the schema of the rows that this sink accepts.
+func (cds *CSVDiffSink) GetSchema() schema.Schema {
+	return cds.joiner.GetSchema()
+}
+
+// ProcRowWithProps satisfies pipeline.SinkFunc; it writes the row diff as a line of CSV.
+func (cds *CSVDiffSink) ProcRowWithProps(r row.Row, _ pipeline.ReadableMap) error {
+	rows, err := cds.joiner.Split(r)
+	if err != nil {
+		return err
+	}
+
+	from, to := rows[From], rows[To]
+
+	taggedVals := row.TaggedValues{0: types.String(RowDiffTypeName(from, to))}
+	err = addFormattedVals(taggedVals, from, cds.joiner.SchemaForName(From), cds.fromMap)
+	if err != nil {
+		return err
+	}
+
+	err = addFormattedVals(taggedVals, to, cds.joiner.SchemaForName(To), cds.toMap)
+	if err != nil {
+		return err
+	}
+
+	outRow, err := row.New(r.Format(), cds.outSch, taggedVals)
+	if err != nil {
+		return err
+	}
+
+	return cds.csvw.WriteRow(context.TODO(), outRow)
+}
+
+// addFormattedVals formats each non-null value of |r| as a string, and adds it to |taggedVals| using the tag mapped
+// to by |tagMap|.
+func addFormattedVals(taggedVals row.TaggedValues, r row.Row, sch schema.Schema, tagMap map[uint64]uint64) error {
+	if r == nil {
+		return nil
+	}
+
+	return sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		val, ok := r.GetColVal(tag)
+		if !ok || types.IsNull(val) {
+			return false, nil
+		}
+
+		str, err := col.TypeInfo.FormatValue(val)
+		if err != nil {
+			return true, err
+		}
+
+		if str != nil {
+			taggedVals[tagMap[tag]] = types.String(*str)
+		}
+
+		return false, nil
+	})
+}
+
+// Close should release resources being held
+func (cds *CSVDiffSink) Close() error {
+	return cds.csvw.Close(context.TODO())
+}
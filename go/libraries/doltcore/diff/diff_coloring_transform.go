@@ -15,10 +15,14 @@
 package diff
 
 import (
+	"strings"
+	"unicode"
+
 	"github.com/fatih/color"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/pipeline"
+	"github.com/dolthub/dolt/go/store/types"
 )
 
 var greenTextProp = map[string]interface{}{colorRowProp: color.GreenString}
@@ -49,3 +53,80 @@ func ColoringTransform(r row.Row, props pipeline.ReadableMap) ([]*pipeline.Trans
 
 	return []*pipeline.TransformedRowResult{{RowData: r, PropertyUpdates: updatedProps}}, ""
 }
+
+// HighlightInline compares |oldStr| and |newStr| word by word and returns copies of both strings in which only the
+// text removed from |oldStr| is colored with |oldColor|, and only the text added to |newStr| is colored with
+// |newColor|. Words are runs of letters and digits, every other character is compared on its own.
+func HighlightInline(oldStr, newStr string, oldColor, newColor ColorFunc) (string, string, error) {
+	oldToks, newToks := tokenizeWords(oldStr), tokenizeWords(newStr)
+
+	splices, err := types.CalcSplices(uint64(len(oldToks)), uint64(len(newToks)), types.DEFAULT_MAX_SPLICE_MATRIX_SIZE,
+		func(i uint64, j uint64) (bool, error) {
+			return oldToks[i] == newToks[j], nil
+		})
+
+	if err != nil {
+		return "", "", err
+	}
+
+	oldChanged := make([]bool, len(oldToks))
+	newChanged := make([]bool, len(newToks))
+	for _, sp := range splices {
+		for i := sp.SpAt; i < sp.SpAt+sp.SpRemoved; i++ {
+			oldChanged[i] = true
+		}
+		for i := sp.SpFrom; i < sp.SpFrom+sp.SpAdded; i++ {
+			newChanged[i] = true
+		}
+	}
+
+	return colorTokens(oldToks, oldChanged, oldColor), colorTokens(newToks, newChanged, newColor), nil
+}
+
+// tokenizeWords splits |str| into runs of letters and digits, and single characters of any other kind.
+func tokenizeWords(str string) []string {
+	var toks []string
+	start := -1
+	for i, r := range str {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start == -1 {
+				start = i
+			}
+			continue
+		}
+
+		if start != -1 {
+			toks = append(toks, str[start:i])
+			start = -1
+		}
+
+		toks = append(toks, string(r))
+	}
+
+	if start != -1 {
+		toks = append(toks, str[start:])
+	}
+
+	return toks
+}
+
+// colorTokens joins |toks| back together, coloring each consecutive run of changed tokens with |colorFunc|.
+func colorTokens(toks []string, changed []bool, colorFunc ColorFunc) string {
+	sb := strings.Builder{}
+	for i := 0; i < len(toks); {
+		j := i
+		for j < len(toks) && changed[j] == changed[i] {
+			j++
+		}
+
+		run := strings.Join(toks[i:j], "")
+		if changed[i] {
+			run = colorFunc("%s", run)
+		}
+
+		sb.WriteString(run)
+		i = j
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,77 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighlightInline(t *testing.T) {
+	oldColor := func(format string, a ...interface{}) string {
+		return "[-" + fmt.Sprintf(format, a...) + "-]"
+	}
+	newColor := func(format string, a ...interface{}) string {
+		return "{+" + fmt.Sprintf(format, a...) + "+}"
+	}
+
+	tests := []struct {
+		old, new       string
+		expOld, expNew string
+	}{
+		{
+			old:    "the quick brown fox",
+			new:    "the quick brown fox",
+			expOld: "the quick brown fox",
+			expNew: "the quick brown fox",
+		},
+		{
+			old:    "the quick brown fox",
+			new:    "the slow brown fox",
+			expOld: "the [-quick-] brown fox",
+			expNew: "the {+slow+} brown fox",
+		},
+		{
+			old:    "jumps over the dog",
+			new:    "jumps over the lazy dog",
+			expOld: "jumps over the dog",
+			expNew: "jumps over the {+lazy +}dog",
+		},
+		{
+			old:    "100% done.",
+			new:    "50% done!",
+			expOld: "[-100-]% done[-.-]",
+			expNew: "{+50+}% done{+!+}",
+		},
+		{
+			old:    "",
+			new:    "added",
+			expOld: "",
+			expNew: "{+added+}",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.old+" -> "+test.new, func(t *testing.T) {
+			actOld, actNew, err := HighlightInline(test.old, test.new, oldColor, newColor)
+			require.NoError(t, err)
+			assert.Equal(t, test.expOld, actOld)
+			assert.Equal(t, test.expNew, actNew)
+		})
+	}
+}
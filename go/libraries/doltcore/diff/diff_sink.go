@@ -38,8 +38,20 @@ const (
 type ColorFunc func(string, ...interface{}) string
 
 type ColorDiffSink struct {
-	sch schema.Schema
-	ttw *tabular.TextTableWriter
+	sch    schema.Schema
+	ttw    *tabular.TextTableWriter
+	inline bool
+
+	// pendingOld holds the old half of a modified row while inline highlighting waits for the new half
+	pendingOld *coloredRow
+}
+
+// coloredRow is a row of string values along with the color to apply to each column when it's written.
+type coloredRow struct {
+	nbf        *types.NomsBinFormat
+	taggedVals row.TaggedValues
+	colorFuncs map[uint64]ColorFunc
+	colDiffs   map[string]DiffChType
 }
 
 // NewColorDiffSink returns a ColorDiffSink that uses  the writer and schema given to print its output. numHeaderRows
@@ -58,7 +70,19 @@ func NewColorDiffSink(wr io.WriteCloser, sch schema.Schema, numHeaderRows int) (
 		return nil, err
 	}
 
-	return &ColorDiffSink{outSch, ttw}, nil
+	return &ColorDiffSink{sch: outSch, ttw: ttw}, nil
+}
+
+// NewInlineColorDiffSink returns a ColorDiffSink which, rather than coloring the entire value of a modified cell,
+// highlights only the text that changed within it. See NewColorDiffSink for details on numHeaderRows.
+func NewInlineColorDiffSink(wr io.WriteCloser, sch schema.Schema, numHeaderRows int) (*ColorDiffSink, error) {
+	cds, err := NewColorDiffSink(wr, sch, numHeaderRows)
+	if err != nil {
+		return nil, err
+	}
+
+	cds.inline = true
+	return cds, nil
 }
 
 // GetSchema gets the schema of the rows that this writer writes
@@ -74,6 +98,52 @@ var colDiffColors = map[DiffChType]ColorFunc{
 }
 
 func (cds *ColorDiffSink) ProcRowWithProps(r row.Row, props pipeline.ReadableMap) error {
+	cr, err := cds.colorRow(r, props)
+
+	if err != nil {
+		return err
+	}
+
+	if !cds.inline {
+		return cds.writeRow(cr)
+	}
+
+	var dt DiffChType = -1
+	if prop, ok := props.Get(DiffTypeProp); ok {
+		if convertedDT, convertedOK := prop.(DiffChType); convertedOK {
+			dt = convertedDT
+		}
+	}
+
+	if cds.pendingOld != nil {
+		oldRow := cds.pendingOld
+		cds.pendingOld = nil
+
+		if dt == DiffModifiedNew {
+			err = cds.highlightInline(oldRow, cr)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		err = cds.writeRow(oldRow)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if dt == DiffModifiedOld {
+		cds.pendingOld = cr
+		return nil
+	}
+
+	return cds.writeRow(cr)
+}
+
+// colorRow converts |r| to a coloredRow, choosing the color of each column from the diff properties of the row.
+func (cds *ColorDiffSink) colorRow(r row.Row, props pipeline.ReadableMap) (*coloredRow, error) {
 
 	taggedVals := make(row.TaggedValues)
 	allCols := cds.sch.GetAllCols()
@@ -93,7 +163,7 @@ func (cds *ColorDiffSink) ProcRowWithProps(r row.Row, props pipeline.ReadableMap
 	})
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	taggedVals[diffColTag] = types.String("   ")
@@ -118,6 +188,7 @@ func (cds *ColorDiffSink) ProcRowWithProps(r row.Row, props pipeline.ReadableMap
 	}
 
 	// Color the columns as appropriate. Some rows will be all colored.
+	colorFuncs := make(map[uint64]ColorFunc)
 	err = allCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
 		var colorFunc ColorFunc
 		if colorColumns {
@@ -137,17 +208,55 @@ func (cds *ColorDiffSink) ProcRowWithProps(r row.Row, props pipeline.ReadableMap
 		}
 
 		if colorFunc != nil {
-			taggedVals[tag] = types.String(colorFunc(string(taggedVals[tag].(types.String))))
+			colorFuncs[tag] = colorFunc
 		}
 
 		return false, nil
 	})
 
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	return &coloredRow{r.Format(), taggedVals, colorFuncs, colDiffs}, nil
+}
+
+// highlightInline replaces the whole cell coloring of the modified columns of |oldRow| and |newRow| with coloring of
+// only the text that changed between them.
+func (cds *ColorDiffSink) highlightInline(oldRow, newRow *coloredRow) error {
+	return cds.sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		if tag == diffColTag || oldRow.colDiffs[col.Name] != DiffModifiedOld || newRow.colDiffs[col.Name] != DiffModifiedNew {
+			return false, nil
+		}
+
+		oldVal, oldOK := oldRow.taggedVals[tag].(types.String)
+		newVal, newOK := newRow.taggedVals[tag].(types.String)
+		if !oldOK || !newOK {
+			return false, nil
+		}
+
+		oldStr, newStr, err := HighlightInline(string(oldVal), string(newVal), colDiffColors[DiffModifiedOld], colDiffColors[DiffModifiedNew])
+		if err != nil {
+			return true, err
+		}
+
+		oldRow.taggedVals[tag] = types.String(oldStr)
+		newRow.taggedVals[tag] = types.String(newStr)
+		delete(oldRow.colorFuncs, tag)
+		delete(newRow.colorFuncs, tag)
+
+		return false, nil
+	})
+}
+
+func (cds *ColorDiffSink) writeRow(cr *coloredRow) error {
+	for tag, colorFunc := range cr.colorFuncs {
+		if val, ok := cr.taggedVals[tag]; ok {
+			cr.taggedVals[tag] = types.String(colorFunc(string(val.(types.String))))
+		}
 	}
 
-	r, err = row.New(r.Format(), cds.sch, taggedVals)
+	r, err := row.New(cr.nbf, cds.sch, cr.taggedVals)
 
 	if err != nil {
 		return err
@@ -158,6 +267,15 @@ func (cds *ColorDiffSink) ProcRowWithProps(r row.Row, props pipeline.ReadableMap
 
 // Close should release resources being held
 func (cds *ColorDiffSink) Close() error {
+	if cds.pendingOld != nil {
+		err := cds.writeRow(cds.pendingOld)
+		cds.pendingOld = nil
+
+		if err != nil {
+			return err
+		}
+	}
+
 	if cds.ttw != nil {
 		if err := cds.ttw.Close(context.TODO()); err != nil {
 			return err
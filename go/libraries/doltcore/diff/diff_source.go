@@ -23,6 +23,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/pipeline"
+	"github.com/dolthub/dolt/go/store/diff"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
@@ -60,23 +61,54 @@ func (rdRd *RowDiffSource) GetSchema() schema.Schema {
 // NextDiff reads a row from a table.  If there is a bad row the returned error will be non nil, and callin IsBadRow(err)
 // will be return true. This is a potentially non-fatal error and callers can decide if they want to continue on a bad row, or fail.
 func (rdRd *RowDiffSource) NextDiff() (row.Row, pipeline.ImmutableProperties, error) {
-	diffs, hasMore, err := rdRd.ad.GetDiffs(1, time.Second)
-	if err != nil {
-		return nil, pipeline.ImmutableProperties{}, err
-	}
+	for {
+		diffs, hasMore, err := rdRd.ad.GetDiffs(1, time.Second)
+		if err != nil {
+			return nil, pipeline.ImmutableProperties{}, err
+		}
 
-	if len(diffs) == 0 {
-		if !hasMore {
-			return nil, pipeline.NoProps, io.EOF
+		if len(diffs) == 0 {
+			if !hasMore {
+				return nil, pipeline.NoProps, io.EOF
+			}
+			return nil, pipeline.NoProps, errors.New("timeout")
 		}
-		return nil, pipeline.NoProps, errors.New("timeout")
-	}
 
-	if len(diffs) != 1 {
-		panic("only a single diff requested, multiple returned.  bug in AsyncDiffer")
+		if len(diffs) != 1 {
+			panic("only a single diff requested, multiple returned.  bug in AsyncDiffer")
+		}
+
+		rows, err := rdRd.convertDiff(diffs[0])
+
+		if err != nil {
+			return nil, pipeline.NoProps, err
+		}
+
+		// rows which only differ in their encoding, such as rows with columns whose type was changed, are skipped
+		if rows[From] != nil && rows[To] != nil && !(rdRd.oldRowConv.IdentityConverter && rdRd.newRowConv.IdentityConverter) {
+			equal, err := taggedValsAreEqual(rows[From], rows[To])
+
+			if err != nil {
+				return nil, pipeline.NoProps, err
+			}
+
+			if equal {
+				continue
+			}
+		}
+
+		joinedRow, err := rdRd.joiner.Join(rows)
+
+		if err != nil {
+			return nil, pipeline.ImmutableProperties{}, err
+		}
+
+		return joinedRow, pipeline.ImmutableProperties{}, nil
 	}
+}
 
-	d := diffs[0]
+// convertDiff converts the old and new values of |d| into rows, applying any input row conversions.
+func (rdRd *RowDiffSource) convertDiff(d *diff.Difference) (map[string]row.Row, error) {
 	rows := make(map[string]row.Row)
 	if d.OldValue != nil {
 		sch := rdRd.joiner.SchemaForName(From)
@@ -87,13 +119,13 @@ func (rdRd *RowDiffSource) NextDiff() (row.Row, pipeline.ImmutableProperties, er
 		oldRow, err := row.FromNoms(sch, d.KeyValue.(types.Tuple), d.OldValue.(types.Tuple))
 
 		if err != nil {
-			return nil, pipeline.ImmutableProperties{}, err
+			return nil, err
 		}
 
 		rows[From], err = rdRd.oldRowConv.Convert(oldRow)
 
 		if err != nil {
-			return nil, pipeline.NoProps, err
+			return nil, err
 		}
 	}
 
@@ -106,23 +138,42 @@ func (rdRd *RowDiffSource) NextDiff() (row.Row, pipeline.ImmutableProperties, er
 		newRow, err := row.FromNoms(sch, d.KeyValue.(types.Tuple), d.NewValue.(types.Tuple))
 
 		if err != nil {
-			return nil, pipeline.ImmutableProperties{}, err
+			return nil, err
 		}
 
 		rows[To], err = rdRd.newRowConv.Convert(newRow)
 
 		if err != nil {
-			return nil, pipeline.NoProps, err
+			return nil, err
 		}
 	}
 
-	joinedRow, err := rdRd.joiner.Join(rows)
+	return rows, nil
+}
 
+func taggedValsAreEqual(r1, r2 row.Row) (bool, error) {
+	tv1, err := row.GetTaggedVals(r1)
 	if err != nil {
-		return nil, pipeline.ImmutableProperties{}, err
+		return false, err
+	}
+
+	tv2, err := row.GetTaggedVals(r2)
+	if err != nil {
+		return false, err
+	}
+
+	if len(tv1) != len(tv2) {
+		return false, nil
+	}
+
+	for tag, v1 := range tv1 {
+		v2, ok := tv2[tag]
+		if !ok || !v1.Equals(v2) {
+			return false, nil
+		}
 	}
 
-	return joinedRow, pipeline.ImmutableProperties{}, nil
+	return true, nil
 }
 
 // Close should release resources being held
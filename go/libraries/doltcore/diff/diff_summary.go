@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"time"
 
+	"golang.org/x/sync/errgroup"
+
 	"github.com/dolthub/dolt/go/libraries/doltcore/row"
 
 	"github.com/dolthub/dolt/go/store/diff"
@@ -185,3 +187,29 @@ func reportKeylessChanges(ctx context.Context, change *diff.Difference, ch chan<
 		return ctx.Err()
 	}
 }
+
+// SummarizeTableDelta returns the total of the DiffSummaryProgress reported by SummaryForTableDelta for |td|.
+func SummarizeTableDelta(ctx context.Context, td TableDelta) (DiffSummaryProgress, error) {
+	ch := make(chan DiffSummaryProgress)
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		defer close(ch)
+		return SummaryForTableDelta(egCtx, ch, td)
+	})
+
+	var acc DiffSummaryProgress
+	for p := range ch {
+		acc.Adds += p.Adds
+		acc.Removes += p.Removes
+		acc.Changes += p.Changes
+		acc.CellChanges += p.CellChanges
+		acc.NewSize += p.NewSize
+		acc.OldSize += p.OldSize
+	}
+
+	if err := eg.Wait(); err != nil {
+		return DiffSummaryProgress{}, err
+	}
+
+	return acc, nil
+}
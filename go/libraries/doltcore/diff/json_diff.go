@@ -0,0 +1,337 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/pipeline"
+	jsonwr "github.com/dolthub/dolt/go/libraries/doltcore/table/typed/json"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
+)
+
+const (
+	jsonDiffTableHeader = `{"tables":[`
+	jsonDiffTableFooter = `]}`
+)
+
+var tableDiffTypeNames = map[TableDiffType]string{
+	AddedTable:    "added",
+	ModifiedTable: "modified",
+	RenamedTable:  "renamed",
+	RemovedTable:  "removed",
+}
+
+var schemaChangeTypeNames = map[SchemaChangeType]string{
+	SchDiffAdded:    "added",
+	SchDiffRemoved:  "removed",
+	SchDiffModified: "modified",
+}
+
+// RowDiffTypeName returns the name of the type of change between |from| and |to|, either of which may be nil.
+func RowDiffTypeName(from, to row.Row) string {
+	if from == nil {
+		return "added"
+	} else if to == nil {
+		return "removed"
+	}
+	return "modified"
+}
+
+type jsonColumn struct {
+	Name       string `json:"name"`
+	Tag        uint64 `json:"tag"`
+	Type       string `json:"type"`
+	PrimaryKey bool   `json:"primary_key"`
+	Nullable   bool   `json:"nullable"`
+}
+
+type jsonColumnDiff struct {
+	DiffType string      `json:"diff_type"`
+	From     *jsonColumn `json:"from,omitempty"`
+	To       *jsonColumn `json:"to,omitempty"`
+}
+
+type jsonIndex struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Unique  bool     `json:"unique"`
+}
+
+type jsonIndexDiff struct {
+	DiffType string     `json:"diff_type"`
+	From     *jsonIndex `json:"from,omitempty"`
+	To       *jsonIndex `json:"to,omitempty"`
+}
+
+type jsonSchemaDiff struct {
+	Columns []jsonColumnDiff `json:"columns"`
+	Indexes []jsonIndexDiff  `json:"indexes"`
+}
+
+type jsonTableDiff struct {
+	Name     string `json:"name"`
+	FromName string `json:"from_name,omitempty"`
+	ToName   string `json:"to_name,omitempty"`
+	DiffType string `json:"diff_type"`
+}
+
+type jsonRowDiff struct {
+	DiffType string                 `json:"diff_type"`
+	From     map[string]interface{} `json:"from,omitempty"`
+	To       map[string]interface{} `json:"to,omitempty"`
+}
+
+func newJSONColumn(col *schema.Column) *jsonColumn {
+	if col == nil {
+		return nil
+	}
+
+	return &jsonColumn{
+		Name:       col.Name,
+		Tag:        col.Tag,
+		Type:       col.TypeInfo.ToSqlType().String(),
+		PrimaryKey: col.IsPartOfPK,
+		Nullable:   col.IsNullable(),
+	}
+}
+
+func newJSONIndex(idx schema.Index) *jsonIndex {
+	if idx == nil {
+		return nil
+	}
+
+	return &jsonIndex{
+		Name:    idx.Name(),
+		Columns: idx.ColumnNames(),
+		Unique:  idx.IsUnique(),
+	}
+}
+
+// JSONDiffWriter writes table deltas as a single JSON document of the form
+// {"tables":[{"name":...,"diff_type":...,"schema_diff":{...},"data_diff":[...]},...]}.
+// Callers write each table by calling BeginTable, optionally WriteSchemaDiff and a JSONDiffSink for the row data
+// obtained from BeginDataDiff, followed by EndTable.
+type JSONDiffWriter struct {
+	wr            io.WriteCloser
+	tablesWritten int
+}
+
+// NewJSONDiffWriter returns a JSONDiffWriter which writes its output to |wr|.
+func NewJSONDiffWriter(wr io.WriteCloser) (*JSONDiffWriter, error) {
+	err := iohelp.WriteAll(wr, []byte(jsonDiffTableHeader))
+	if err != nil {
+		return nil, err
+	}
+	return &JSONDiffWriter{wr: wr}, nil
+}
+
+// BeginTable starts the JSON object for the table described by |td|.
+func (jdw *JSONDiffWriter) BeginTable(td TableDelta) error {
+	diffType := ModifiedTable
+	if td.IsAdd() {
+		diffType = AddedTable
+	} else if td.IsDrop() {
+		diffType = RemovedTable
+	} else if td.IsRename() {
+		diffType = RenamedTable
+	}
+
+	data, err := json.Marshal(jsonTableDiff{
+		Name:     td.CurName(),
+		FromName: td.FromName,
+		ToName:   td.ToName,
+		DiffType: tableDiffTypeNames[diffType],
+	})
+	if err != nil {
+		return err
+	}
+
+	if jdw.tablesWritten != 0 {
+		err = iohelp.WriteAll(jdw.wr, []byte(","))
+		if err != nil {
+			return err
+		}
+	}
+	jdw.tablesWritten++
+
+	// leave the table object open so that the schema and data diffs can be appended
+	return iohelp.WriteAll(jdw.wr, data[:len(data)-1])
+}
+
+// WriteSchemaDiff writes the column and index changes between |fromSch| and |toSch| as the "schema_diff" field of the
+// current table.
+func (jdw *JSONDiffWriter) WriteSchemaDiff(fromSch, toSch schema.Schema) error {
+	sd := jsonSchemaDiff{
+		Columns: []jsonColumnDiff{},
+		Indexes: []jsonIndexDiff{},
+	}
+
+	colDiffs, unionTags := DiffSchColumns(fromSch, toSch)
+	for _, tag := range unionTags {
+		cd := colDiffs[tag]
+		if cd.DiffType == SchDiffNone {
+			continue
+		}
+
+		sd.Columns = append(sd.Columns, jsonColumnDiff{
+			DiffType: schemaChangeTypeNames[cd.DiffType],
+			From:     newJSONColumn(cd.Old),
+			To:       newJSONColumn(cd.New),
+		})
+	}
+
+	for _, idxDiff := range DiffSchIndexes(fromSch, toSch) {
+		if idxDiff.DiffType == SchDiffNone {
+			continue
+		}
+
+		sd.Indexes = append(sd.Indexes, jsonIndexDiff{
+			DiffType: schemaChangeTypeNames[idxDiff.DiffType],
+			From:     newJSONIndex(idxDiff.From),
+			To:       newJSONIndex(idxDiff.To),
+		})
+	}
+
+	data, err := json.Marshal(sd)
+	if err != nil {
+		return err
+	}
+
+	err = iohelp.WriteAll(jdw.wr, []byte(`,"schema_diff":`))
+	if err != nil {
+		return err
+	}
+
+	return iohelp.WriteAll(jdw.wr, data)
+}
+
+// BeginDataDiff returns a JSONDiffSink which writes the row diffs of the current table as its "data_diff" field.
+// Rows given to the sink must have the schema of |joiner|.
+func (jdw *JSONDiffWriter) BeginDataDiff(joiner *rowconv.Joiner) (*JSONDiffSink, error) {
+	err := iohelp.WriteAll(jdw.wr, []byte(`,"data_diff":[`))
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONDiffSink{wr: jdw.wr, joiner: joiner}, nil
+}
+
+// EndTable closes the JSON object for the current table.
+func (jdw *JSONDiffWriter) EndTable() error {
+	return iohelp.WriteAll(jdw.wr, []byte("}"))
+}
+
+// Close writes the end of the JSON document and releases resources being held
+func (jdw *JSONDiffWriter) Close() error {
+	if jdw.wr == nil {
+		return errors.New("already closed")
+	}
+
+	err := iohelp.WriteAll(jdw.wr, []byte(jsonDiffTableFooter+"\n"))
+	if err != nil {
+		return err
+	}
+
+	err = jdw.wr.Close()
+	jdw.wr = nil
+	return err
+}
+
+// JSONDiffSink is a DiffSink which writes each joined diff row as a JSON object containing the diff type and the from
+// and to values of the row.
+type JSONDiffSink struct {
+	wr          io.Writer
+	joiner      *rowconv.Joiner
+	rowsWritten int
+	closed      bool
+}
+
+// GetSchema gets the schema of the rows that this sink accepts.
+func (jds *JSONDiffSink) GetSchema() schema.Schema {
+	return jds.joiner.GetSchema()
+}
+
+// ProcRowWithProps satisfies pipeline.SinkFunc; it writes the row diff as a JSON object.
+func (jds *JSONDiffSink) ProcRowWithProps(r row.Row, _ pipeline.ReadableMap) error {
+	rows, err := jds.joiner.Split(r)
+	if err != nil {
+		return err
+	}
+
+	from, to := rows[From], rows[To]
+	rd := jsonRowDiff{DiffType: RowDiffTypeName(from, to)}
+
+	if from != nil {
+		rd.From, err = rowToJSONColValMap(from, jds.joiner.SchemaForName(From))
+		if err != nil {
+			return err
+		}
+	}
+
+	if to != nil {
+		rd.To, err = rowToJSONColValMap(to, jds.joiner.SchemaForName(To))
+		if err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(rd)
+	if err != nil {
+		return err
+	}
+
+	if jds.rowsWritten != 0 {
+		err = iohelp.WriteAll(jds.wr, []byte(","))
+		if err != nil {
+			return err
+		}
+	}
+	jds.rowsWritten++
+
+	return iohelp.WriteAll(jds.wr, data)
+}
+
+// rowToJSONColValMap returns a map from column name to value for every column of |sch|. Unlike in a JSON table export,
+// NULL values are kept as explicit nulls, so that a NULL can be told apart from a column which does not exist.
+func rowToJSONColValMap(r row.Row, sch schema.Schema) (map[string]interface{}, error) {
+	colValMap, err := jsonwr.RowToColValMap(r, sch)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, col := range sch.GetAllCols().GetColumns() {
+		if _, ok := colValMap[col.Name]; !ok {
+			colValMap[col.Name] = nil
+		}
+	}
+
+	return colValMap, nil
+}
+
+// Close ends the "data_diff" array of the current table.
+func (jds *JSONDiffSink) Close() error {
+	if jds.closed {
+		return errors.New("already closed")
+	}
+	jds.closed = true
+
+	return iohelp.WriteAll(jds.wr, []byte("]"))
+}
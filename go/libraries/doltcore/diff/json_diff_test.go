@@ -0,0 +1,139 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/utils/iohelp"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var fromTestCols = []schema.Column{
+	schema.NewColumn("pk", 0, types.IntKind, true, schema.NotNullConstraint{}),
+	schema.NewColumn("name", 1, types.StringKind, false),
+}
+
+var toTestCols = []schema.Column{
+	schema.NewColumn("pk", 0, types.IntKind, true, schema.NotNullConstraint{}),
+	schema.NewColumn("name", 1, types.StringKind, false),
+	schema.NewColumn("age", 2, types.IntKind, false),
+}
+
+func newTestJoiner(t *testing.T) (*rowconv.Joiner, schema.Schema, schema.Schema) {
+	fromSch, err := schema.SchemaFromCols(schema.NewColCollection(fromTestCols...))
+	require.NoError(t, err)
+	toSch, err := schema.SchemaFromCols(schema.NewColCollection(toTestCols...))
+	require.NoError(t, err)
+
+	namer := func(prefix string) rowconv.ColNamingFunc {
+		return func(name string) string { return prefix + "_" + name }
+	}
+
+	joiner, err := rowconv.NewJoiner(
+		[]rowconv.NamedSchema{{Name: From, Sch: fromSch}, {Name: To, Sch: toSch}},
+		map[string]rowconv.ColNamingFunc{From: namer(From), To: namer(To)},
+	)
+	require.NoError(t, err)
+
+	return joiner, fromSch, toSch
+}
+
+func testDiffRows(t *testing.T, joiner *rowconv.Joiner, fromSch, toSch schema.Schema) []row.Row {
+	nbf := types.Format_Default
+	mustJoin := func(from, to row.TaggedValues) row.Row {
+		namedRows := make(map[string]row.Row)
+		if from != nil {
+			r, err := row.New(nbf, fromSch, from)
+			require.NoError(t, err)
+			namedRows[From] = r
+		}
+		if to != nil {
+			r, err := row.New(nbf, toSch, to)
+			require.NoError(t, err)
+			namedRows[To] = r
+		}
+		r, err := joiner.Join(namedRows)
+		require.NoError(t, err)
+		return r
+	}
+
+	return []row.Row{
+		mustJoin(row.TaggedValues{0: types.Int(1), 1: types.String("bill")}, row.TaggedValues{0: types.Int(1), 1: types.String("bill, jr"), 2: types.Int(30)}),
+		mustJoin(row.TaggedValues{0: types.Int(2), 1: types.String("jane")}, nil),
+		mustJoin(nil, row.TaggedValues{0: types.Int(3), 2: types.Int(4)}),
+		mustJoin(row.TaggedValues{0: types.Int(4), 1: types.String("joe")}, row.TaggedValues{0: types.Int(4), 1: types.NullValue}),
+	}
+}
+
+func TestJSONDiffWriter(t *testing.T) {
+	joiner, fromSch, toSch := newTestJoiner(t)
+
+	buf := &bytes.Buffer{}
+	jdw, err := NewJSONDiffWriter(iohelp.NopWrCloser(buf))
+	require.NoError(t, err)
+
+	err = jdw.BeginTable(TableDelta{FromName: "people", ToName: "people"})
+	require.NoError(t, err)
+	err = jdw.WriteSchemaDiff(fromSch, toSch)
+	require.NoError(t, err)
+
+	sink, err := jdw.BeginDataDiff(joiner)
+	require.NoError(t, err)
+	for _, r := range testDiffRows(t, joiner, fromSch, toSch) {
+		err = sink.ProcRowWithProps(r, nil)
+		require.NoError(t, err)
+	}
+	require.NoError(t, sink.Close())
+	require.NoError(t, jdw.EndTable())
+	require.NoError(t, jdw.Close())
+
+	expected := `{"tables":[{"name":"people","from_name":"people","to_name":"people","diff_type":"modified",` +
+		`"schema_diff":{"columns":[{"diff_type":"added","to":{"name":"age","tag":2,"type":"BIGINT","primary_key":false,"nullable":true}}],"indexes":[]},` +
+		`"data_diff":[` +
+		`{"diff_type":"modified","from":{"name":"bill","pk":1},"to":{"age":30,"name":"bill, jr","pk":1}},` +
+		`{"diff_type":"removed","from":{"name":"jane","pk":2}},` +
+		`{"diff_type":"added","to":{"age":4,"name":null,"pk":3}},` +
+		`{"diff_type":"modified","from":{"name":"joe","pk":4},"to":{"age":null,"name":null,"pk":4}}` +
+		`]}]}` + "\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestCSVDiffSink(t *testing.T) {
+	joiner, fromSch, toSch := newTestJoiner(t)
+
+	buf := &bytes.Buffer{}
+	sink, err := NewCSVDiffSink(iohelp.NopWrCloser(buf), joiner)
+	require.NoError(t, err)
+
+	for _, r := range testDiffRows(t, joiner, fromSch, toSch) {
+		err = sink.ProcRowWithProps(r, nil)
+		require.NoError(t, err)
+	}
+	require.NoError(t, sink.Close())
+
+	expected := "diff_type,from_pk,to_pk,from_name,to_name,from_age,to_age\n" +
+		"modified,1,1,bill,\"bill, jr\",,30\n" +
+		"removed,2,,jane,,,\n" +
+		"added,,3,,,,4\nmodified,4,4,joe,,,\n"
+	assert.Equal(t, expected, buf.String())
+}
@@ -154,8 +154,15 @@ type TableDelta struct {
 }
 
 // GetTableDeltas returns a slice of TableDelta objects for each table that changed between fromRoot and toRoot.
-// It matches tables across roots using the tag of the first primary key column in the table's schema.
+// It matches tables across roots using the tag of the first primary key column in the table's schema. Tables which
+// were left out of a sparse clone are matched by name, and ErrTableNotPresent is returned if one of them changed, as
+// its changes can't be known.
 func GetTableDeltas(ctx context.Context, fromRoot, toRoot *doltdb.RootValue) (deltas []TableDelta, err error) {
+	err = checkTablesNotPresent(ctx, fromRoot, toRoot)
+	if err != nil {
+		return nil, err
+	}
+
 	deltas, err = getKeylessDeltas(ctx, fromRoot, toRoot)
 	if err != nil {
 		return nil, err
@@ -260,6 +267,35 @@ func GetTableDeltas(ctx context.Context, fromRoot, toRoot *doltdb.RootValue) (de
 	return deltas, nil
 }
 
+// checkTablesNotPresent returns ErrTableNotPresent if a table which was left out of a sparse clone is not the same
+// table with the same name in |fromRoot| and |toRoot|. These tables are skipped by IterTables, and would otherwise be
+// missing from the deltas.
+func checkTablesNotPresent(ctx context.Context, fromRoot, toRoot *doltdb.RootValue) error {
+	fromNotPresent, err := fromRoot.TablesNotPresent(ctx)
+	if err != nil {
+		return err
+	}
+
+	toNotPresent, err := toRoot.TablesNotPresent(ctx)
+	if err != nil {
+		return err
+	}
+
+	for name, h := range fromNotPresent {
+		if toH, ok := toNotPresent[name]; !ok || toH != h {
+			return doltdb.ErrTableNotPresent{TableName: name}
+		}
+	}
+
+	for name := range toNotPresent {
+		if _, ok := fromNotPresent[name]; !ok {
+			return doltdb.ErrTableNotPresent{TableName: name}
+		}
+	}
+
+	return nil
+}
+
 func GetStagedUnstagedTableDeltas(ctx context.Context, ddb *doltdb.DoltDB, rsr env.RepoStateReader) (staged, unstaged []TableDelta, err error) {
 	headRoot, err := env.HeadRoot(ctx, ddb, rsr)
 	if err != nil {
@@ -431,6 +467,21 @@ func (td TableDelta) HasFKChanges() bool {
 	return !fkSlicesAreEqual(td.FromFks, td.ToFks)
 }
 
+// HasSchemaChanged returns true if the table was added, dropped or renamed, or if its columns, indexes or foreign keys
+// changed between the fromRoot and toRoot.
+func (td TableDelta) HasSchemaChanged(ctx context.Context) (bool, error) {
+	if td.IsAdd() || td.IsDrop() || td.IsRename() || td.HasFKChanges() {
+		return true, nil
+	}
+
+	from, to, err := td.GetSchemas(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return !schema.SchemasAreEqual(from, to), nil
+}
+
 // GetSchemas returns the table's schema at the fromRoot and toRoot, or schema.Empty if the table did not exist.
 func (td TableDelta) GetSchemas(ctx context.Context) (from, to schema.Schema, err error) {
 	if td.FromTable != nil {
@@ -17,6 +17,8 @@ package doltdb
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sort"
 
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -158,10 +160,45 @@ func (c *Commit) getParent(ctx context.Context, idx int) (*types.Struct, error)
 	if err != nil {
 		return nil, err
 	}
+	if targVal == nil {
+		return nil, c.missingParentErr(parentRef.TargetHash())
+	}
 	parentSt := targVal.(types.Struct)
 	return &parentSt, nil
 }
 
+// isAtShallowBoundary returns whether the commit is at the boundary of a shallow clone, below which its history is cut
+// off.
+func (c *Commit) isAtShallowBoundary() (bool, error) {
+	pvs, ok := c.vrw.(*partialValueStore)
+
+	if !ok || len(pvs.shallow) == 0 {
+		return false, nil
+	}
+
+	h, err := c.HashOf()
+
+	if err != nil {
+		return false, err
+	}
+
+	return pvs.shallow.Has(h), nil
+}
+
+// missingParentErr returns the error for the parent |parent| of the commit not being present, which is
+// ErrShallowBoundary for a commit at the boundary of a shallow clone. Otherwise the database is missing a commit.
+func (c *Commit) missingParentErr(parent hash.Hash) error {
+	atBoundary, err := c.isAtShallowBoundary()
+
+	if err != nil {
+		return err
+	} else if atBoundary {
+		return ErrShallowBoundary
+	}
+
+	return fmt.Errorf("failed to get commit %s", parent.String())
+}
+
 // GetRootValue gets the RootValue of the commit.
 func (c *Commit) GetRootValue() (*RootValue, error) {
 	rootVal, _, err := c.commitSt.MaybeGet(rootValueField)
@@ -234,6 +271,208 @@ func getCommitAncestorRef(ctx context.Context, ref1, ref2 types.Ref, vrw1, vrw2
 	return ancestorRef, nil
 }
 
+// IsAncestor returns whether |ancestor| is reachable from |descendant| by following parent links. Every commit is an
+// ancestor of itself.
+func IsAncestor(ctx context.Context, ancestor, descendant *Commit) (bool, error) {
+	mergeBase, err := GetCommitAncestor(ctx, ancestor, descendant)
+
+	if err == ErrNoCommonAncestor {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return mergeBase.commitSt.Equals(ancestor.commitSt), nil
+}
+
+// GetMergeBases returns all the best common ancestors of |cm1| and |cm2|, which are the common ancestors that are not
+// ancestors of any other common ancestor. There is usually only one, but histories with criss-cross merges can have
+// several. They are ordered by descending height. Returns ErrNoCommonAncestor if the commits share no history.
+func GetMergeBases(ctx context.Context, cm1, cm2 *Commit) ([]*Commit, error) {
+	cm1Ancestors := make(map[hash.Hash]bool)
+	err := walkAncestors(ctx, cm1, func(c *Commit, h hash.Hash) (bool, error) {
+		cm1Ancestors[h] = true
+		return true, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the first ancestors of |cm2| found in the history of |cm1| are the candidates. There is no need to walk past them,
+	// as their ancestors can't be best common ancestors.
+	var candidates []*Commit
+	err = walkAncestors(ctx, cm2, func(c *Commit, h hash.Hash) (bool, error) {
+		if cm1Ancestors[h] {
+			candidates = append(candidates, c)
+			return false, nil
+		}
+		return true, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(candidates) == 0 {
+		return nil, ErrNoCommonAncestor
+	}
+
+	return independentCommits(ctx, candidates)
+}
+
+// GetOctopusMergeBases returns the best common ancestors of all of |commits|, as would be used for a merge of all of
+// them at once. Returns ErrNoCommonAncestor if the commits share no history.
+func GetOctopusMergeBases(ctx context.Context, commits []*Commit) ([]*Commit, error) {
+	if len(commits) == 0 {
+		return nil, errors.New("no commits provided")
+	}
+
+	bases := commits[:1]
+	for _, cm := range commits[1:] {
+		var next []*Commit
+		for _, base := range bases {
+			mergeBases, err := GetMergeBases(ctx, base, cm)
+
+			if err == ErrNoCommonAncestor {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+
+			next = append(next, mergeBases...)
+		}
+
+		if len(next) == 0 {
+			return nil, ErrNoCommonAncestor
+		}
+
+		var err error
+		bases, err = independentCommits(ctx, next)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return bases, nil
+}
+
+// walkAncestors visits |cm| and each of its ancestors once. The ancestors of a commit are not visited if |cb| returns
+// false for it, unless they are reachable through another commit. The walk stops at the boundary of a shallow history.
+func walkAncestors(ctx context.Context, cm *Commit, cb func(c *Commit, h hash.Hash) (bool, error)) error {
+	seen := make(map[hash.Hash]bool)
+	queue := []*Commit{cm}
+
+	for len(queue) > 0 {
+		curr := queue[0]
+		queue = queue[1:]
+
+		h, err := curr.HashOf()
+
+		if err != nil {
+			return err
+		}
+
+		if seen[h] {
+			continue
+		}
+		seen[h] = true
+
+		walkParents, err := cb(curr, h)
+
+		if err != nil {
+			return err
+		}
+
+		if !walkParents {
+			continue
+		}
+
+		for i := range curr.parents {
+			parentSt, err := curr.getParent(ctx, i)
+
+			if err == ErrShallowBoundary {
+				continue
+			} else if err != nil {
+				return err
+			}
+
+			queue = append(queue, NewCommit(curr.vrw, *parentSt))
+		}
+	}
+
+	return nil
+}
+
+// independentCommits removes duplicates and the commits which are ancestors of another commit from |commits|, and
+// orders the remaining commits by descending height and then hash.
+func independentCommits(ctx context.Context, commits []*Commit) ([]*Commit, error) {
+	type commitAndHeight struct {
+		cm     *Commit
+		hash   hash.Hash
+		height uint64
+	}
+
+	seen := make(map[hash.Hash]bool)
+	var unique []commitAndHeight
+	for _, cm := range commits {
+		h, err := cm.HashOf()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[h] {
+			continue
+		}
+		seen[h] = true
+
+		height, err := cm.Height()
+
+		if err != nil {
+			return nil, err
+		}
+
+		unique = append(unique, commitAndHeight{cm, h, height})
+	}
+
+	sort.Slice(unique, func(i, j int) bool {
+		if unique[i].height != unique[j].height {
+			return unique[i].height > unique[j].height
+		}
+		return unique[i].hash.String() < unique[j].hash.String()
+	})
+
+	var independent []*Commit
+	for i, c := range unique {
+		isAncestorOfOther := false
+		// only a commit with a greater height can have |c| as an ancestor
+		for _, other := range unique[:i] {
+			if other.height == c.height {
+				break
+			}
+
+			var err error
+			isAncestorOfOther, err = IsAncestor(ctx, c.cm, other.cm)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if isAncestorOfOther {
+				break
+			}
+		}
+
+		if !isAncestorOfOther {
+			independent = append(independent, c.cm)
+		}
+	}
+
+	return independent, nil
+}
+
 func (c *Commit) CanFastForwardTo(ctx context.Context, new *Commit) (bool, error) {
 	ancestor, err := GetCommitAncestor(ctx, c, new)
 
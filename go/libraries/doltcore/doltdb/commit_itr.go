@@ -115,8 +115,23 @@ func (cmItr *commitItr) Next(ctx context.Context) (hash.Hash, *Commit, error) {
 		return hash.Hash{}, nil, err
 	}
 
-	for _, h := range parents {
+	atBoundary, err := cmItr.curr.isAtShallowBoundary()
+
+	if err != nil {
+		return hash.Hash{}, nil, err
+	}
+
+	for i, h := range parents {
 		if !cmItr.added[h] {
+			// the parents of a commit at the boundary of a shallow clone which are not present are not iterated
+			if atBoundary {
+				if _, err := cmItr.curr.getParent(ctx, i); err == ErrShallowBoundary {
+					continue
+				} else if err != nil {
+					return hash.Hash{}, nil, err
+				}
+			}
+
 			cmItr.added[h] = true
 			cmItr.unprocessed = append(cmItr.unprocessed, h)
 		}
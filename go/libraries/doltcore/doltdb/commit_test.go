@@ -0,0 +1,115 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doltdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestMergeBases(t *testing.T) {
+	ctx := context.Background()
+	ddb, err := LoadDoltDB(ctx, types.Format_Default, InMemDoltDB)
+	require.NoError(t, err)
+	err = ddb.WriteEmptyRepo(ctx, "Bill Billerson", "bigbillieb@fake.horse")
+	require.NoError(t, err)
+
+	cs, _ := NewCommitSpec("master")
+	root, err := ddb.Resolve(ctx, cs, nil)
+	require.NoError(t, err)
+	rv, err := root.GetRootValue()
+	require.NoError(t, err)
+	rvh, err := ddb.WriteRootValue(ctx, rv)
+	require.NoError(t, err)
+
+	commit := func(desc string, parents ...*Commit) *Commit {
+		meta, err := NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", desc)
+		require.NoError(t, err)
+		cm, err := ddb.CommitDanglingWithParentCommits(ctx, rvh, parents, meta)
+		require.NoError(t, err)
+		return cm
+	}
+
+	// a1 and b1 are both merged into a2 and b2, so a2 and b2 have two best common ancestors
+	//
+	//      a1--a2
+	//     /  \/
+	// root   /\
+	//     \ /  \
+	//      b1--b2
+	a1 := commit("a1", root)
+	b1 := commit("b1", root)
+	a2 := commit("a2", a1, b1)
+	b2 := commit("b2", b1, a1)
+	c1 := commit("c1", root)
+
+	meta, err := NewCommitMeta("Bill Billerson", "bigbillieb@fake.horse", "unrelated")
+	require.NoError(t, err)
+	unrelated, err := ddb.Commit(ctx, rvh, ref.NewBranchRef("unrelated"), meta)
+	require.NoError(t, err)
+
+	hashes := func(commits []*Commit) []hash.Hash {
+		var hs []hash.Hash
+		for _, cm := range commits {
+			h, err := cm.HashOf()
+			require.NoError(t, err)
+			hs = append(hs, h)
+		}
+		return hs
+	}
+
+	isAncestor, err := IsAncestor(ctx, root, a2)
+	require.NoError(t, err)
+	assert.True(t, isAncestor)
+	isAncestor, err = IsAncestor(ctx, a2, a2)
+	require.NoError(t, err)
+	assert.True(t, isAncestor)
+	isAncestor, err = IsAncestor(ctx, a2, b2)
+	require.NoError(t, err)
+	assert.False(t, isAncestor)
+	isAncestor, err = IsAncestor(ctx, unrelated, a2)
+	require.NoError(t, err)
+	assert.False(t, isAncestor)
+
+	bases, err := GetMergeBases(ctx, a2, b2)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, hashes([]*Commit{a1, b1}), hashes(bases))
+
+	bases, err = GetMergeBases(ctx, a1, a2)
+	require.NoError(t, err)
+	assert.Equal(t, hashes([]*Commit{a1}), hashes(bases))
+
+	bases, err = GetMergeBases(ctx, a2, c1)
+	require.NoError(t, err)
+	assert.Equal(t, hashes([]*Commit{root}), hashes(bases))
+
+	_, err = GetMergeBases(ctx, a2, unrelated)
+	assert.Equal(t, ErrNoCommonAncestor, err)
+
+	bases, err = GetOctopusMergeBases(ctx, []*Commit{a1, b1, c1})
+	require.NoError(t, err)
+	assert.Equal(t, hashes([]*Commit{root}), hashes(bases))
+
+	bases, err = GetOctopusMergeBases(ctx, []*Commit{a2, b2, a1})
+	require.NoError(t, err)
+	assert.Equal(t, hashes([]*Commit{a1}), hashes(bases))
+}
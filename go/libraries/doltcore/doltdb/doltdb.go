@@ -25,6 +25,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/dbfactory"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/libraries/utils/set"
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/datas"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -58,13 +59,16 @@ var InMemDoltDB = "mem://"
 // errors in many cases.
 type DoltDB struct {
 	db datas.Database
+	// vrw is the ValueReadWriter of the commits and root values read from db. For a shallow or sparse clone it is a
+	// *partialValueStore, which records the values that are expected to be missing.
+	vrw types.ValueReadWriter
 }
 
 // DoltDBFromCS creates a DoltDB from a noms chunks.ChunkStore
 func DoltDBFromCS(cs chunks.ChunkStore) *DoltDB {
 	db := datas.NewDatabase(cs)
 
-	return &DoltDB{db}
+	return &DoltDB{db: db, vrw: db}
 }
 
 // LoadDoltDB will acquire a reference to the underlying noms db.  If the Location is InMemDoltDB then a reference
@@ -91,7 +95,7 @@ func LoadDoltDBWithParams(ctx context.Context, nbf *types.NomsBinFormat, urlStr
 		return nil, err
 	}
 
-	return &DoltDB{db}, nil
+	return &DoltDB{db: db, vrw: db}, nil
 }
 
 func (ddb *DoltDB) CSMetricsSummary() string {
@@ -123,7 +127,7 @@ func (ddb *DoltDB) WriteEmptyRepoWithCommitTime(ctx context.Context, name, email
 		return errors.New("database already exists")
 	}
 
-	rv, err := emptyRootValue(ctx, ddb.db)
+	rv, err := emptyRootValue(ctx, ddb.vrw)
 
 	if err != nil {
 		return err
@@ -342,13 +346,13 @@ func (ddb *DoltDB) Resolve(ctx context.Context, cs *CommitSpec, cwb ref.DoltRef)
 		return nil, err
 	}
 
-	commitSt, err = getAncestor(ctx, ddb.db, commitSt, cs.aSpec)
+	commitSt, err = getAncestor(ctx, ddb.vrw, commitSt, cs.aSpec)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return NewCommit(ddb.db, commitSt), nil
+	return NewCommit(ddb.vrw, commitSt), nil
 }
 
 // ResolveRef takes a DoltRef and returns a Commit, or an error if the commit cannot be found.
@@ -357,7 +361,7 @@ func (ddb *DoltDB) ResolveRef(ctx context.Context, ref ref.DoltRef) (*Commit, er
 	if err != nil {
 		return nil, err
 	}
-	return NewCommit(ddb.db, commitSt), nil
+	return NewCommit(ddb.vrw, commitSt), nil
 }
 
 // ResolveTag takes a TagRef and returns the corresponding Tag object.
@@ -378,7 +382,7 @@ func (ddb *DoltDB) ResolveTag(ctx context.Context, tagRef ref.TagRef) (*Tag, err
 		return nil, fmt.Errorf("tagRef head is not a tag")
 	}
 
-	return NewTag(ctx, tagRef.GetPath(), ddb.db, tagSt)
+	return NewTag(ctx, tagRef.GetPath(), ddb.vrw, tagSt)
 }
 
 // TODO: convenience method to resolve the head commit of a branch.
@@ -426,7 +430,7 @@ func (ddb *DoltDB) ReadRootValue(ctx context.Context, h hash.Hash) (*RootValue,
 		return nil, errors.New("there is no dolt root value at that hash")
 	}
 
-	return newRootValue(ddb.db, rootSt)
+	return newRootValue(ddb.vrw, rootSt)
 }
 
 // Commit will update a branch's head value to be that of a previously committed root value hash
@@ -587,7 +591,7 @@ func (ddb *DoltDB) CommitWithParentCommits(ctx context.Context, valHash hash.Has
 		return nil, errors.New("commit has no head but commit succeeded (How?!?!?)")
 	}
 
-	return NewCommit(ddb.db, commitSt), nil
+	return NewCommit(ddb.vrw, commitSt), nil
 }
 
 // dangling commits are unreferenced by any branch or ref. They are created in the course of programmatic updates
@@ -634,18 +638,24 @@ func (ddb *DoltDB) CommitDanglingWithParentCommits(ctx context.Context, valHash
 		return nil, err
 	}
 
-	return NewCommit(ddb.db, commitSt), nil
+	return NewCommit(ddb.vrw, commitSt), nil
 }
 
 // ValueReadWriter returns the underlying noms database as a types.ValueReadWriter.
 func (ddb *DoltDB) ValueReadWriter() types.ValueReadWriter {
-	return ddb.db
+	return ddb.vrw
 }
 
 func (ddb *DoltDB) Format() *types.NomsBinFormat {
 	return ddb.db.Format()
 }
 
+// Rebase brings the view of the database in line with its storage, so that changes made to it by other processes
+// are seen.
+func (ddb *DoltDB) Rebase(ctx context.Context) error {
+	return ddb.db.Rebase(ctx)
+}
+
 func WriteValAndGetRef(ctx context.Context, vrw types.ValueReadWriter, val types.Value) (types.Ref, error) {
 	valRef, err := types.NewRef(val, vrw.Format())
 
@@ -672,7 +682,8 @@ func WriteValAndGetRef(ctx context.Context, vrw types.ValueReadWriter, val types
 
 // ResolveParent returns the n-th ancestor of a given commit (direct parent is index 0). error return value will be
 // non-nil in the case that the commit cannot be resolved, there aren't as many ancestors as requested, or the
-// underlying storage cannot be accessed.
+// underlying storage cannot be accessed. ErrShallowBoundary is returned if the commit is at the boundary of a shallow
+// history and its parent is not present.
 func (ddb *DoltDB) ResolveParent(ctx context.Context, commit *Commit, parentIdx int) (*Commit, error) {
 	parentCommitSt, err := commit.getParent(ctx, parentIdx)
 	if err != nil {
@@ -1019,6 +1030,12 @@ func (ddb *DoltDB) PushChunksForRefHash(ctx context.Context, tempDir string, src
 // PullChunks initiates a pull into a database from the source database given, at the commit given. Progress is
 // communicated over the provided channel.
 func (ddb *DoltDB) PullChunks(ctx context.Context, tempDir string, srcDB *DoltDB, stRef types.Ref, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
+	return ddb.PullChunksSkipping(ctx, tempDir, srcDB, stRef, nil, progChan, pullerEventCh)
+}
+
+// PullChunksSkipping initiates a pull like PullChunks, except that the values with the hashes |skip|, and the chunks
+// which are only reachable through them, are not pulled. It is used to pull a history which is cut off at some commits.
+func (ddb *DoltDB) PullChunksSkipping(ctx context.Context, tempDir string, srcDB *DoltDB, stRef types.Ref, skip hash.HashSet, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
 	if datas.CanUsePuller(srcDB.db) && datas.CanUsePuller(ddb.db) {
 		puller, err := datas.NewPuller(ctx, tempDir, 256*1024, srcDB.db, ddb.db, stRef.TargetHash(), pullerEventCh)
 
@@ -1028,10 +1045,64 @@ func (ddb *DoltDB) PullChunks(ctx context.Context, tempDir string, srcDB *DoltDB
 			return err
 		}
 
+		puller.Skip(skip)
 		return puller.Pull(ctx)
 	} else {
-		return datas.PullWithoutBatching(ctx, srcDB.db, ddb.db, stRef, progChan)
+		return datas.PullWithoutBatchingSkipping(ctx, srcDB.db, ddb.db, stRef, skip, progChan)
+	}
+}
+
+// SetEnforceCompleteness sets whether writing a value to the database checks that every value it references is in the
+// database. The check is turned off for shallow and sparse clones, which reference values which are not present.
+func (ddb *DoltDB) SetEnforceCompleteness(enforce bool) {
+	ddb.db.SetEnforceCompleteness(enforce)
+}
+
+// SetShallow records |commits| as the commits at the boundary of a shallow clone, whose parents are not present. The
+// parents of other commits are still expected to be present, and reading one which is not is an error.
+func (ddb *DoltDB) SetShallow(commits hash.HashSet) {
+	pvs := ddb.copyPartialValueStore()
+	pvs.shallow = commits
+	ddb.vrw = pvs
+}
+
+// SetSparse records |tables| as the tables of a sparse clone, which are the only tables present besides the dolt system
+// tables. The other tables of its root values are expected to be missing.
+func (ddb *DoltDB) SetSparse(tables []string) {
+	pvs := ddb.copyPartialValueStore()
+	pvs.sparse = set.NewStrSet(tables)
+	ddb.vrw = pvs
+}
+
+// copyPartialValueStore returns a copy of the partialValueStore of the database, which is empty if the database has all
+// the values referenced by its commits. The copy is modified and then replaces it, so that the values already read
+// keep the ValueReadWriter they were read with.
+func (ddb *DoltDB) copyPartialValueStore() *partialValueStore {
+	pvs := &partialValueStore{ValueReadWriter: ddb.db}
+	if curr, ok := ddb.vrw.(*partialValueStore); ok {
+		*pvs = *curr
 	}
+
+	return pvs
+}
+
+// partialValueStore is the ValueReadWriter of a DoltDB which does not have all of the values referenced by its commits,
+// such as a shallow or sparse clone. It records which values are expected to be missing, so that they can be told apart
+// from the values missing from a corrupt database.
+type partialValueStore struct {
+	types.ValueReadWriter
+
+	// shallow holds the commits at the boundary of a shallow clone, whose parents are not present
+	shallow hash.HashSet
+	// sparse holds the names of the tables of a sparse clone, which are the only tables present besides the dolt
+	// system tables
+	sparse *set.StrSet
+}
+
+// isLeftOutOfSparseClone returns whether |vrw| is the ValueReadWriter of a sparse clone which left out the table |name|.
+func isLeftOutOfSparseClone(vrw types.ValueReadWriter, name string) bool {
+	pvs, ok := vrw.(*partialValueStore)
+	return ok && pvs.sparse != nil && !pvs.sparse.Contains(name) && !HasDoltPrefix(name)
 }
 
 func (ddb *DoltDB) Clone(ctx context.Context, destDB *DoltDB, eventCh chan<- datas.TableFileEvent) error {
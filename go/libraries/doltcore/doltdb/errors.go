@@ -41,6 +41,10 @@ var ErrAlreadyOnWorkspace = errors.New("Already on workspace")
 
 var ErrNomsIO = errors.New("error reading from or writing to noms")
 
+// ErrShallowBoundary is returned when the parent of a commit is read which is not present locally, because the commit
+// is at the boundary of a shallow clone of the repository.
+var ErrShallowBoundary = errors.New("the parent commit is not present, as it is past the boundary of a shallow clone")
+
 var ErrNoConflicts = errors.New("no conflicts")
 var ErrUpToDate = errors.New("up to date")
 var ErrIsAhead = errors.New("current fast forward from a to b. a is ahead of b already")
@@ -56,6 +60,16 @@ func (e ErrClientOutOfDate) Error() string {
 	visit https://github.com/dolthub/dolt/releases/latest/`, e.ClientVer, e.RepoVer)
 }
 
+// ErrTableNotPresent is returned when a table is read which is not present locally, because it was left out of a
+// sparse clone of the repository.
+type ErrTableNotPresent struct {
+	TableName string
+}
+
+func (e ErrTableNotPresent) Error() string {
+	return fmt.Sprintf("table '%s' is not present locally, as it was not included in the sparse clone of this repository", e.TableName)
+}
+
 func IsInvalidFormatErr(err error) bool {
 	switch err {
 	case ErrInvBranchName, ErrInvTableName, ErrInvHash, ErrInvalidAncestorSpec, ErrInvalidBranchOrHash:
@@ -40,7 +40,8 @@ type FeatureVersion int64
 
 // DoltFeatureVersion is described in feature_version.md.
 // only variable for testing.
-var DoltFeatureVersion FeatureVersion = 0
+// last bumped when recording schema conflicts in the table struct
+var DoltFeatureVersion FeatureVersion = 1
 
 // RootValue defines the structure used inside all Dolthub noms dbs
 type RootValue struct {
@@ -140,7 +141,10 @@ func (root *RootValue) GetSuperSchema(ctx context.Context, tName string) (*schem
 	}
 
 	t, tblFound, err := root.GetTable(ctx, tName)
-	if err != nil {
+	if _, ok := err.(ErrTableNotPresent); ok && found {
+		// a table which is not present in a sparse clone is unchanged since the last commit
+		return ss, true, nil
+	} else if err != nil {
 		return nil, false, err
 	}
 
@@ -295,6 +299,8 @@ func (root *RootValue) getTableSt(ctx context.Context, tName string) (*types.Str
 
 	if err != nil {
 		return nil, false, err
+	} else if val == nil {
+		return nil, false, ErrTableNotPresent{tName}
 	}
 
 	tableStruct := val.(types.Struct)
@@ -332,11 +338,24 @@ func (root *RootValue) GetTableHash(ctx context.Context, tName string) (hash.Has
 	return tValRef.TargetHash(), true, nil
 }
 
+// SetTableHash sets the value of the table |tName| to the table value with the hash |h|. If the table already has that
+// value it is not read, so that the tables left out of a sparse clone can be set to their own values. Setting a table
+// to any other value which is not present returns ErrTableNotPresent.
 func (root *RootValue) SetTableHash(ctx context.Context, tName string, h hash.Hash) (*RootValue, error) {
+	curr, ok, err := root.GetTableHash(ctx, tName)
+
+	if err != nil {
+		return nil, err
+	} else if ok && curr == h {
+		return root, nil
+	}
+
 	val, err := root.vrw.ReadValue(ctx, h)
 
 	if err != nil {
 		return nil, err
+	} else if val == nil {
+		return nil, ErrTableNotPresent{tName}
 	}
 
 	ref, err := types.NewRef(val, root.vrw.Format())
@@ -471,6 +490,15 @@ func (root *RootValue) getTableMap() (types.Map, error) {
 }
 
 func (root *RootValue) TablesInConflict(ctx context.Context) ([]string, error) {
+	return root.tablesMatching(ctx, (*Table).HasConflicts)
+}
+
+// TablesWithSchemaConflicts returns the names of the tables whose schemas conflicted during a merge.
+func (root *RootValue) TablesWithSchemaConflicts(ctx context.Context) ([]string, error) {
+	return root.tablesMatching(ctx, (*Table).HasSchemaConflict)
+}
+
+func (root *RootValue) tablesMatching(ctx context.Context, pred func(*Table) (bool, error)) ([]string, error) {
 	tableMap, err := root.getTableMap()
 
 	if err != nil {
@@ -481,18 +509,25 @@ func (root *RootValue) TablesInConflict(ctx context.Context) ([]string, error) {
 	names := make([]string, 0, numTables)
 
 	err = tableMap.Iter(ctx, func(key, tblRefVal types.Value) (stop bool, err error) {
+		name := string(key.(types.String))
 		tblVal, err := tblRefVal.(types.Ref).TargetValue(ctx, root.vrw)
 
 		if err != nil {
 			return false, err
+		} else if tblVal == nil {
+			// tables which were left out of a sparse clone have no local changes
+			if isLeftOutOfSparseClone(root.vrw, name) {
+				return false, nil
+			}
+			return false, ErrTableNotPresent{name}
 		}
 
 		tblSt := tblVal.(types.Struct)
 		tbl := &Table{root.vrw, tblSt}
-		if has, err := tbl.HasConflicts(); err != nil {
+		if has, err := pred(tbl); err != nil {
 			return false, err
 		} else if has {
-			names = append(names, string(key.(types.String)))
+			names = append(names, name)
 		}
 
 		return false, nil
@@ -505,6 +540,7 @@ func (root *RootValue) TablesInConflict(ctx context.Context) ([]string, error) {
 	return names, nil
 }
 
+// HasConflicts returns whether any table has row or schema conflicts.
 func (root *RootValue) HasConflicts(ctx context.Context) (bool, error) {
 	cnfTbls, err := root.TablesInConflict(ctx)
 
@@ -512,10 +548,18 @@ func (root *RootValue) HasConflicts(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
-	return len(cnfTbls) > 0, nil
+	schCnfTbls, err := root.TablesWithSchemaConflicts(ctx)
+
+	if err != nil {
+		return false, err
+	}
+
+	return len(cnfTbls) > 0 || len(schCnfTbls) > 0, nil
 }
 
-// IterTables calls the callback function cb on each table in this RootValue.
+// IterTables calls the callback function cb on each table in this RootValue. Tables which were left out of a sparse
+// clone are skipped, and are returned by TablesNotPresent instead. ErrTableNotPresent is returned for any other table
+// which is not present.
 func (root *RootValue) IterTables(ctx context.Context, cb func(name string, table *Table, sch schema.Schema) (stop bool, err error)) error {
 	tm, err := root.getTableMap()
 
@@ -536,13 +580,18 @@ func (root *RootValue) IterTables(ctx context.Context, cb func(name string, tabl
 			return err
 		}
 
+		name := string(nm.(types.String))
 		tableStruct, err := tableRef.(types.Ref).TargetValue(ctx, root.vrw)
 
 		if err != nil {
 			return err
+		} else if tableStruct == nil {
+			if isLeftOutOfSparseClone(root.vrw, name) {
+				continue
+			}
+			return ErrTableNotPresent{name}
 		}
 
-		name := string(nm.(types.String))
 		table := &Table{root.vrw, tableStruct.(types.Struct)}
 
 		sch, err := table.GetSchema(ctx)
@@ -558,6 +607,42 @@ func (root *RootValue) IterTables(ctx context.Context, cb func(name string, tabl
 	}
 }
 
+// TablesNotPresent returns the hashes of the tables which were left out of a sparse clone, by table name. These tables
+// are skipped by IterTables.
+func (root *RootValue) TablesNotPresent(ctx context.Context) (map[string]hash.Hash, error) {
+	notPresent := make(map[string]hash.Hash)
+	tm, err := root.getTableMap()
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = tm.IterAll(ctx, func(key, value types.Value) error {
+		name := string(key.(types.String))
+
+		if !isLeftOutOfSparseClone(root.vrw, name) {
+			return nil
+		}
+
+		tableRef := value.(types.Ref)
+		tableStruct, err := tableRef.TargetValue(ctx, root.vrw)
+
+		if err != nil {
+			return err
+		} else if tableStruct == nil {
+			notPresent[name] = tableRef.TargetHash()
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return notPresent, nil
+}
+
 func (root *RootValue) iterSuperSchemas(ctx context.Context, cb func(name string, ss *schema.SuperSchema) (stop bool, err error)) error {
 	m, err := root.getOrCreateSuperSchemaMap(ctx)
 	if err != nil {
@@ -976,9 +1061,14 @@ func (root *RootValue) ValidateForeignKeysOnSchemas(ctx context.Context) (*RootV
 		return nil, err
 	}
 	allTablesSet := make(map[string]schema.Schema)
+	notPresent := make(map[string]bool)
 	for _, tableName := range allTablesSlice {
 		tbl, ok, err := root.GetTable(ctx, tableName)
-		if err != nil {
+		if _, isNotPresent := err.(ErrTableNotPresent); isNotPresent {
+			// tables which are not present in a sparse clone are unchanged, so their keys are still valid
+			notPresent[tableName] = true
+			continue
+		} else if err != nil {
 			return nil, err
 		}
 		if !ok {
@@ -994,11 +1084,17 @@ func (root *RootValue) ValidateForeignKeysOnSchemas(ctx context.Context) (*RootV
 	// some of these checks are sanity checks and should never happen
 	allForeignKeys := fkCollection.AllKeys()
 	for _, foreignKey := range allForeignKeys {
+		if notPresent[foreignKey.TableName] {
+			continue
+		}
 		tblSch, existsInRoot := allTablesSet[foreignKey.TableName]
 		if existsInRoot {
 			if err := foreignKey.ValidateTableSchema(tblSch); err != nil {
 				return nil, err
 			}
+			if notPresent[foreignKey.ReferencedTableName] {
+				continue
+			}
 			parentSch, existsInRoot := allTablesSet[foreignKey.ReferencedTableName]
 			if !existsInRoot {
 				return nil, fmt.Errorf("foreign key `%s` requires the referenced table `%s`", foreignKey.Name, foreignKey.ReferencedTableName)
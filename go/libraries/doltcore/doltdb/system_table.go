@@ -134,9 +134,13 @@ var generatedSystemTables = []string{
 	BranchesTableName,
 	LogTableName,
 	TableOfTablesInConflictName,
+	SchemaConflictsTableName,
 	CommitsTableName,
 	CommitAncestorsTableName,
 	StatusTableName,
+	DiffSummaryTableName,
+	SchemaDiffTableName,
+	ReplicationStatusTableName,
 }
 
 var generatedSystemTablePrefixes = []string{
@@ -144,6 +148,7 @@ var generatedSystemTablePrefixes = []string{
 	DoltCommitDiffTablePrefix,
 	DoltHistoryTablePrefix,
 	DoltConfTablePrefix,
+	DoltBlameTablePrefix,
 }
 
 const (
@@ -200,6 +205,8 @@ const (
 	DoltCommitDiffTablePrefix = "dolt_commit_diff_"
 	// DoltConfTablePrefix is the prefix assigned to all the generated conflict tables
 	DoltConfTablePrefix = "dolt_conflicts_"
+	// DoltBlameTablePrefix is the prefix assigned to all the generated blame tables
+	DoltBlameTablePrefix = "dolt_blame_"
 )
 
 const (
@@ -209,6 +216,9 @@ const (
 	// TableOfTablesInConflictName is the conflicts system table name
 	TableOfTablesInConflictName = "dolt_conflicts"
 
+	// SchemaConflictsTableName is the schema conflicts system table name
+	SchemaConflictsTableName = "dolt_schema_conflicts"
+
 	// BranchesTableName is the branches system table name
 	BranchesTableName = "dolt_branches"
 
@@ -220,6 +230,15 @@ const (
 
 	// StatusTableName is the status system table name.
 	StatusTableName = "dolt_status"
+
+	// DiffSummaryTableName is the diff summary system table name.
+	DiffSummaryTableName = "dolt_diff_summary"
+
+	// SchemaDiffTableName is the schema diff system table name.
+	SchemaDiffTableName = "dolt_schema_diff"
+
+	// ReplicationStatusTableName is the replication status system table name.
+	ReplicationStatusTableName = "dolt_replication_status"
 )
 
 const (
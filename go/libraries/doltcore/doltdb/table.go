@@ -33,6 +33,7 @@ const (
 	tableRowsKey       = "rows"
 	conflictsKey       = "conflicts"
 	conflictSchemasKey = "conflict_schemas"
+	schemaConflictsKey = "schema_conflicts"
 	indexesKey         = "indexes"
 	autoIncrementKey   = "auto_increment"
 
@@ -219,6 +220,90 @@ func (t *Table) ClearConflicts() (*Table, error) {
 	return &Table{t.vrw, tSt}, nil
 }
 
+// SetSchemaConflict records that the schemas of |ours| and |theirs| could not be merged. The merge base, our and their
+// versions of the table are stored in full so that the conflict can later be resolved in favor of either side.
+func (t *Table) SetSchemaConflict(ctx context.Context, base, ours, theirs *Table) (*Table, error) {
+	refs := make([]types.Value, 3)
+	for i, tbl := range []*Table{base, ours, theirs} {
+		tblRef, err := WriteValAndGetRef(ctx, t.vrw, tbl.tableStruct)
+
+		if err != nil {
+			return nil, err
+		}
+
+		refs[i] = tblRef
+	}
+
+	tpl, err := NewConflict(refs[0], refs[1], refs[2]).ToNomsList(t.vrw)
+
+	if err != nil {
+		return nil, err
+	}
+
+	updatedSt, err := t.tableStruct.Set(schemaConflictsKey, tpl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Table{t.vrw, updatedSt}, nil
+}
+
+// GetSchemaConflict returns the merge base, our and their versions of a table with a schema conflict, or
+// ErrNoConflicts if the table does not have one.
+func (t *Table) GetSchemaConflict(ctx context.Context) (base, ours, theirs *Table, err error) {
+	tplVal, ok, err := t.tableStruct.MaybeGet(schemaConflictsKey)
+
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if !ok {
+		return nil, nil, nil, ErrNoConflicts
+	}
+
+	cnf, err := ConflictFromTuple(tplVal.(types.Tuple))
+
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	tbls := make([]*Table, 3)
+	for i, v := range []types.Value{cnf.Base, cnf.Value, cnf.MergeValue} {
+		tblVal, err := v.(types.Ref).TargetValue(ctx, t.vrw)
+
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		tbls[i] = &Table{t.vrw, tblVal.(types.Struct)}
+	}
+
+	return tbls[0], tbls[1], tbls[2], nil
+}
+
+// HasSchemaConflict returns whether the schema of this table conflicted during a merge.
+func (t *Table) HasSchemaConflict() (bool, error) {
+	if t == nil {
+		return false, nil
+	}
+
+	_, ok, err := t.tableStruct.MaybeGet(schemaConflictsKey)
+
+	return ok, err
+}
+
+// ClearSchemaConflict removes the schema conflict of this table, marking it resolved.
+func (t *Table) ClearSchemaConflict() (*Table, error) {
+	tSt, err := t.tableStruct.Delete(schemaConflictsKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Table{t.vrw, tSt}, nil
+}
+
 func (t *Table) GetConflictSchemas(ctx context.Context) (base, sch, mergeSch schema.Schema, err error) {
 	schemasVal, ok, err := t.tableStruct.MaybeGet(conflictSchemasKey)
 
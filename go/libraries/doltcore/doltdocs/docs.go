@@ -248,3 +248,34 @@ func UpdateRootWithDocs(ctx context.Context, root *doltdb.RootValue, docs Docs)
 
 	return root, nil
 }
+
+// ResolveDocConflicts removes the merge conflicts of the docs given from the dolt_docs table of the root value. Docs
+// are resolved by writing them to the table, so their conflicts are no longer needed.
+func ResolveDocConflicts(ctx context.Context, root *doltdb.RootValue, docs Docs) (*doltdb.RootValue, error) {
+	docTbl, found, err := root.GetTable(ctx, doltdb.DocTableName)
+	if err != nil || !found {
+		return root, err
+	}
+
+	if has, err := docTbl.HasConflicts(); err != nil || !has {
+		return root, err
+	}
+
+	keys := make([]types.Value, len(docs))
+	for i, doc := range docs {
+		keys[i], err = docTblKeyFromName(docTbl.Format(), doc.DocPk)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, _, resolved, err := docTbl.ResolveConflicts(ctx, keys)
+	if err != nil {
+		return nil, err
+	} else if resolved == nil {
+		// none of the docs were in conflict
+		return root, nil
+	}
+
+	return root.PutTable(ctx, doltdb.DocTableName, resolved)
+}
@@ -0,0 +1,138 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/store/datas"
+)
+
+var ErrNotABackup = errors.New("the database is not a dolt backup")
+
+// backupRepoStateRef is the ref of the commit which holds the repo state of the repository in a backup. The root of
+// the commit is the working root of the repository, its parent is the head of the checked out branch, and its
+// description is the repo state as json.
+var backupRepoStateRef = ref.NewInternalRef("backup")
+
+// SyncBackup brings the backup |backupDB| up to date with the repository of |dEnv|. Every table file of the
+// repository which the backup does not have yet is copied to it, which also copies every ref and the working and
+// staged roots. The repo state of the repository, including its remotes and branch configuration, is stored in the
+// backup as well.
+func SyncBackup(ctx context.Context, dEnv *env.DoltEnv, backupDB *doltdb.DoltDB, eventCh chan<- datas.TableFileEvent) error {
+	name, email, err := GetNameAndEmail(dEnv.Config)
+
+	if err != nil {
+		return err
+	}
+
+	repoState, err := json.Marshal(dEnv.RepoState)
+
+	if err != nil {
+		return err
+	}
+
+	meta, err := doltdb.NewCommitMeta(name, email, string(repoState))
+
+	if err != nil {
+		return err
+	}
+
+	head, err := dEnv.DoltDB.ResolveRef(ctx, dEnv.RepoState.CWBHeadRef())
+
+	if err != nil {
+		return err
+	}
+
+	err = dEnv.DoltDB.Clone(ctx, backupDB, eventCh)
+
+	if err != nil {
+		return err
+	}
+
+	cm, err := backupDB.CommitDanglingWithParentCommits(ctx, dEnv.RepoState.WorkingHash(), []*doltdb.Commit{head}, meta)
+
+	if err != nil {
+		return err
+	}
+
+	return backupDB.SetHeadToCommit(ctx, backupRepoStateRef, cm)
+}
+
+// RestoreBackup restores the backup |backupDB| to the repository of |dEnv|, which must not have any data. The repo
+// state of the repository is set to the repo state stored in the backup.
+func RestoreBackup(ctx context.Context, backupDB *doltdb.DoltDB, dEnv *env.DoltEnv, eventCh chan<- datas.TableFileEvent) error {
+	err := backupDB.Clone(ctx, dEnv.DoltDB, eventCh)
+
+	if err != nil {
+		return err
+	}
+
+	ok, err := dEnv.DoltDB.HasRef(ctx, backupRepoStateRef)
+
+	if err != nil {
+		return err
+	} else if !ok {
+		return ErrNotABackup
+	}
+
+	cm, err := dEnv.DoltDB.ResolveRef(ctx, backupRepoStateRef)
+
+	if err != nil {
+		return err
+	}
+
+	meta, err := cm.GetCommitMeta()
+
+	if err != nil {
+		return err
+	}
+
+	var repoState env.RepoState
+	err = json.Unmarshal([]byte(meta.Description), &repoState)
+
+	if err != nil {
+		return err
+	}
+
+	// the repo state is only needed in the backup
+	err = dEnv.DoltDB.DeleteBranch(ctx, backupRepoStateRef)
+
+	if err != nil {
+		return err
+	}
+
+	err = repoState.Save(dEnv.FS)
+
+	if err != nil {
+		return err
+	}
+
+	dEnv.RepoState = &repoState
+	dEnv.RSLoadErr = nil
+
+	working, err := dEnv.WorkingRoot(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return SaveDocsFromRoot(ctx, working, dEnv)
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/utils/set"
 	"github.com/dolthub/dolt/go/store/hash"
@@ -450,3 +451,85 @@ func MaybeGetCommit(ctx context.Context, dEnv *env.DoltEnv, str string) (*doltdb
 
 	return nil, nil
 }
+
+// UpstreamStatus is how a branch compares to the remote tracking branch of its upstream.
+type UpstreamStatus struct {
+	// RemoteRef is the remote tracking branch of the upstream, e.g. remotes/origin/master
+	RemoteRef ref.RemoteRef
+	// Ahead is the number of commits on the branch which are not on the upstream
+	Ahead int
+	// Behind is the number of commits on the upstream which are not on the branch
+	Behind int
+}
+
+// GetUpstreamStatus compares the branch |brName| to the upstream configured for it in |branches|. It returns nil if
+// the branch has no upstream, or if the remote tracking branch of the upstream does not exist.
+func GetUpstreamStatus(ctx context.Context, ddb *doltdb.DoltDB, branches map[string]env.BranchConfig, brName string) (*UpstreamStatus, error) {
+	upstream, ok := branches[brName]
+	if !ok || upstream.Merge.Ref == nil {
+		return nil, nil
+	}
+
+	remoteRef := ref.NewRemoteRef(upstream.Remote, upstream.Merge.Ref.GetPath())
+	if has, err := ddb.HasRef(ctx, remoteRef); err != nil || !has {
+		return nil, err
+	}
+
+	cm, err := ddb.ResolveRef(ctx, ref.NewBranchRef(brName))
+	if err != nil {
+		return nil, err
+	}
+
+	remoteCm, err := ddb.ResolveRef(ctx, remoteRef)
+	if err != nil {
+		return nil, err
+	}
+
+	ahead, behind, err := AheadBehind(ctx, ddb, cm, remoteCm)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpstreamStatus{RemoteRef: remoteRef, Ahead: ahead, Behind: behind}, nil
+}
+
+// AheadBehind returns the number of commits reachable from |cm| but not |other|, and the number of commits reachable
+// from |other| but not |cm|.
+func AheadBehind(ctx context.Context, ddb *doltdb.DoltDB, cm, other *doltdb.Commit) (ahead, behind int, err error) {
+	h, err := cm.HashOf()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	otherH, err := other.HashOf()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if h == otherH {
+		return 0, 0, nil
+	}
+
+	// commits before the merge base are reachable from both, so the walks stop there
+	mergeBase, err := doltdb.GetCommitAncestor(ctx, cm, other)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	mergeBaseH, err := mergeBase.HashOf()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	ahead, err = commitwalk.CountDotDotRevisions(ctx, ddb, h, mergeBaseH)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	behind, err = commitwalk.CountDotDotRevisions(ctx, ddb, otherH, mergeBaseH)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return ahead, behind, nil
+}
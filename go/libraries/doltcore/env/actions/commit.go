@@ -107,6 +107,11 @@ func CommitStaged(ctx context.Context, dbData env.DbData, props CommitStagedProp
 		if err != nil {
 			return "", err
 		}
+		schInConflict, err := root.TablesWithSchemaConflicts(ctx)
+		if err != nil {
+			return "", err
+		}
+		inConflict = append(inConflict, schInConflict...)
 		if len(inConflict) > 0 {
 			return "", NewTblInConflictError(inConflict)
 		}
@@ -338,7 +343,9 @@ func AddCommits(ctx context.Context, ddb *doltdb.DoltDB, commit *doltdb.Commit,
 	for i := 0; i < numParents && len(hashToCommit) != n; i++ {
 		parentCommit, err := ddb.ResolveParent(ctx, commit, i)
 
-		if err != nil {
+		if err == doltdb.ErrShallowBoundary {
+			continue
+		} else if err != nil {
 			return err
 		}
 
@@ -134,6 +134,27 @@ func (q *q) Get(ctx context.Context, ddb *doltdb.DoltDB, id hash.Hash) (*c, erro
 	return c, nil
 }
 
+// presentParents returns the hashes of the parents of |c| which are in its database. The parents of the shallow commits
+// of a repository with a shallow history are not, and walks of the history stop at those commits.
+func presentParents(ctx context.Context, c *c) ([]hash.Hash, error) {
+	parents, err := c.commit.ParentHashes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	present := make([]hash.Hash, 0, len(parents))
+	for _, h := range parents {
+		v, err := c.ddb.ValueReadWriter().ReadValue(ctx, h)
+		if err != nil {
+			return nil, err
+		}
+		if v != nil {
+			present = append(present, h)
+		}
+	}
+	return present, nil
+}
+
 func newQueue() *q {
 	return &q{loaded: make(map[hash.Hash]*c)}
 }
@@ -149,40 +170,70 @@ func newQueue() *q {
 // Roughly mimics `git log master..feature`.
 func GetDotDotRevisions(ctx context.Context, includedDB *doltdb.DoltDB, includedHead hash.Hash, excludedDB *doltdb.DoltDB, excludedHead hash.Hash, num int) ([]*doltdb.Commit, error) {
 	commitList := make([]*doltdb.Commit, 0, num)
+	err := walkDotDotRevisions(ctx, includedDB, includedHead, excludedDB, excludedHead, func(cm *doltdb.Commit) bool {
+		commitList = append(commitList, cm)
+		return len(commitList) == num
+	})
+	if err != nil {
+		return nil, err
+	}
+	return commitList, nil
+}
+
+// CountDotDotRevisions returns the number of commits reachable from the commit
+// at hash `includedHead` that are not reachable from hash `excludedHead`.
+// Both must be commits in `ddb`.
+//
+// Roughly mimics `git rev-list --count master..feature`.
+func CountDotDotRevisions(ctx context.Context, ddb *doltdb.DoltDB, includedHead hash.Hash, excludedHead hash.Hash) (int, error) {
+	count := 0
+	err := walkDotDotRevisions(ctx, ddb, includedHead, ddb, excludedHead, func(*doltdb.Commit) bool {
+		count++
+		return false
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// walkDotDotRevisions calls `cb` with each commit reachable from
+// `includedHead` that is not reachable from `excludedHead`, in the order
+// described for GetDotDotRevisions, until `cb` returns true.
+func walkDotDotRevisions(ctx context.Context, includedDB *doltdb.DoltDB, includedHead hash.Hash, excludedDB *doltdb.DoltDB, excludedHead hash.Hash, cb func(*doltdb.Commit) (stop bool)) error {
 	q := newQueue()
 	if err := q.SetInvisible(ctx, excludedDB, excludedHead); err != nil {
-		return nil, err
+		return err
 	}
 	if err := q.AddPendingIfUnseen(ctx, excludedDB, excludedHead); err != nil {
-		return nil, err
+		return err
 	}
 	if err := q.AddPendingIfUnseen(ctx, includedDB, includedHead); err != nil {
-		return nil, err
+		return err
 	}
 	for q.NumVisiblePending() > 0 {
 		nextC := q.PopPending()
-		parents, err := nextC.commit.ParentHashes(ctx)
+		parents, err := presentParents(ctx, nextC)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		for _, parentID := range parents {
 			if nextC.invisible {
 				if err := q.SetInvisible(ctx, nextC.ddb, parentID); err != nil {
-					return nil, err
+					return err
 				}
 			}
 			if err := q.AddPendingIfUnseen(ctx, nextC.ddb, parentID); err != nil {
-				return nil, err
+				return err
 			}
 		}
 		if !nextC.invisible {
-			commitList = append(commitList, nextC.commit)
-			if len(commitList) == num {
-				return commitList, nil
+			if cb(nextC.commit) {
+				return nil
 			}
 		}
 	}
-	return commitList, nil
+	return nil
 }
 
 // GetTopologicalOrderCommits returns the commits reachable from the commit at hash `startCommitHash`
@@ -224,7 +275,7 @@ func newCommiterator(ctx context.Context, ddb *doltdb.DoltDB, startCommitHash ha
 func (i *commiterator) Next(ctx context.Context) (hash.Hash, *doltdb.Commit, error) {
 	if i.q.NumVisiblePending() > 0 {
 		nextC := i.q.PopPending()
-		parents, err := nextC.commit.ParentHashes(ctx)
+		parents, err := presentParents(ctx, nextC)
 		if err != nil {
 			return hash.Hash{}, nil, err
 		}
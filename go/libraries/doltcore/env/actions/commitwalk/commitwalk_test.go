@@ -142,6 +142,18 @@ func TestGetDotDotRevisions(t *testing.T) {
 	assertEqualHashes(t, featureCommits[2], res[1])
 	assertEqualHashes(t, featureCommits[1], res[2])
 
+	count, err := CountDotDotRevisions(context.Background(), env.DoltDB, featureHash, masterHash)
+	require.NoError(t, err)
+	assert.Equal(t, 7, count)
+
+	count, err = CountDotDotRevisions(context.Background(), env.DoltDB, mustGetHash(t, masterCommits[9]), featureHash)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	count, err = CountDotDotRevisions(context.Background(), env.DoltDB, masterHash, featureHash)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
 	// Create a similar branch to "feature" on a forked repository and GetDotDotRevisions using that as well.
 	forkEnv := mustForkDB(t, env.DoltDB, "feature", featureCommits[4])
 
@@ -195,6 +207,50 @@ func TestGetDotDotRevisions(t *testing.T) {
 	assertEqualHashes(t, featureCommits[1], res[2])
 }
 
+func TestShallowHistory(t *testing.T) {
+	env := createUninitializedEnv()
+	err := env.InitRepo(context.Background(), types.Format_LD_1, "Bill Billerson", "bill@billerson.com")
+	require.NoError(t, err)
+
+	cs, err := doltdb.NewCommitSpec("master")
+	require.NoError(t, err)
+	commit, err := env.DoltDB.Resolve(context.Background(), cs, nil)
+	require.NoError(t, err)
+
+	rv, err := commit.GetRootValue()
+	require.NoError(t, err)
+	rvh, err := env.DoltDB.WriteRootValue(context.Background(), rv)
+	require.NoError(t, err)
+
+	// Create 5 commits on master.
+	masterCommits := make([]*doltdb.Commit, 6)
+	masterCommits[0] = commit
+	for i := 1; i < 6; i++ {
+		masterCommits[i] = mustCreateCommit(t, env.DoltDB, "master", rvh, masterCommits[i-1])
+	}
+
+	// Pull the last 3 commits into a fork, leaving out the history below them.
+	stref, err := masterCommits[5].GetStRef()
+	require.NoError(t, err)
+	forkEnv := createUninitializedEnv()
+	err = forkEnv.InitRepo(context.Background(), types.Format_LD_1, "Bill Billerson", "bill@billerson.com")
+	require.NoError(t, err)
+	skip := hash.NewHashSet(mustGetHash(t, masterCommits[2]))
+	err = forkEnv.DoltDB.PullChunksSkipping(context.Background(), "", env.DoltDB, stref, skip, nil, nil)
+	require.NoError(t, err)
+
+	res, err := GetTopologicalOrderCommits(context.Background(), forkEnv.DoltDB, mustGetHash(t, masterCommits[5]))
+	require.NoError(t, err)
+	assert.Len(t, res, 3)
+	assertEqualHashes(t, masterCommits[5], res[0])
+	assertEqualHashes(t, masterCommits[4], res[1])
+	assertEqualHashes(t, masterCommits[3], res[2])
+
+	count, err := CountDotDotRevisions(context.Background(), forkEnv.DoltDB, mustGetHash(t, masterCommits[5]), mustGetHash(t, masterCommits[4]))
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
 func assertEqualHashes(t *testing.T, lc, rc *doltdb.Commit) {
 	assert.Equal(t, mustGetHash(t, lc), mustGetHash(t, rc))
 }
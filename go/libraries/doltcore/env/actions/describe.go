@@ -0,0 +1,123 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions/commitwalk"
+)
+
+// DirtySuffix is appended to the description of HEAD when the working set has changes
+const DirtySuffix = "-dirty"
+
+var ErrNoTagsToDescribe = errors.New("no tags can describe this commit")
+var ErrNoAnnotatedTagsToDescribe = errors.New("no annotated tags can describe this commit, but there are lightweight tags. Try --tags")
+
+type DescribeOpts struct {
+	// AllTags allows tags without a message to be used. By default only annotated tags, which have a message, are used.
+	AllTags bool
+	// Long always uses the long format, even when the commit is tagged.
+	Long bool
+}
+
+// Describe names |cm| after the nearest tag that is reachable from it. If the commit is tagged the name is the name of
+// the tag, otherwise it is "<tag>-<n>-g<hash>", where n is the number of commits in the history of |cm| that are not in
+// the history of the tag. When several tags are equally near, the newest is used.
+func Describe(ctx context.Context, ddb *doltdb.DoltDB, cm *doltdb.Commit, opts DescribeOpts) (string, error) {
+	cmHash, err := cm.HashOf()
+
+	if err != nil {
+		return "", err
+	}
+
+	var best *doltdb.Tag
+	bestDistance := -1
+	skippedLightweight := false
+	err = IterResolvedTags(ctx, ddb, func(tag *doltdb.Tag) (bool, error) {
+		if !opts.AllTags && tag.Meta.Description == "" {
+			skippedLightweight = true
+			return false, nil
+		}
+
+		isAncestor, err := doltdb.IsAncestor(ctx, tag.Commit, cm)
+
+		if err != nil || !isAncestor {
+			return false, err
+		}
+
+		tagHash, err := tag.Commit.HashOf()
+
+		if err != nil {
+			return false, err
+		}
+
+		distance, err := commitwalk.CountDotDotRevisions(ctx, ddb, cmHash, tagHash)
+
+		if err != nil {
+			return false, err
+		}
+
+		// tags are visited newest first, so only a strictly nearer tag replaces the current one
+		if best == nil || distance < bestDistance {
+			best, bestDistance = tag, distance
+		}
+
+		return bestDistance == 0, nil
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	if best == nil {
+		if skippedLightweight {
+			return "", ErrNoAnnotatedTagsToDescribe
+		}
+		return "", ErrNoTagsToDescribe
+	}
+
+	if bestDistance == 0 && !opts.Long {
+		return best.Name, nil
+	}
+
+	return fmt.Sprintf("%s-%d-g%s", best.Name, bestDistance, cmHash.String()), nil
+}
+
+// IsWorkingSetDirty returns whether |working| differs from the root value of |head|.
+func IsWorkingSetDirty(working *doltdb.RootValue, head *doltdb.Commit) (bool, error) {
+	headRoot, err := head.GetRootValue()
+
+	if err != nil {
+		return false, err
+	}
+
+	headHash, err := headRoot.HashOf()
+
+	if err != nil {
+		return false, err
+	}
+
+	workingHash, err := working.HashOf()
+
+	if err != nil {
+		return false, err
+	}
+
+	return headHash != workingHash, nil
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
 )
 
 var ErrCantFF = errors.New("can't fast forward merge")
@@ -120,13 +121,33 @@ func DeleteRemoteBranch(ctx context.Context, targetRef ref.BranchRef, remoteRef
 
 // FetchCommit takes a fetches a commit and all underlying data from a remote source database to the local destination database.
 func FetchCommit(ctx context.Context, dEnv *env.DoltEnv, srcDB, destDB *doltdb.DoltDB, srcDBCommit *doltdb.Commit, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
+	return fetchCommit(ctx, dEnv, srcDB, destDB, srcDBCommit, nil, progChan, pullerEventCh)
+}
+
+// fetchCommit fetches a commit like FetchCommit, except that the values |skip| are not fetched. If the repository of
+// |dEnv| is sparse, the tables which are not present in it are not fetched either.
+func fetchCommit(ctx context.Context, dEnv *env.DoltEnv, srcDB, destDB *doltdb.DoltDB, srcDBCommit *doltdb.Commit, skip hash.HashSet, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
 	stRef, err := srcDBCommit.GetStRef()
 
 	if err != nil {
 		return err
 	}
 
-	return destDB.PullChunks(ctx, dEnv.TempTableFilesDir(), srcDB, stRef, progChan, pullerEventCh)
+	if dEnv.RepoState.IsSparse() {
+		tblSkip, err := sparseSkip(ctx, srcDB, destDB, srcDBCommit, dEnv.RepoState.Sparse, skip)
+
+		if err != nil {
+			return err
+		}
+
+		for h := range skip {
+			tblSkip.Insert(h)
+		}
+
+		skip = tblSkip
+	}
+
+	return destDB.PullChunksSkipping(ctx, dEnv.TempTableFilesDir(), srcDB, stRef, skip, progChan, pullerEventCh)
 }
 
 // FetchCommit takes a fetches a commit tag and all underlying data from a remote source database to the local destination database.
@@ -26,7 +26,7 @@ import (
 type AutoResolveStats struct {
 }
 
-func AutoResolveAll(ctx context.Context, dEnv *env.DoltEnv, autoResolver merge.AutoResolver) error {
+func AutoResolveAll(ctx context.Context, dEnv *env.DoltEnv, autoResolver merge.AutoResolver, schResolver merge.SchemaConflictResolver) error {
 	root, err := dEnv.WorkingRoot(ctx)
 
 	if err != nil {
@@ -39,20 +39,28 @@ func AutoResolveAll(ctx context.Context, dEnv *env.DoltEnv, autoResolver merge.A
 		return err
 	}
 
-	return autoResolve(ctx, dEnv, root, autoResolver, tbls)
+	schTbls, err := root.TablesWithSchemaConflicts(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return autoResolve(ctx, dEnv, root, autoResolver, schResolver, append(schTbls, tbls...))
 }
 
-func AutoResolveTables(ctx context.Context, dEnv *env.DoltEnv, autoResolver merge.AutoResolver, tbls []string) error {
+func AutoResolveTables(ctx context.Context, dEnv *env.DoltEnv, autoResolver merge.AutoResolver, schResolver merge.SchemaConflictResolver, tbls []string) error {
 	root, err := dEnv.WorkingRoot(ctx)
 
 	if err != nil {
 		return err
 	}
 
-	return autoResolve(ctx, dEnv, root, autoResolver, tbls)
+	return autoResolve(ctx, dEnv, root, autoResolver, schResolver, tbls)
 }
 
-func autoResolve(ctx context.Context, dEnv *env.DoltEnv, root *doltdb.RootValue, autoResolver merge.AutoResolver, tbls []string) error {
+// autoResolve resolves the conflicts of each of |tbls|. A table with a schema conflict is resolved using |schResolver|,
+// as its rows have not been merged. Any other table has its row conflicts resolved using |autoResolver|.
+func autoResolve(ctx context.Context, dEnv *env.DoltEnv, root *doltdb.RootValue, autoResolver merge.AutoResolver, schResolver merge.SchemaConflictResolver, tbls []string) error {
 	tableEditSession := editor.CreateTableEditSession(root, editor.TableEditSessionProps{})
 
 	for _, tblName := range tbls {
@@ -66,7 +74,17 @@ func autoResolve(ctx context.Context, dEnv *env.DoltEnv, root *doltdb.RootValue,
 			return doltdb.ErrTableNotFound
 		}
 
-		err = merge.ResolveTable(ctx, root.VRW(), tblName, tbl, autoResolver, tableEditSession)
+		hasSchConflict, err := tbl.HasSchemaConflict()
+
+		if err != nil {
+			return err
+		}
+
+		if hasSchConflict {
+			err = merge.ResolveSchemaConflict(ctx, tblName, tbl, schResolver, tableEditSession)
+		} else {
+			err = merge.ResolveTable(ctx, root.VRW(), tblName, tbl, autoResolver, tableEditSession)
+		}
 
 		if err != nil {
 			return err
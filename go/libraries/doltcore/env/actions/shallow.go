@@ -0,0 +1,254 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/store/datas"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+var ErrInvalidDepth = errors.New("depth must be a positive number")
+
+// FetchCommitShallow fetches a commit from a remote source database to the local destination database along with the
+// commits of its history which are less than |depth| commits away from it. The parents of the oldest of these commits
+// are not fetched, and those commits are recorded as shallow in the repo state of |dEnv|.
+func FetchCommitShallow(ctx context.Context, dEnv *env.DoltEnv, srcDB, destDB *doltdb.DoltDB, srcDBCommit *doltdb.Commit, depth int, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
+	if depth <= 0 {
+		return ErrInvalidDepth
+	}
+
+	shallow, boundary, err := shallowBoundary(ctx, srcDB, destDB, srcDBCommit, depth)
+
+	if err != nil {
+		return err
+	}
+
+	// the parents of the boundary commits are not fetched
+	destDB.SetEnforceCompleteness(false)
+
+	err = fetchCommit(ctx, dEnv, srcDB, destDB, srcDBCommit, boundary, progChan, pullerEventCh)
+
+	if err != nil {
+		return err
+	}
+
+	return updateShallow(ctx, dEnv, destDB, shallow)
+}
+
+// FetchUnshallow fetches the history which is missing below the shallow commits of the repository of |dEnv| from a
+// remote source database to the local destination database, after which the repository is no longer shallow.
+func FetchUnshallow(ctx context.Context, dEnv *env.DoltEnv, srcDB, destDB *doltdb.DoltDB, progChan chan datas.PullProgress, pullerEventCh chan datas.PullerEvent) error {
+	for _, hStr := range dEnv.RepoState.Shallow {
+		h, ok := hash.MaybeParse(hStr)
+
+		if !ok {
+			return fmt.Errorf("invalid shallow commit hash '%s'", hStr)
+		}
+
+		cm, err := resolveHash(ctx, destDB, h)
+
+		if err != nil {
+			return err
+		}
+
+		parents, err := missingParents(ctx, destDB, cm)
+
+		if err != nil {
+			return err
+		}
+
+		for _, parent := range parents {
+			srcDBParent, err := resolveHash(ctx, srcDB, parent)
+
+			if err == doltdb.ErrHashNotFound {
+				return fmt.Errorf("unable to find the commit %s in the remote", parent.String())
+			} else if err != nil {
+				return err
+			}
+
+			err = FetchCommit(ctx, dEnv, srcDB, destDB, srcDBParent, progChan, pullerEventCh)
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return updateShallow(ctx, dEnv, destDB, nil)
+}
+
+// shallowBoundary walks the history of |cm| in |srcDB| for |depth| commits. It returns the commits of the walk whose
+// parents are not all part of it, and the parents which are not. Commits which |destDB| already has end the walk, as
+// their history does not need to be fetched.
+func shallowBoundary(ctx context.Context, srcDB, destDB *doltdb.DoltDB, cm *doltdb.Commit, depth int) ([]hash.Hash, hash.HashSet, error) {
+	h, err := cm.HashOf()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	window := hash.NewHashSet(h)
+	level := []*doltdb.Commit{cm}
+	for i := 1; i < depth && len(level) > 0; i++ {
+		var next []*doltdb.Commit
+		for _, cm := range level {
+			parents, err := srcDB.ResolveAllParents(ctx, cm)
+
+			if err != nil {
+				return nil, nil, err
+			}
+
+			for _, parent := range parents {
+				ph, err := parent.HashOf()
+
+				if err != nil {
+					return nil, nil, err
+				}
+
+				if window.Has(ph) {
+					continue
+				}
+
+				window.Insert(ph)
+
+				if ok, err := hasValue(ctx, destDB, ph); err != nil {
+					return nil, nil, err
+				} else if !ok {
+					next = append(next, parent)
+				}
+			}
+		}
+
+		level = next
+	}
+
+	var shallow []hash.Hash
+	boundary := hash.HashSet{}
+	for _, cm := range level {
+		h, err := cm.HashOf()
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		parents, err := cm.ParentHashes(ctx)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		isShallow := false
+		for _, ph := range parents {
+			if !window.Has(ph) {
+				boundary.Insert(ph)
+				isShallow = true
+			}
+		}
+
+		if isShallow {
+			shallow = append(shallow, h)
+		}
+	}
+
+	return shallow, boundary, nil
+}
+
+// updateShallow records the commits |candidates| as shallow in the repo state of |dEnv| in addition to the commits
+// which already are. Only the commits which are missing a parent in |ddb| are kept, and are recorded as the shallow
+// commits of |ddb|.
+func updateShallow(ctx context.Context, dEnv *env.DoltEnv, ddb *doltdb.DoltDB, candidates []hash.Hash) error {
+	seen := dEnv.RepoState.ShallowCommits()
+	for _, h := range candidates {
+		seen.Insert(h)
+	}
+
+	var shallow []string
+	commits := hash.HashSet{}
+	for h := range seen {
+		cm, err := resolveHash(ctx, ddb, h)
+
+		if err == doltdb.ErrHashNotFound {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		parents, err := missingParents(ctx, ddb, cm)
+
+		if err != nil {
+			return err
+		}
+
+		if len(parents) > 0 {
+			shallow = append(shallow, h.String())
+			commits.Insert(h)
+		}
+	}
+
+	sort.Strings(shallow)
+	dEnv.RepoState.Shallow = shallow
+	ddb.SetShallow(commits)
+
+	return dEnv.RepoState.Save(dEnv.FS)
+}
+
+// missingParents returns the hashes of the parents of |cm| which |ddb| does not have.
+func missingParents(ctx context.Context, ddb *doltdb.DoltDB, cm *doltdb.Commit) ([]hash.Hash, error) {
+	parents, err := cm.ParentHashes(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []hash.Hash
+	for _, h := range parents {
+		ok, err := hasValue(ctx, ddb, h)
+
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			missing = append(missing, h)
+		}
+	}
+
+	return missing, nil
+}
+
+func hasValue(ctx context.Context, ddb *doltdb.DoltDB, h hash.Hash) (bool, error) {
+	v, err := ddb.ValueReadWriter().ReadValue(ctx, h)
+
+	if err != nil {
+		return false, err
+	}
+
+	return v != nil, nil
+}
+
+func resolveHash(ctx context.Context, ddb *doltdb.DoltDB, h hash.Hash) (*doltdb.Commit, error) {
+	cs, err := doltdb.NewCommitSpec(h.String())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ddb.Resolve(ctx, cs, nil)
+}
@@ -0,0 +1,102 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package actions
+
+import (
+	"context"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/utils/set"
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// sparseSkip walks the commits of the history of |cm| in |srcDB| which |destDB| does not have yet, and returns the
+// hashes of the tables of those commits which are not in |tables|. The dolt system tables are always kept. The walk
+// does not descend below the commits |stop|, or below commits whose parents |srcDB| does not have.
+func sparseSkip(ctx context.Context, srcDB, destDB *doltdb.DoltDB, cm *doltdb.Commit, tables []string, stop hash.HashSet) (hash.HashSet, error) {
+	tblSet := set.NewCaseInsensitiveStrSet(tables)
+
+	skip := hash.HashSet{}
+	keep := hash.HashSet{}
+	seen := hash.HashSet{}
+	pending := []*doltdb.Commit{cm}
+	for len(pending) > 0 {
+		cm := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		root, err := cm.GetRootValue()
+
+		if err != nil {
+			return nil, err
+		}
+
+		names, err := root.GetTableNames(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, name := range names {
+			h, _, err := root.GetTableHash(ctx, name)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if tblSet.Contains(name) || doltdb.HasDoltPrefix(name) {
+				keep.Insert(h)
+			} else {
+				skip.Insert(h)
+			}
+		}
+
+		parents, err := cm.ParentHashes(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, h := range parents {
+			if seen.Has(h) || stop.Has(h) {
+				continue
+			}
+
+			seen.Insert(h)
+
+			if ok, err := hasValue(ctx, destDB, h); err != nil {
+				return nil, err
+			} else if ok {
+				continue
+			}
+
+			parent, err := resolveHash(ctx, srcDB, h)
+
+			if err == doltdb.ErrHashNotFound {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+
+			pending = append(pending, parent)
+		}
+	}
+
+	// a table which is identical to a kept table has the same hash, and must be fetched
+	for h := range keep {
+		skip.Remove(h)
+	}
+
+	return skip, nil
+}
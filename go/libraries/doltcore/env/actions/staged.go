@@ -47,6 +47,11 @@ func StageTables(ctx context.Context, dbData env.DbData, tbls []string) error {
 		if err != nil {
 			return err
 		}
+
+		working, err = doltdocs.ResolveDocConflicts(ctx, working, docs)
+		if err != nil {
+			return err
+		}
 	}
 
 	err = stageTables(ctx, ddb, rsw, tables, staged, working)
@@ -132,8 +137,18 @@ func checkTablesForConflicts(ctx context.Context, tbls []string, working *doltdb
 	var inConflict []string
 	for _, tblName := range tbls {
 		tbl, _, err := working.GetTable(ctx, tblName)
-		if err != nil {
+		if _, ok := err.(doltdb.ErrTableNotPresent); ok {
+			// a table which is not present in a sparse clone cannot have been changed
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		if has, err := tbl.HasSchemaConflict(); err != nil {
 			return nil, err
+		} else if has {
+			inConflict = append(inConflict, tblName)
+			continue
 		}
 
 		has, err := tbl.HasConflicts()
@@ -47,6 +47,13 @@ const (
 	MetricsHost     = "metrics.host"
 	MetricsPort     = "metrics.port"
 	MetricsInsecure = "metrics.insecure"
+
+	// ReplicateToRemoteKey is the name of the remote which sql-server pushes the changes to the repository to
+	ReplicateToRemoteKey = "sqlserver.replicate_to_remote"
+
+	// ReadReplicaRemoteKey is the name of the remote which sql-server pulls the checked out branch of the repository
+	// from, serving the repository as a read replica
+	ReadReplicaRemoteKey = "sqlserver.read_replica_remote"
 )
 
 var LocalConfigWhitelist = set.NewStrSet([]string{UserNameKey, UserEmailKey})
@@ -122,6 +122,20 @@ func Load(ctx context.Context, hdp HomeDirProvider, fs filesys.Filesys, urlStr,
 		}
 	}
 
+	// the root values of a sparse clone reference tables which are not present, and the commits at the boundary of a
+	// shallow clone reference parents which are not present
+	if dbLoadErr == nil && rsErr == nil && (repoState.IsSparse() || repoState.IsShallow()) {
+		ddb.SetEnforceCompleteness(false)
+	}
+
+	if dbLoadErr == nil && rsErr == nil && repoState.IsShallow() {
+		ddb.SetShallow(repoState.ShallowCommits())
+	}
+
+	if dbLoadErr == nil && rsErr == nil && repoState.IsSparse() {
+		ddb.SetSparse(repoState.Sparse)
+	}
+
 	dbfactory.InitializeFactories(dEnv)
 
 	return dEnv
@@ -390,6 +404,10 @@ func (r *repoStateReader) GetPreMergeWorking() string {
 	return r.dEnv.RepoState.Merge.PreMergeWorking
 }
 
+func (r *repoStateReader) GetBranches() map[string]BranchConfig {
+	return r.dEnv.RepoState.Branches
+}
+
 func (dEnv *DoltEnv) RepoStateReader() RepoStateReader {
 	return &repoStateReader{dEnv}
 }
@@ -597,7 +615,12 @@ func (dEnv *DoltEnv) UserRPCCreds() (creds.DoltCreds, bool, error) {
 	return creds.EmptyCreds, false, nil
 }
 
+// getRPCCreds returns the credentials set in the environment, or else the user's dolt credentials if they have any.
 func (dEnv *DoltEnv) getRPCCreds() (credentials.PerRPCCredentials, error) {
+	if envCreds := creds.RPCCredsFromEnv(); envCreds != nil {
+		return envCreds, nil
+	}
+
 	dCreds, valid, err := dEnv.UserRPCCreds()
 	if err != nil {
 		return nil, ErrInvalidCredsFile
@@ -643,7 +666,12 @@ func (dEnv *DoltEnv) GetGRPCDialParams(config grpcendpoint.Config) (string, []gr
 	if config.Insecure {
 		opts = append(opts, grpc.WithInsecure())
 	} else {
-		tc := credentials.NewTLS(&tls.Config{})
+		tlsConfig := config.TLSConfig
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		}
+
+		tc := credentials.NewTLS(tlsConfig)
 		opts = append(opts, grpc.WithTransportCredentials(tc))
 	}
 
@@ -673,6 +701,14 @@ func (dEnv *DoltEnv) GetRemotes() (map[string]Remote, error) {
 	return dEnv.RepoState.Remotes, nil
 }
 
+func (dEnv *DoltEnv) GetBackups() (map[string]Remote, error) {
+	if dEnv.RSLoadErr != nil {
+		return nil, dEnv.RSLoadErr
+	}
+
+	return dEnv.RepoState.Backups, nil
+}
+
 var ErrNotACred = errors.New("not a valid credential key id or public key")
 
 func (dEnv *DoltEnv) FindCreds(credsDir, pubKeyOrId string) (string, error) {
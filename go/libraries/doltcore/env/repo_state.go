@@ -36,6 +36,7 @@ type RepoStateReader interface {
 	IsMergeActive() bool
 	GetMergeCommit() string
 	GetPreMergeWorking() string
+	GetBranches() map[string]BranchConfig
 }
 
 type RepoStateWriter interface {
@@ -73,12 +74,16 @@ type MergeState struct {
 }
 
 type RepoState struct {
-	Head     ref.MarshalableRef      `json:"head"`
-	Staged   string                  `json:"staged"`
-	Working  string                  `json:"working"`
-	Merge    *MergeState             `json:"merge"`
-	Remotes  map[string]Remote       `json:"remotes"`
-	Branches map[string]BranchConfig `json:"branches"`
+	Head            ref.MarshalableRef      `json:"head"`
+	Staged          string                  `json:"staged"`
+	Working         string                  `json:"working"`
+	Merge           *MergeState             `json:"merge"`
+	Remotes         map[string]Remote       `json:"remotes"`
+	Branches        map[string]BranchConfig `json:"branches"`
+	Backups         map[string]Remote       `json:"backups,omitempty"`
+	Shallow         []string                `json:"shallow,omitempty"`
+	Sparse          []string                `json:"sparse,omitempty"`
+	CloneInProgress bool                    `json:"clone_in_progress,omitempty"`
 }
 
 func LoadRepoState(fs filesys.ReadWriteFS) (*RepoState, error) {
@@ -109,6 +114,10 @@ func CloneRepoState(fs filesys.ReadWriteFS, r Remote) (*RepoState, error) {
 		nil,
 		map[string]Remote{r.Name: r},
 		make(map[string]BranchConfig),
+		make(map[string]Remote),
+		nil,
+		nil,
+		true,
 	}
 
 	err := rs.Save(fs)
@@ -135,6 +144,10 @@ func CreateRepoState(fs filesys.ReadWriteFS, br string, rootHash hash.Hash) (*Re
 		nil,
 		make(map[string]Remote),
 		make(map[string]BranchConfig),
+		make(map[string]Remote),
+		nil,
+		nil,
+		false,
 	}
 
 	err = rs.Save(fs)
@@ -186,6 +199,38 @@ func (rs *RepoState) AddRemote(r Remote) {
 	rs.Remotes[r.Name] = r
 }
 
+// IsShallow returns whether the history of the repository is cut off below some of its commits, which is the case
+// after a clone or fetch with a limited depth.
+func (rs *RepoState) IsShallow() bool {
+	return len(rs.Shallow) > 0
+}
+
+// ShallowCommits returns the commits at the boundary of a shallow repository, whose parents are not present.
+func (rs *RepoState) ShallowCommits() hash.HashSet {
+	commits := hash.HashSet{}
+	for _, hStr := range rs.Shallow {
+		if h, ok := hash.MaybeParse(hStr); ok {
+			commits.Insert(h)
+		}
+	}
+
+	return commits
+}
+
+// IsSparse returns whether only some of the tables of the repository are present locally, which is the case after a
+// clone with a list of tables. Sparse holds the names of the tables which are present.
+func (rs *RepoState) IsSparse() bool {
+	return len(rs.Sparse) > 0
+}
+
+func (rs *RepoState) AddBackup(r Remote) {
+	if rs.Backups == nil {
+		rs.Backups = make(map[string]Remote)
+	}
+
+	rs.Backups[r.Name] = r
+}
+
 func (rs *RepoState) WorkingHash() hash.Hash {
 	return hash.Parse(rs.Working)
 }
@@ -15,6 +15,8 @@
 package grpcendpoint
 
 import (
+	"crypto/tls"
+
 	"google.golang.org/grpc/credentials"
 )
 
@@ -23,4 +25,6 @@ type Config struct {
 	Insecure     bool
 	Creds        credentials.PerRPCCredentials
 	WithEnvCreds bool
+	// TLSConfig is used for secure connections. If nil, the system's certificate authorities are trusted.
+	TLSConfig *tls.Config
 }
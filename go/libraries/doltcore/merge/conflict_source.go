@@ -179,6 +179,19 @@ func (cr *ConflictReader) GetKeyForConflict(ctx context.Context, r row.Row) (typ
 	return nil, errors.New("could not determine key")
 }
 
+// GetOurs returns our version of the row from a conflict row, along with its schema. It returns false if the conflict
+// row has no values for our version of the row.
+func (cr *ConflictReader) GetOurs(r row.Row) (row.Row, schema.Schema, bool, error) {
+	rows, err := cr.joiner.Split(r)
+
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	ours, ok := rows[oursStr]
+	return ours, cr.joiner.SchemaForName(oursStr), ok, nil
+}
+
 // Close should release resources being held
 func (cr *ConflictReader) Close() error {
 	return nil
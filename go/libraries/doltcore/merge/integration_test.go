@@ -105,6 +105,85 @@ func TestMerge(t *testing.T) {
 				{"z"},
 			},
 		},
+		{
+			name: "rename table on master, insert rows on other",
+			setup: []testCommand{
+				{cmd.BranchCmd{}, args{"other"}},
+				{cmd.SqlCmd{}, args{"-q", "RENAME TABLE test TO quiz;"}},
+				{cmd.CommitCmd{}, args{"-am", "renamed test to quiz on master"}},
+				{cmd.CheckoutCmd{}, args{"other"}},
+				{cmd.SqlCmd{}, args{"-q", "INSERT INTO test VALUES (1,1),(2,2);"}},
+				{cmd.CommitCmd{}, args{"-am", "added rows on other"}},
+				{cmd.CheckoutCmd{}, args{"master"}},
+				{cmd.MergeCmd{}, args{"other"}},
+			},
+			query: "SELECT * FROM quiz",
+			expected: []sql.Row{
+				{int32(1), int32(1)},
+				{int32(2), int32(2)},
+			},
+		},
+		{
+			name: "rename table on other, insert rows on both branches",
+			setup: []testCommand{
+				{cmd.BranchCmd{}, args{"other"}},
+				{cmd.SqlCmd{}, args{"-q", "INSERT INTO test VALUES (11,11),(22,22);"}},
+				{cmd.CommitCmd{}, args{"-am", "added rows on master"}},
+				{cmd.CheckoutCmd{}, args{"other"}},
+				{cmd.SqlCmd{}, args{"-q", "RENAME TABLE test TO quiz;"}},
+				{cmd.SqlCmd{}, args{"-q", "INSERT INTO quiz VALUES (1,1),(2,2);"}},
+				{cmd.CommitCmd{}, args{"-am", "renamed test to quiz and added rows on other"}},
+				{cmd.CheckoutCmd{}, args{"master"}},
+				{cmd.MergeCmd{}, args{"other"}},
+			},
+			query: "SELECT * FROM quiz",
+			expected: []sql.Row{
+				{int32(1), int32(1)},
+				{int32(2), int32(2)},
+				{int32(11), int32(11)},
+				{int32(22), int32(22)},
+			},
+		},
+		{
+			name: "rename table to the same name on both branches",
+			setup: []testCommand{
+				{cmd.BranchCmd{}, args{"other"}},
+				{cmd.SqlCmd{}, args{"-q", "RENAME TABLE test TO quiz;"}},
+				{cmd.SqlCmd{}, args{"-q", "INSERT INTO quiz VALUES (11,11);"}},
+				{cmd.CommitCmd{}, args{"-am", "renamed test to quiz on master"}},
+				{cmd.CheckoutCmd{}, args{"other"}},
+				{cmd.SqlCmd{}, args{"-q", "RENAME TABLE test TO quiz;"}},
+				{cmd.SqlCmd{}, args{"-q", "INSERT INTO quiz VALUES (1,1);"}},
+				{cmd.CommitCmd{}, args{"-am", "renamed test to quiz on other"}},
+				{cmd.CheckoutCmd{}, args{"master"}},
+				{cmd.MergeCmd{}, args{"other"}},
+			},
+			query: "SELECT * FROM quiz",
+			expected: []sql.Row{
+				{int32(1), int32(1)},
+				{int32(11), int32(11)},
+			},
+		},
+		{
+			name: "drop different tables on two branches",
+			setup: []testCommand{
+				{cmd.SqlCmd{}, args{"-q", "CREATE TABLE quiz (pk int PRIMARY KEY);"}},
+				{cmd.AddCmd{}, args{"."}},
+				{cmd.CommitCmd{}, args{"-m", "created table quiz"}},
+				{cmd.BranchCmd{}, args{"other"}},
+				{cmd.SqlCmd{}, args{"-q", "DROP TABLE quiz;"}},
+				{cmd.AddCmd{}, args{"."}},
+				{cmd.CommitCmd{}, args{"-m", "dropped quiz on master"}},
+				{cmd.CheckoutCmd{}, args{"other"}},
+				{cmd.SqlCmd{}, args{"-q", "DROP TABLE test;"}},
+				{cmd.AddCmd{}, args{"."}},
+				{cmd.CommitCmd{}, args{"-m", "dropped test on other"}},
+				{cmd.CheckoutCmd{}, args{"master"}},
+				{cmd.MergeCmd{}, args{"other"}},
+			},
+			query:    "SHOW TABLES",
+			expected: []sql.Row{},
+		},
 	}
 
 	for _, test := range tests {
@@ -35,6 +35,7 @@ import (
 
 var ErrFastForward = errors.New("fast forward")
 var ErrSameTblAddedTwice = errors.New("table with same name added in 2 commits can't be merged")
+var ErrTblRenamedDifferently = errors.New("table renamed to different names in 2 commits can't be merged")
 
 type Merger struct {
 	root      *doltdb.RootValue
@@ -138,7 +139,10 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string, sess *edit
 			}
 		}
 
-		if h == anch {
+		if h == anch && !mergeOk {
+			// dropped in the merge root
+			return nil, &MergeStats{Operation: TableRemoved}, nil
+		} else if h == anch {
 			// fast-forward
 			ms := MergeStats{Operation: TableModified}
 			if h != mh {
@@ -161,8 +165,13 @@ func (merger *Merger) MergeTable(ctx context.Context, tblName string, sess *edit
 		return nil, nil, err
 	}
 	if schConflicts.Count() != 0 {
-		// error on schema conflicts for now
-		return nil, nil, schConflicts.AsError()
+		// leave our table as is, and record the conflict so that it can be resolved before the data is merged
+		resultTbl, err := tbl.SetSchemaConflict(ctx, ancTbl, tbl, mergeTbl)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return resultTbl, &MergeStats{Operation: TableModified, SchemaConflicts: schConflicts.Count()}, nil
 	}
 
 	rows, err := tbl.GetRowData(ctx)
@@ -276,6 +285,9 @@ func mergeTableData(ctx context.Context, vrw types.ValueReadWriter, tblName stri
 	if schema.IsKeyless(sch) {
 		rowMerge = keylessRowMerge
 		applyChange = applyKeylessChange
+	} else if tblName == doltdb.DocTableName {
+		rowMerge = docRowMerge
+		applyChange = applyPkChange
 	} else {
 		rowMerge = pkRowMerge
 		applyChange = applyPkChange
@@ -388,6 +400,15 @@ func mergeTableData(ctx context.Context, vrw types.ValueReadWriter, tblName stri
 					if err != nil {
 						return err
 					}
+
+					// a row merger may still produce a row for a conflict, such as a doc with conflict markers
+					if mergedRow != nil {
+						vc := types.ValueChanged{ChangeType: change.ChangeType, Key: key, OldValue: ancRow, NewValue: mergedRow}
+						err = applyChange(ctx, sch, tblEdit, rows, &MergeStats{}, vc)
+						if err != nil {
+							return err
+						}
+					}
 				} else {
 					vc := types.ValueChanged{ChangeType: change.ChangeType, Key: key, OldValue: ancRow, NewValue: mergedRow}
 					err = applyChange(ctx, sch, tblEdit, rows, stats, vc)
@@ -749,6 +770,11 @@ func MergeCommits(ctx context.Context, commit, mergeCommit *doltdb.Commit) (*dol
 }
 
 func MergeRoots(ctx context.Context, ourRoot, theirRoot, ancRoot *doltdb.RootValue) (*doltdb.RootValue, map[string]*MergeStats, error) {
+	ourRoot, theirRoot, ancRoot, err := alignRenamedTables(ctx, ourRoot, theirRoot, ancRoot)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	merger := NewMerger(ctx, ourRoot, theirRoot, ancRoot, ourRoot.VRW())
 
 	tblNames, err := doltdb.UnionTableNames(ctx, ourRoot, theirRoot)
@@ -765,6 +791,14 @@ func MergeRoots(ctx context.Context, ourRoot, theirRoot, ancRoot *doltdb.RootVal
 	})
 	// need to validate merges can be done on all tables before starting the actual merges.
 	for _, tblName := range tblNames {
+		// tables which are unmodified on both sides are not read, as they may not be present in a sparse clone
+		if unmodified, err := tableUnmodified(ctx, tblName, ourRoot, theirRoot, ancRoot); err != nil {
+			return nil, nil, err
+		} else if unmodified {
+			tblToStats[tblName] = &MergeStats{Operation: TableUnmodified}
+			continue
+		}
+
 		mergedTable, stats, err := merger.MergeTable(ctx, tblName, tableEditSession)
 
 		if err != nil {
@@ -795,9 +829,8 @@ func MergeRoots(ctx context.Context, ourRoot, theirRoot, ancRoot *doltdb.RootVal
 			if err != nil {
 				return nil, nil, err
 			}
-		} else {
-			panic("?")
 		}
+		// otherwise the table was dropped in our root, and is not in the merged root either
 	}
 
 	err = tableEditSession.UpdateRoot(ctx, func(ctx context.Context, root *doltdb.RootValue) (value *doltdb.RootValue, err error) {
@@ -828,6 +861,110 @@ func MergeRoots(ctx context.Context, ourRoot, theirRoot, ancRoot *doltdb.RootVal
 	return newRoot, tblToStats, nil
 }
 
+// alignRenamedTables detects tables that were renamed between |ancRoot| and either |ourRoot| or |theirRoot| and
+// applies each rename to the other roots, so that tables are matched by identity rather than by name when merging.
+// Tables renamed on both sides must have been given the same name.
+func alignRenamedTables(ctx context.Context, ourRoot, theirRoot, ancRoot *doltdb.RootValue) (*doltdb.RootValue, *doltdb.RootValue, *doltdb.RootValue, error) {
+	ourRenames, err := getTableRenames(ctx, ancRoot, ourRoot)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	theirRenames, err := getTableRenames(ctx, ancRoot, theirRoot)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	for ancName, ourName := range ourRenames {
+		theirName, ok := theirRenames[ancName]
+		if ok && theirName != ourName {
+			return nil, nil, nil, fmt.Errorf("%w: '%s' was renamed to '%s' and '%s'", ErrTblRenamedDifferently, ancName, ourName, theirName)
+		}
+
+		if !ok {
+			theirRoot, err = renameIfExists(ctx, theirRoot, ancName, ourName)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+		}
+
+		ancRoot, err = ancRoot.RenameTable(ctx, ancName, ourName)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	for ancName, theirName := range theirRenames {
+		if _, ok := ourRenames[ancName]; ok {
+			continue
+		}
+
+		ourRoot, err = renameIfExists(ctx, ourRoot, ancName, theirName)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		ancRoot, err = ancRoot.RenameTable(ctx, ancName, theirName)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	return ourRoot, theirRoot, ancRoot, nil
+}
+
+// tableUnmodified returns whether the table |tblName| has the same value in |ourRoot|, |theirRoot| and |ancRoot|.
+func tableUnmodified(ctx context.Context, tblName string, ourRoot, theirRoot, ancRoot *doltdb.RootValue) (bool, error) {
+	h, ok, err := ourRoot.GetTableHash(ctx, tblName)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	for _, root := range []*doltdb.RootValue{theirRoot, ancRoot} {
+		rh, ok, err := root.GetTableHash(ctx, tblName)
+		if err != nil || !ok || rh != h {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// getTableRenames returns a map from old table name to new table name for every table renamed between |fromRoot|
+// and |toRoot|.
+func getTableRenames(ctx context.Context, fromRoot, toRoot *doltdb.RootValue) (map[string]string, error) {
+	deltas, err := diff.GetTableDeltas(ctx, fromRoot, toRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	renames := make(map[string]string)
+	for _, td := range deltas {
+		if td.IsRename() {
+			renames[td.FromName] = td.ToName
+		}
+	}
+
+	return renames, nil
+}
+
+// renameIfExists renames |oldName| to |newName| in |root|. If |oldName| does not exist in |root|, because it was
+// dropped, |root| is returned unchanged.
+func renameIfExists(ctx context.Context, root *doltdb.RootValue, oldName, newName string) (*doltdb.RootValue, error) {
+	if has, err := root.HasTable(ctx, oldName); err != nil {
+		return nil, err
+	} else if !has {
+		return root, nil
+	}
+
+	root, err := root.RenameTable(ctx, oldName, newName)
+	if err == doltdb.ErrTableExists {
+		return nil, fmt.Errorf("cannot rename table '%s' to '%s' during merge: %w", oldName, newName, err)
+	}
+
+	return root, err
+}
+
 func GetTablesInConflict(ctx context.Context, ddb *doltdb.DoltDB, rsr env.RepoStateReader) (workingInConflict, stagedInConflict, headInConflict []string, err error) {
 	var headRoot, stagedRoot, workingRoot *doltdb.RootValue
 
@@ -870,16 +1007,39 @@ func GetTablesInConflict(ctx context.Context, ddb *doltdb.DoltDB, rsr env.RepoSt
 	return workingInConflict, stagedInConflict, headInConflict, err
 }
 
-func GetDocsInConflict(ctx context.Context, ddb *doltdb.DoltDB, rsr env.RepoStateReader, drw env.DocsReadWriter) (*diff.DocDiffs, error) {
-	docs, err := drw.GetDocsOnDisk()
+// GetTablesWithSchemaConflicts returns the names of the tables in the working root whose schemas conflicted during a
+// merge.
+func GetTablesWithSchemaConflicts(ctx context.Context, ddb *doltdb.DoltDB, rsr env.RepoStateReader) ([]string, error) {
+	workingRoot, err := env.WorkingRoot(ctx, ddb, rsr)
+
 	if err != nil {
 		return nil, err
 	}
 
+	return workingRoot.TablesWithSchemaConflicts(ctx)
+}
+
+// GetDocsInConflict returns the names of the docs in the working root whose changes conflicted during a merge.
+func GetDocsInConflict(ctx context.Context, ddb *doltdb.DoltDB, rsr env.RepoStateReader) ([]string, error) {
 	workingRoot, err := env.WorkingRoot(ctx, ddb, rsr)
 	if err != nil {
 		return nil, err
 	}
 
-	return diff.NewDocDiffs(ctx, workingRoot, nil, docs)
+	docTbl, ok, err := workingRoot.GetTable(ctx, doltdb.DocTableName)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	docCnfs, err := GetDocConflicts(ctx, docTbl)
+	if err != nil {
+		return nil, err
+	}
+
+	docNames := make([]string, len(docCnfs))
+	for i, docCnf := range docCnfs {
+		docNames[i] = docCnf.DocName
+	}
+
+	return docNames, nil
 }
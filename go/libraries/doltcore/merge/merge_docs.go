@@ -0,0 +1,312 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	OursConflictMarker   = "<<<<<<< ours"
+	SplitConflictMarker  = "======="
+	TheirsConflictMarker = ">>>>>>> theirs"
+)
+
+// maxLineMatrixSize is the largest number of cells of the table used to match the lines of two docs. Docs which would
+// need a larger table are not merged line by line, and conflict as a whole if both sides changed them.
+var maxLineMatrixSize = int(types.DEFAULT_MAX_SPLICE_MATRIX_SIZE)
+
+// docRowMerge merges rows of the docs table. Docs modified on both sides are merged line by line, so that only
+// overlapping changes conflict. The row of a conflicting doc is returned along with the conflict, with conflict markers
+// around each of the overlapping changes in its text.
+func docRowMerge(ctx context.Context, nbf *types.NomsBinFormat, sch schema.Schema, r, mergeRow, baseRow types.Value) (types.Value, bool, error) {
+	merged, isConflict, err := pkRowMerge(ctx, nbf, sch, r, mergeRow, baseRow)
+	if err != nil || !isConflict || r == nil || mergeRow == nil {
+		return merged, isConflict, err
+	}
+
+	var baseText string
+	if baseRow != nil {
+		baseText, err = docText(baseRow)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	text, err := docText(r)
+	if err != nil {
+		return nil, false, err
+	}
+
+	mergeText, err := docText(mergeRow)
+	if err != nil {
+		return nil, false, err
+	}
+
+	mergedText, isConflict := MergeText(baseText, text, mergeText)
+
+	vals, err := row.ParseTaggedValues(r.(types.Tuple))
+	if err != nil {
+		return nil, false, err
+	}
+
+	vals[schema.DocTextTag] = types.String(mergedText)
+	v, err := vals.NomsTupleForNonPKCols(nbf, sch.GetNonPKCols()).Value(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return v, isConflict, nil
+}
+
+func docText(v types.Value) (string, error) {
+	vals, err := row.ParseTaggedValues(v.(types.Tuple))
+	if err != nil {
+		return "", err
+	}
+
+	text, ok := vals.Get(schema.DocTextTag)
+	if !ok || types.IsNull(text) {
+		return "", nil
+	}
+
+	return string(text.(types.String)), nil
+}
+
+// MergeText performs a three-way merge of the lines of |ours| and |theirs|, which were both derived from |base|.
+// Changes which do not overlap are applied to the result. Overlapping changes are a conflict, and both versions of the
+// changed lines are written to the result between git style conflict markers. Texts too large to be matched line by
+// line are merged as a single chunk.
+func MergeText(base, ours, theirs string) (string, bool) {
+	baseLines, ourLines, theirLines := splitLines(base), splitLines(ours), splitLines(theirs)
+	ourMatches, ourOk := matchLines(baseLines, ourLines)
+	theirMatches, theirOk := matchLines(baseLines, theirLines)
+
+	var sb strings.Builder
+	if !ourOk || !theirOk {
+		hasConflict := mergeChunk(&sb, baseLines, ourLines, theirLines)
+		return sb.String(), hasConflict
+	}
+
+	var hasConflict bool
+	o, a, b := 0, 0, 0
+	for {
+		// find the next line of base kept by both sides. Everything before it is a chunk changed by one or both sides.
+		i := o
+		for i < len(baseLines) && (ourMatches[i] == -1 || theirMatches[i] == -1) {
+			i++
+		}
+
+		endA, endB := len(ourLines), len(theirLines)
+		if i < len(baseLines) {
+			endA, endB = ourMatches[i], theirMatches[i]
+		}
+
+		if mergeChunk(&sb, baseLines[o:i], ourLines[a:endA], theirLines[b:endB]) {
+			hasConflict = true
+		}
+
+		if i == len(baseLines) {
+			break
+		}
+
+		sb.WriteString(baseLines[i])
+		o, a, b = i+1, endA+1, endB+1
+	}
+
+	return sb.String(), hasConflict
+}
+
+// mergeChunk writes the merge of a chunk of lines changed by one or both sides to |sb|, and returns whether the changes
+// conflict.
+func mergeChunk(sb *strings.Builder, baseChunk, ourChunk, theirChunk []string) bool {
+	switch {
+	case linesEqual(ourChunk, theirChunk), linesEqual(baseChunk, theirChunk):
+		writeLines(sb, ourChunk)
+	case linesEqual(baseChunk, ourChunk):
+		writeLines(sb, theirChunk)
+	default:
+		writeConflict(sb, ourChunk, theirChunk)
+		return true
+	}
+
+	return false
+}
+
+// splitLines splits |s| into lines, keeping the line endings.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// matchLines returns, for each line of |from|, the index of the line of |to| it was matched with in a longest common
+// subsequence of the two, or -1 if it was not matched. It returns false if the lines which differ are too many to be
+// matched within maxLineMatrixSize.
+func matchLines(from, to []string) ([]int, bool) {
+	matches := make([]int, len(from))
+	for i := range matches {
+		matches[i] = -1
+	}
+
+	// lines at the start and end of both are matched without computing the subsequence
+	pre := 0
+	for pre < len(from) && pre < len(to) && from[pre] == to[pre] {
+		matches[pre] = pre
+		pre++
+	}
+
+	suf := 0
+	for suf < len(from)-pre && suf < len(to)-pre && from[len(from)-1-suf] == to[len(to)-1-suf] {
+		matches[len(from)-1-suf] = len(to) - 1 - suf
+		suf++
+	}
+
+	f, t := from[pre:len(from)-suf], to[pre:len(to)-suf]
+	if (len(f)+1)*(len(t)+1) > maxLineMatrixSize {
+		return nil, false
+	}
+
+	lcs := make([][]int, len(f)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(t)+1)
+	}
+
+	for i := len(f) - 1; i >= 0; i-- {
+		for j := len(t) - 1; j >= 0; j-- {
+			if f[i] == t[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	for i, j := 0, 0; i < len(f) && j < len(t); {
+		if f[i] == t[j] {
+			matches[pre+i] = pre + j
+			i++
+			j++
+		} else if lcs[i+1][j] >= lcs[i][j+1] {
+			i++
+		} else {
+			j++
+		}
+	}
+
+	return matches, true
+}
+
+func linesEqual(l1, l2 []string) bool {
+	if len(l1) != len(l2) {
+		return false
+	}
+
+	for i := range l1 {
+		if l1[i] != l2[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func writeLines(sb *strings.Builder, lines []string) {
+	for _, l := range lines {
+		sb.WriteString(l)
+	}
+}
+
+func writeConflict(sb *strings.Builder, ours, theirs []string) {
+	sb.WriteString(OursConflictMarker + "\n")
+	writeLinesTerminated(sb, ours)
+	sb.WriteString(SplitConflictMarker + "\n")
+	writeLinesTerminated(sb, theirs)
+	sb.WriteString(TheirsConflictMarker + "\n")
+}
+
+// writeLinesTerminated writes |lines| making sure that the last one ends with a newline, so that a conflict marker
+// written after them starts on its own line.
+func writeLinesTerminated(sb *strings.Builder, lines []string) {
+	writeLines(sb, lines)
+	if len(lines) > 0 && !strings.HasSuffix(lines[len(lines)-1], "\n") {
+		sb.WriteString("\n")
+	}
+}
+
+// DocConflict is a doc whose changes conflicted during a merge.
+type DocConflict struct {
+	DocName string
+	// Text is the three-way merge of the conflicting versions of the doc, with conflict markers around the overlapping
+	// changes.
+	Text string
+}
+
+// GetDocConflicts returns the conflicts of the docs table |docTbl|, ordered by doc name.
+func GetDocConflicts(ctx context.Context, docTbl *doltdb.Table) ([]DocConflict, error) {
+	if has, err := docTbl.HasConflicts(); err != nil || !has {
+		return nil, err
+	}
+
+	_, conflicts, err := docTbl.GetConflicts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var docCnfs []DocConflict
+	err = conflicts.Iter(ctx, func(key, value types.Value) (stop bool, err error) {
+		keyVals, err := row.ParseTaggedValues(key.(types.Tuple))
+		if err != nil {
+			return false, err
+		}
+
+		cnf, err := doltdb.ConflictFromTuple(value.(types.Tuple))
+		if err != nil {
+			return false, err
+		}
+
+		var texts [3]string
+		for i, v := range []types.Value{cnf.Base, cnf.Value, cnf.MergeValue} {
+			if types.IsNull(v) {
+				continue
+			}
+			if texts[i], err = docText(v); err != nil {
+				return false, err
+			}
+		}
+
+		name, _ := keyVals.Get(schema.DocNameTag)
+		text, _ := MergeText(texts[0], texts[1], texts[2])
+		docCnfs = append(docCnfs, DocConflict{DocName: string(name.(types.String)), Text: text})
+		return false, nil
+	})
+
+	return docCnfs, err
+}
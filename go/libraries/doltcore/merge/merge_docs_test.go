@@ -0,0 +1,120 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeText(t *testing.T) {
+	tests := []struct {
+		name        string
+		base        string
+		ours        string
+		theirs      string
+		expected    string
+		expConflict bool
+	}{
+		{
+			name:     "unchanged",
+			base:     "a\nb\nc\n",
+			ours:     "a\nb\nc\n",
+			theirs:   "a\nb\nc\n",
+			expected: "a\nb\nc\n",
+		},
+		{
+			name:     "changed by one side",
+			base:     "a\nb\nc\n",
+			ours:     "a\nb\nc\n",
+			theirs:   "a\nB\nc\n",
+			expected: "a\nB\nc\n",
+		},
+		{
+			name:     "different lines changed",
+			base:     "a\nb\nc\nd\ne\n",
+			ours:     "A\nb\nc\nd\ne\n",
+			theirs:   "a\nb\nc\nd\nE\n",
+			expected: "A\nb\nc\nd\nE\n",
+		},
+		{
+			name:     "lines added and removed",
+			base:     "a\nb\nc\nd\n",
+			ours:     "a\nnew\nb\nc\nd\n",
+			theirs:   "a\nb\nc\n",
+			expected: "a\nnew\nb\nc\n",
+		},
+		{
+			name:     "same change on both sides",
+			base:     "a\nb\nc\n",
+			ours:     "a\nB\nc\n",
+			theirs:   "a\nB\nc\n",
+			expected: "a\nB\nc\n",
+		},
+		{
+			name:        "overlapping changes",
+			base:        "a\nb\nc\n",
+			ours:        "a\nours\nc\n",
+			theirs:      "a\ntheirs\nc\n",
+			expected:    "a\n<<<<<<< ours\nours\n=======\ntheirs\n>>>>>>> theirs\nc\n",
+			expConflict: true,
+		},
+		{
+			name:        "overlapping changes without trailing newline",
+			base:        "a\nb",
+			ours:        "a\nours",
+			theirs:      "a\ntheirs",
+			expected:    "a\n<<<<<<< ours\nours\n=======\ntheirs\n>>>>>>> theirs\n",
+			expConflict: true,
+		},
+		{
+			name:        "added on both sides",
+			base:        "",
+			ours:        "ours\n",
+			theirs:      "theirs\n",
+			expected:    "<<<<<<< ours\nours\n=======\ntheirs\n>>>>>>> theirs\n",
+			expConflict: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merged, conflict := MergeText(test.base, test.ours, test.theirs)
+			assert.Equal(t, test.expected, merged)
+			assert.Equal(t, test.expConflict, conflict)
+		})
+	}
+}
+
+func TestMergeTextTooLarge(t *testing.T) {
+	defer func(size int) { maxLineMatrixSize = size }(maxLineMatrixSize)
+	maxLineMatrixSize = 4
+
+	// changes to different lines conflict when the texts are too large to be matched line by line
+	base, ours, theirs := "a\nb\nc\nd\n", "A\nB\nc\nd\n", "a\nb\nc\nD\n"
+	merged, conflict := MergeText(base, ours, theirs)
+	assert.True(t, conflict)
+	assert.Equal(t, "<<<<<<< ours\nA\nB\nc\nd\n=======\na\nb\nc\nD\n>>>>>>> theirs\n", merged)
+
+	merged, conflict = MergeText(base, ours, base)
+	assert.False(t, conflict)
+	assert.Equal(t, ours, merged)
+
+	// the lines at the start and end of the texts which are the same don't count towards their size
+	merged, conflict = MergeText(base, "a\nB\nc\nd\n", theirs)
+	assert.False(t, conflict)
+	assert.Equal(t, "a\nB\nc\nD\n", merged)
+}
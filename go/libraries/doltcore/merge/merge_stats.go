@@ -29,4 +29,7 @@ type MergeStats struct {
 	Deletes       int
 	Modifications int
 	Conflicts     int
+	// SchemaConflicts is the number of column and index definitions which could not be merged. The table is left
+	// unmerged while it has any.
+	SchemaConflicts int
 }
@@ -28,6 +28,12 @@ import (
 
 type AutoResolver func(key types.Value, conflict doltdb.Conflict) (types.Value, error)
 
+// AutoResolvers maps the names of the strategies for automatically resolving conflicts to their AutoResolver.
+var AutoResolvers = map[string]AutoResolver{
+	"ours":   Ours,
+	"theirs": Theirs,
+}
+
 func Ours(key types.Value, cnf doltdb.Conflict) (types.Value, error) {
 	return cnf.Value, nil
 }
@@ -208,3 +214,120 @@ func resolveKeylessTable(ctx context.Context, tbl *doltdb.Table, auto AutoResolv
 
 	return tbl.UpdateRows(ctx, rowData)
 }
+
+// SchemaConflictResolver resolves the schema conflict of a table by returning the version of the table to keep.
+type SchemaConflictResolver func(ctx context.Context, tbl *doltdb.Table) (*doltdb.Table, error)
+
+// SchemaConflictResolvers maps the names of the strategies for resolving schema conflicts to their
+// SchemaConflictResolver.
+var SchemaConflictResolvers = map[string]SchemaConflictResolver{
+	"ours":   OurTable,
+	"theirs": TheirTable,
+}
+
+// OurTable resolves a schema conflict by keeping the table as it is in the working set, ignoring their changes.
+func OurTable(ctx context.Context, tbl *doltdb.Table) (*doltdb.Table, error) {
+	return tbl.ClearSchemaConflict()
+}
+
+// TheirTable resolves a schema conflict by replacing the table with their version of it, discarding our changes.
+func TheirTable(ctx context.Context, tbl *doltdb.Table) (*doltdb.Table, error) {
+	_, _, theirs, err := tbl.GetSchemaConflict(ctx)
+	return theirs, err
+}
+
+// ResolveSchemaConflict resolves the schema conflict of the table |tblName| using |resolver|.
+func ResolveSchemaConflict(ctx context.Context, tblName string, tbl *doltdb.Table, resolver SchemaConflictResolver, sess *editor.TableEditSession) error {
+	if has, err := tbl.HasSchemaConflict(); err != nil {
+		return err
+	} else if !has {
+		return doltdb.ErrNoConflicts
+	}
+
+	resolved, err := resolver(ctx, tbl)
+	if err != nil {
+		return err
+	}
+
+	return sess.UpdateRoot(ctx, func(ctx context.Context, root *doltdb.RootValue) (*doltdb.RootValue, error) {
+		return root.PutTable(ctx, tblName, resolved)
+	})
+}
+
+// MergeSchemaConflict resolves the schema conflict of the table |tblName| by merging their version of the table into
+// the table as it is in the working set. It is used once the working table has been altered so that its schema no
+// longer conflicts with theirs, and returns an error if it still does. Row conflicts are recorded as in any other
+// merge.
+func MergeSchemaConflict(ctx context.Context, tblName string, tbl *doltdb.Table, sess *editor.TableEditSession) (*MergeStats, error) {
+	base, _, theirs, err := tbl.GetSchemaConflict(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tbl, err = tbl.ClearSchemaConflict()
+	if err != nil {
+		return nil, err
+	}
+
+	var ourRoot *doltdb.RootValue
+	err = sess.UpdateRoot(ctx, func(ctx context.Context, root *doltdb.RootValue) (*doltdb.RootValue, error) {
+		ourRoot, err = root.PutTable(ctx, tblName, tbl)
+		return ourRoot, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	theirRoot, err := ourRoot.PutTable(ctx, tblName, theirs)
+	if err != nil {
+		return nil, err
+	}
+
+	ancRoot, err := ourRoot.PutTable(ctx, tblName, base)
+	if err != nil {
+		return nil, err
+	}
+
+	merger := NewMerger(ctx, ourRoot, theirRoot, ancRoot, ourRoot.VRW())
+	mergedTbl, stats, err := merger.MergeTable(ctx, tblName, sess)
+	if err != nil {
+		return nil, err
+	}
+
+	if stats.SchemaConflicts > 0 {
+		return nil, schemaConflictError(ctx, tblName, tbl, theirs, base)
+	}
+
+	err = sess.UpdateRoot(ctx, func(ctx context.Context, root *doltdb.RootValue) (*doltdb.RootValue, error) {
+		return root.PutTable(ctx, tblName, mergedTbl)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func schemaConflictError(ctx context.Context, tblName string, ours, theirs, base *doltdb.Table) error {
+	sc, err := SchemaConflictOf(ctx, tblName, ours, theirs, base)
+	if err != nil {
+		return err
+	}
+
+	return sc.AsError()
+}
+
+// SchemaConflictOf returns the conflicts between the schemas of |ours| and |theirs| when merged with |base|.
+func SchemaConflictOf(ctx context.Context, tblName string, ours, theirs, base *doltdb.Table) (SchemaConflict, error) {
+	var schs [3]schema.Schema
+	for i, tbl := range []*doltdb.Table{ours, theirs, base} {
+		sch, err := tbl.GetSchema(ctx)
+		if err != nil {
+			return EmptySchConflicts, err
+		}
+		schs[i] = sch
+	}
+
+	_, sc, err := SchemaMerge(schs[0], schs[1], schs[2], tblName)
+	return sc, err
+}
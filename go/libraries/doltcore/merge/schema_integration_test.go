@@ -29,6 +29,7 @@ import (
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema/typeinfo"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 )
 
 type testCommand struct {
@@ -504,6 +505,8 @@ func testMergeSchemasWithConflicts(t *testing.T, test mergeSchemaConflictTest) {
 	}
 
 	ancSch := getSchema(t, dEnv)
+	ancRoot, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
 
 	for _, c := range test.setup {
 		c.exec(t, ctx, dEnv)
@@ -514,11 +517,15 @@ func testMergeSchemasWithConflicts(t *testing.T, test mergeSchemaConflictTest) {
 	require.Equal(t, 1, exitCode)
 
 	masterSch := getSchema(t, dEnv)
+	masterRoot, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
 
 	exitCode = commands.CheckoutCmd{}.Exec(ctx, "checkout", []string{"other"}, dEnv)
 	require.Equal(t, 0, exitCode)
 
 	otherSch := getSchema(t, dEnv)
+	otherRoot, err := dEnv.WorkingRoot(ctx)
+	require.NoError(t, err)
 
 	_, actConflicts, err := merge.SchemaMerge(masterSch, otherSch, ancSch, "test")
 	require.NoError(t, err)
@@ -537,6 +544,37 @@ func testMergeSchemasWithConflicts(t *testing.T, test mergeSchemaConflictTest) {
 		assert.True(t, test.expConflict.IdxConflicts[i].Ours.Equals(icc.Ours))
 		assert.True(t, test.expConflict.IdxConflicts[i].Theirs.Equals(icc.Theirs))
 	}
+
+	mergedRoot, tblToStats, err := merge.MergeRoots(ctx, masterRoot, otherRoot, ancRoot)
+	require.NoError(t, err)
+	inConflict, err := mergedRoot.TablesWithSchemaConflicts(ctx)
+	require.NoError(t, err)
+
+	if test.expConflict.Count() == 0 {
+		assert.Empty(t, inConflict)
+		return
+	}
+
+	assert.Equal(t, []string{"test"}, inConflict)
+	assert.Equal(t, test.expConflict.Count(), tblToStats["test"].SchemaConflicts)
+
+	tbl, _, err := mergedRoot.GetTable(ctx, "test")
+	require.NoError(t, err)
+	sess := editor.CreateTableEditSession(mergedRoot, editor.TableEditSessionProps{})
+	err = merge.ResolveSchemaConflict(ctx, "test", tbl, merge.TheirTable, sess)
+	require.NoError(t, err)
+	resolvedRoot, err := sess.Flush(ctx)
+	require.NoError(t, err)
+
+	inConflict, err = resolvedRoot.TablesWithSchemaConflicts(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, inConflict)
+
+	tbl, _, err = resolvedRoot.GetTable(ctx, "test")
+	require.NoError(t, err)
+	sch, err := tbl.GetSchema(ctx)
+	require.NoError(t, err)
+	assert.True(t, schema.SchemasAreEqual(otherSch, sch))
 }
 
 func testMergeForeignKeys(t *testing.T, test mergeForeignKeyTest) {
@@ -0,0 +1,338 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/creds"
+)
+
+type permission int
+
+const (
+	permNone permission = iota
+	permRead
+	permWrite
+)
+
+var permissionNames = map[string]permission{
+	"none":  permNone,
+	"read":  permRead,
+	"write": permWrite,
+}
+
+// writeRPCs are the rpcs which modify a repository. All others only read.
+var writeRPCs = map[string]bool{
+	"GetUploadLocations": true,
+	"Commit":             true,
+	"AddTableFiles":      true,
+}
+
+// authFileUser is a user in the auth file. A user authenticates with a bearer token, with http basic authentication
+// using their name and password, or with a token signed by the dolt credentials matching their public key.
+type authFileUser struct {
+	Name      string `json:"name"`
+	Token     string `json:"token"`
+	Password  string `json:"password"`
+	PublicKey string `json:"public_key"`
+	// Repos maps "org/repo", "org/*" or "*" to "read", "write" or "none". The most specific match is used.
+	Repos map[string]string `json:"repos"`
+}
+
+// authFile is the json configuration of the users which may access the server. Anonymous has the permissions of
+// requests without credentials.
+type authFile struct {
+	Users     []authFileUser    `json:"users"`
+	Anonymous map[string]string `json:"anonymous"`
+}
+
+type authUser struct {
+	name   string
+	grants map[string]permission
+}
+
+// Authenticator authenticates grpc requests and checks that the caller has access to the requested repository.
+type Authenticator struct {
+	byToken   map[string]*authUser
+	byName    map[string]*authUser
+	passwords map[string]string
+	byKeyID   map[string]*authUser
+	pubKeys   map[string][]byte
+	anonymous *authUser
+}
+
+// LoadAuthFile reads the auth file at |path| and returns an Authenticator for the users in it.
+func LoadAuthFile(path string) (*Authenticator, error) {
+	data, err := ioutil.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var af authFile
+	err = json.Unmarshal(data, &af)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse auth file %s: %w", path, err)
+	}
+
+	return newAuthenticator(af)
+}
+
+func newAuthenticator(af authFile) (*Authenticator, error) {
+	anonGrants, err := parseGrants(af.Anonymous)
+
+	if err != nil {
+		return nil, fmt.Errorf("anonymous: %w", err)
+	}
+
+	auth := &Authenticator{
+		byToken:   make(map[string]*authUser),
+		byName:    make(map[string]*authUser),
+		passwords: make(map[string]string),
+		byKeyID:   make(map[string]*authUser),
+		pubKeys:   make(map[string][]byte),
+		anonymous: &authUser{name: "anonymous", grants: anonGrants},
+	}
+
+	for _, u := range af.Users {
+		if u.Name == "" {
+			return nil, fmt.Errorf("every user must have a name")
+		}
+
+		if _, ok := auth.byName[u.Name]; ok {
+			return nil, fmt.Errorf("user '%s' is defined more than once", u.Name)
+		}
+
+		grants, err := parseGrants(u.Repos)
+
+		if err != nil {
+			return nil, fmt.Errorf("user '%s': %w", u.Name, err)
+		}
+
+		user := &authUser{name: u.Name, grants: grants}
+		auth.byName[u.Name] = user
+
+		if u.Token != "" {
+			auth.byToken[u.Token] = user
+		}
+
+		if u.Password != "" {
+			auth.passwords[u.Name] = u.Password
+		}
+
+		if u.PublicKey != "" {
+			pubKey, err := creds.B32CredsEncoding.DecodeString(u.PublicKey)
+
+			if err != nil {
+				return nil, fmt.Errorf("user '%s': invalid public key: %w", u.Name, err)
+			}
+
+			kid := creds.PubKeyToKIDStr(pubKey)
+			auth.byKeyID[kid] = user
+			auth.pubKeys[kid] = pubKey
+		}
+	}
+
+	return auth, nil
+}
+
+func parseGrants(repos map[string]string) (map[string]permission, error) {
+	grants := make(map[string]permission, len(repos))
+	for pattern, permStr := range repos {
+		perm, ok := permissionNames[strings.ToLower(permStr)]
+
+		if !ok {
+			return nil, fmt.Errorf("invalid permission '%s' for '%s'. Must be one of read, write or none", permStr, pattern)
+		}
+
+		grants[pattern] = perm
+	}
+
+	return grants, nil
+}
+
+// permission returns the permission |user| has on org/repo, using the most specific of the user's grants.
+func (u *authUser) permission(org, repo string) permission {
+	for _, pattern := range []string{org + "/" + repo, org + "/*", "*"} {
+		if perm, ok := u.grants[pattern]; ok {
+			return perm
+		}
+	}
+
+	return permNone
+}
+
+// authenticate returns the user making a request with the metadata |md|, or the anonymous user if the request has no
+// credentials.
+func (auth *Authenticator) authenticate(md metadata.MD) (*authUser, error) {
+	authHeaders := md.Get(creds.AuthorizationHeader)
+
+	if len(authHeaders) == 0 {
+		return auth.anonymous, nil
+	}
+
+	scheme, value, ok := splitAuthHeader(authHeaders[0])
+
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "malformed authorization header")
+	}
+
+	switch strings.ToLower(scheme) {
+	case "bearer":
+		for token, user := range auth.byToken {
+			if subtle.ConstantTimeCompare([]byte(token), []byte(value)) == 1 {
+				return user, nil
+			}
+		}
+
+		// not a static token, so it may be a token signed by dolt credentials
+		if kid, err := creds.JWTKeyID(value); err == nil {
+			if pubKey, ok := auth.pubKeys[kid]; ok && creds.VerifyJWT(value, pubKey) == nil {
+				return auth.byKeyID[kid], nil
+			}
+		}
+
+	case "basic":
+		decoded, err := base64.StdEncoding.DecodeString(value)
+
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "malformed basic authorization header")
+		}
+
+		nameAndPass := strings.SplitN(string(decoded), ":", 2)
+		if len(nameAndPass) != 2 {
+			return nil, status.Error(codes.Unauthenticated, "malformed basic authorization header")
+		}
+
+		expected, ok := auth.passwords[nameAndPass[0]]
+
+		if ok && subtle.ConstantTimeCompare([]byte(expected), []byte(nameAndPass[1])) == 1 {
+			return auth.byName[nameAndPass[0]], nil
+		}
+	}
+
+	return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+}
+
+func splitAuthHeader(header string) (scheme, value string, ok bool) {
+	tokens := strings.SplitN(strings.TrimSpace(header), " ", 2)
+
+	if len(tokens) != 2 {
+		return "", "", false
+	}
+
+	return tokens[0], strings.TrimSpace(tokens[1]), true
+}
+
+// authorize checks that the caller of the rpc |method| has the permission it needs on |repoId|. Creating a repository,
+// which happens the first time its metadata is requested, requires write permission.
+func (auth *Authenticator) authorize(ctx context.Context, method string, repoId *remotesapi.RepoId, repoExists func(org, repo string) bool) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	user, err := auth.authenticate(md)
+
+	if err != nil {
+		return err
+	}
+
+	required := permRead
+	if requiresWrite(method, repoId, repoExists) {
+		required = permWrite
+	}
+
+	if user.permission(repoId.Org, repoId.RepoName) >= required {
+		return nil
+	}
+
+	if user == auth.anonymous {
+		return status.Error(codes.Unauthenticated, "credentials are required to access this repository")
+	}
+
+	return status.Errorf(codes.PermissionDenied, "%s does not have %s access to %s/%s", user.name, permissionString(required), repoId.Org, repoId.RepoName)
+}
+
+// requiresWrite returns whether the rpc |method| modifies |repoId|. Requesting the metadata of a repository which does
+// not exist creates it.
+func requiresWrite(method string, repoId *remotesapi.RepoId, repoExists func(org, repo string) bool) bool {
+	return writeRPCs[method] || (method == "GetRepoMetadata" && !repoExists(repoId.Org, repoId.RepoName))
+}
+
+func permissionString(perm permission) string {
+	for name, p := range permissionNames {
+		if p == perm {
+			return name
+		}
+	}
+
+	return "unknown"
+}
+
+type repoRequest interface {
+	GetRepoId() *remotesapi.RepoId
+}
+
+// repoUnaryInterceptor validates the RepoId of every request, rejects requests which modify a repository if
+// |readOnly| is true, and if |auth| is not nil checks that the caller is allowed to make the request.
+func repoUnaryInterceptor(auth *Authenticator, readOnly bool, repoExists func(org, repo string) bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		rr, ok := req.(repoRequest)
+
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, "request does not specify a repository")
+		}
+
+		repoId := rr.GetRepoId()
+
+		if repoId == nil || !isValidPathComponent(repoId.Org) || !isValidPathComponent(repoId.RepoName) {
+			return nil, status.Error(codes.InvalidArgument, "invalid repository id")
+		}
+
+		method := path.Base(info.FullMethod)
+
+		if readOnly && requiresWrite(method, repoId, repoExists) {
+			return nil, status.Error(codes.PermissionDenied, "this server is read only")
+		}
+
+		if auth != nil {
+			err := auth.authorize(ctx, method, repoId, repoExists)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// isValidPathComponent returns whether |s| can be used as a single component of a file path without escaping the
+// server's directory.
+func isValidPathComponent(s string) bool {
+	return s != "" && s != "." && s != ".." && !strings.ContainsAny(s, `/\`)
+}
@@ -0,0 +1,203 @@
+// Copyright 2019 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+	"github.com/dolthub/dolt/go/store/nbs"
+)
+
+const (
+	defaultMemTableSize = 128 * 1024 * 1024
+)
+
+var ErrRepoNotFound = errors.New("repository not found")
+
+// DBCache provides the chunk stores served by a RemoteChunkStore, and the directories their table files are read from
+// and written to.
+type DBCache interface {
+	// Get returns the store for org/repo. Caches which can create repositories create it with the format |nbfVerStr| if
+	// it does not exist. Others return ErrRepoNotFound.
+	Get(org, repo, nbfVerStr string) (*nbs.NomsBlockStore, error)
+
+	// Has returns whether the store for org/repo exists.
+	Has(org, repo string) bool
+
+	// Dir returns the directory containing the table files of org/repo.
+	Dir(org, repo string) (string, error)
+}
+
+// LocalCSCache is a DBCache which stores each repository in the directory org/repo, relative to the working directory,
+// creating it the first time it is requested.
+type LocalCSCache struct {
+	mu  *sync.Mutex
+	dbs map[string]*nbs.NomsBlockStore
+
+	fs filesys.Filesys
+}
+
+var _ DBCache = (*LocalCSCache)(nil)
+
+func NewLocalCSCache(filesys filesys.Filesys) *LocalCSCache {
+	return &LocalCSCache{
+		&sync.Mutex{},
+		make(map[string]*nbs.NomsBlockStore),
+		filesys,
+	}
+}
+
+// Has returns whether the store for org/repo exists.
+func (cache *LocalCSCache) Has(org, repo string) bool {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	id := filepath.Join(org, repo)
+
+	if _, ok := cache.dbs[id]; ok {
+		return true
+	}
+
+	if cache.fs == nil {
+		return false
+	}
+
+	exists, isDir := cache.fs.Exists(id)
+	return exists && isDir
+}
+
+func (cache *LocalCSCache) Get(org, repo, nbfVerStr string) (*nbs.NomsBlockStore, error) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	id := filepath.Join(org, repo)
+
+	if cs, ok := cache.dbs[id]; ok {
+		return cs, nil
+	}
+
+	var newCS *nbs.NomsBlockStore
+	if cache.fs != nil {
+		err := cache.fs.MkDirs(id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		newCS, err = nbs.NewLocalStore(context.TODO(), nbfVerStr, id, defaultMemTableSize)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cache.dbs[id] = newCS
+
+	return newCS, nil
+}
+
+func (cache *LocalCSCache) Dir(org, repo string) (string, error) {
+	return filepath.Join(org, repo), nil
+}
+
+// RepoCSCache is a DBCache which serves a fixed set of existing chunk stores, such as those of dolt repositories. It
+// never creates repositories.
+type RepoCSCache struct {
+	mu  *sync.Mutex
+	dbs map[string]*nbs.NomsBlockStore
+
+	// dirs maps a repository name to the directory of its chunk store
+	dirs map[string]string
+	// single is the directory served for every org/repo, or "" if repositories are looked up by name
+	single string
+}
+
+var _ DBCache = (*RepoCSCache)(nil)
+
+// NewSingleRepoCSCache returns a RepoCSCache which serves the chunk store in |dir| for every org/repo.
+func NewSingleRepoCSCache(dir string) *RepoCSCache {
+	return &RepoCSCache{
+		mu:     &sync.Mutex{},
+		dbs:    make(map[string]*nbs.NomsBlockStore),
+		dirs:   map[string]string{},
+		single: dir,
+	}
+}
+
+// NewMultiRepoCSCache returns a RepoCSCache which serves the chunk store in |dirs[repo]| for org/repo. The org is
+// ignored.
+func NewMultiRepoCSCache(dirs map[string]string) *RepoCSCache {
+	return &RepoCSCache{
+		mu:   &sync.Mutex{},
+		dbs:  make(map[string]*nbs.NomsBlockStore),
+		dirs: dirs,
+	}
+}
+
+func (cache *RepoCSCache) Has(org, repo string) bool {
+	_, err := cache.Dir(org, repo)
+	return err == nil
+}
+
+func (cache *RepoCSCache) Dir(org, repo string) (string, error) {
+	if cache.single != "" {
+		return cache.single, nil
+	}
+
+	dir, ok := cache.dirs[repo]
+
+	if !ok {
+		return "", ErrRepoNotFound
+	}
+
+	return dir, nil
+}
+
+// Get returns the store for org/repo. |nbfVerStr| is ignored, as the store already exists and has its own format. The
+// repositories are also modified by dolt outside of the server, so a cached store is rebased to pick up those changes.
+func (cache *RepoCSCache) Get(org, repo, nbfVerStr string) (*nbs.NomsBlockStore, error) {
+	dir, err := cache.Dir(org, repo)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if cs, ok := cache.dbs[dir]; ok {
+		err = cs.Rebase(context.TODO())
+
+		if err != nil {
+			return nil, err
+		}
+
+		return cs, nil
+	}
+
+	cs, err := nbs.NewLocalStore(context.TODO(), nbfVerStr, dir, defaultMemTableSize)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cache.dbs[dir] = cs
+
+	return cs, nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepoCSCacheDir(t *testing.T) {
+	single := NewSingleRepoCSCache("/repos/a/.dolt/noms")
+
+	for _, id := range [][2]string{{"org", "repo"}, {"other-org", "a"}} {
+		dir, err := single.Dir(id[0], id[1])
+		require.NoError(t, err)
+		assert.Equal(t, "/repos/a/.dolt/noms", dir)
+		assert.True(t, single.Has(id[0], id[1]))
+	}
+
+	multi := NewMultiRepoCSCache(map[string]string{
+		"a": "/repos/a/.dolt/noms",
+		"b": "/repos/b/.dolt/noms",
+	})
+
+	dir, err := multi.Dir("org", "a")
+	require.NoError(t, err)
+	assert.Equal(t, "/repos/a/.dolt/noms", dir)
+
+	dir, err = multi.Dir("other-org", "b")
+	require.NoError(t, err)
+	assert.Equal(t, "/repos/b/.dolt/noms", dir)
+
+	_, err = multi.Dir("org", "c")
+	assert.Equal(t, ErrRepoNotFound, err)
+	assert.False(t, multi.Has("org", "c"))
+
+	_, err = multi.Get("org", "c", "")
+	assert.Equal(t, ErrRepoNotFound, err)
+}
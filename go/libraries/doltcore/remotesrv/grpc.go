@@ -12,17 +12,20 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package main
+package remotesrv
 
 import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"sync/atomic"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
@@ -33,17 +36,28 @@ import (
 )
 
 type RemoteChunkStore struct {
-	HttpHost string
-	csCache  *DBCache
-	bucket   string
+	// HttpHost is the host and port of the http file server used in table file urls. If it is empty, the host the
+	// client used to reach the grpc server is used with httpPort.
+	HttpHost      string
+	httpPort      int
+	httpScheme    string
+	csCache       DBCache
+	expectedFiles *expectedFiles
+	bucket        string
+	// signer signs the urls of table files when authentication is enabled. It is nil otherwise.
+	signer *urlSigner
 	remotesapi.UnimplementedChunkStoreServiceServer
 }
 
-func NewHttpFSBackedChunkStore(httpHost string, csCache *DBCache) *RemoteChunkStore {
+func NewHttpFSBackedChunkStore(httpHost string, httpPort int, csCache DBCache, expectedFiles *expectedFiles, signer *urlSigner) *RemoteChunkStore {
 	return &RemoteChunkStore{
-		HttpHost: httpHost,
-		csCache:  csCache,
-		bucket:   "",
+		HttpHost:      httpHost,
+		httpPort:      httpPort,
+		httpScheme:    "http",
+		csCache:       csCache,
+		expectedFiles: expectedFiles,
+		bucket:        "",
+		signer:        signer,
 	}
 }
 
@@ -51,10 +65,10 @@ func (rs *RemoteChunkStore) HasChunks(ctx context.Context, req *remotesapi.HasCh
 	logger := getReqLogger("GRPC", "HasChunks")
 	defer func() { logger("finished") }()
 
-	cs := rs.getStore(req.RepoId, "HasChunks")
+	cs, err := rs.getStore(req.RepoId, "HasChunks")
 
-	if cs == nil {
-		return nil, status.Error(codes.Internal, "Could not get chunkstore")
+	if err != nil {
+		return nil, err
 	}
 
 	logger(fmt.Sprintf("found repo %s/%s", req.RepoId.Org, req.RepoId.RepoName))
@@ -88,10 +102,10 @@ func (rs *RemoteChunkStore) GetDownloadLocations(ctx context.Context, req *remot
 	logger := getReqLogger("GRPC", "GetDownloadLocations")
 	defer func() { logger("finished") }()
 
-	cs := rs.getStore(req.RepoId, "GetDownloadLoctions")
+	cs, err := rs.getStore(req.RepoId, "GetDownloadLoctions")
 
-	if cs == nil {
-		return nil, status.Error(codes.Internal, "Could not get chunkstore")
+	if err != nil {
+		return nil, err
 	}
 
 	logger(fmt.Sprintf("found repo %s/%s", req.RepoId.Org, req.RepoId.RepoName))
@@ -113,7 +127,7 @@ func (rs *RemoteChunkStore) GetDownloadLocations(ctx context.Context, req *remot
 			ranges = append(ranges, &remotesapi.RangeChunk{Hash: hCpy[:], Offset: r.Offset, Length: r.Length})
 		}
 
-		url, err := rs.getDownloadUrl(logger, org, repoName, loc.String())
+		url, err := rs.getDownloadUrl(ctx, logger, org, repoName, loc.String())
 		if err != nil {
 			log.Println("Failed to sign request", err)
 		}
@@ -127,8 +141,28 @@ func (rs *RemoteChunkStore) GetDownloadLocations(ctx context.Context, req *remot
 	return &remotesapi.GetDownloadLocsResponse{Locs: locs}, nil
 }
 
-func (rs *RemoteChunkStore) getDownloadUrl(logger func(string), org, repoName, fileId string) (string, error) {
-	return fmt.Sprintf("http://%s/%s/%s/%s", rs.HttpHost, org, repoName, fileId), nil
+func (rs *RemoteChunkStore) getDownloadUrl(ctx context.Context, logger func(string), org, repoName, fileId string) (string, error) {
+	return rs.signURL(fmt.Sprintf("%s://%s/%s/%s/%s", rs.httpScheme, rs.getHttpHost(ctx), org, repoName, fileId), permRead)
+}
+
+// getHttpHost returns the host and port of the http file server for a request with the context |ctx|.
+func (rs *RemoteChunkStore) getHttpHost(ctx context.Context) string {
+	if rs.HttpHost != "" {
+		return rs.HttpHost
+	}
+
+	host := "localhost"
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if authority := md.Get(":authority"); len(authority) > 0 && authority[0] != "" {
+			host = authority[0]
+
+			if h, _, err := net.SplitHostPort(host); err == nil {
+				host = h
+			}
+		}
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(rs.httpPort))
 }
 
 func parseTableFileDetails(req *remotesapi.GetUploadLocsRequest) []*remotesapi.TableFileDetails {
@@ -154,10 +188,10 @@ func (rs *RemoteChunkStore) GetUploadLocations(ctx context.Context, req *remotes
 	logger := getReqLogger("GRPC", "GetUploadLocations")
 	defer func() { logger("finished") }()
 
-	cs := rs.getStore(req.RepoId, "GetWriteChunkUrls")
+	_, err := rs.getStore(req.RepoId, "GetWriteChunkUrls")
 
-	if cs == nil {
-		return nil, status.Error(codes.Internal, "Could not get chunkstore")
+	if err != nil {
+		return nil, err
 	}
 
 	logger(fmt.Sprintf("found repo %s/%s", req.RepoId.Org, req.RepoId.RepoName))
@@ -169,7 +203,7 @@ func (rs *RemoteChunkStore) GetUploadLocations(ctx context.Context, req *remotes
 	var locs []*remotesapi.UploadLoc
 	for _, tfd := range tfds {
 		h := hash.New(tfd.Id)
-		url, err := rs.getUploadUrl(logger, org, repoName, tfd)
+		url, err := rs.getUploadUrl(ctx, logger, org, repoName, tfd)
 
 		if err != nil {
 			return nil, status.Error(codes.Internal, "Failed to get upload Url.")
@@ -184,25 +218,33 @@ func (rs *RemoteChunkStore) GetUploadLocations(ctx context.Context, req *remotes
 	return &remotesapi.GetUploadLocsResponse{Locs: locs}, nil
 }
 
-func (rs *RemoteChunkStore) getUploadUrl(logger func(string), org, repoName string, tfd *remotesapi.TableFileDetails) (string, error) {
+func (rs *RemoteChunkStore) getUploadUrl(ctx context.Context, logger func(string), org, repoName string, tfd *remotesapi.TableFileDetails) (string, error) {
 	fileID := hash.New(tfd.Id).String()
-	expectedFiles[fileID] = tfd
-	return fmt.Sprintf("http://%s/%s/%s/%s", rs.HttpHost, org, repoName, fileID), nil
+	rs.expectedFiles.add(fileID, tfd)
+	return rs.signURL(fmt.Sprintf("%s://%s/%s/%s/%s", rs.httpScheme, rs.getHttpHost(ctx), org, repoName, fileID), permWrite)
+}
+
+func (rs *RemoteChunkStore) signURL(url string, perm permission) (string, error) {
+	if rs.signer == nil {
+		return url, nil
+	}
+
+	return rs.signer.sign(url, perm)
 }
 
 func (rs *RemoteChunkStore) Rebase(ctx context.Context, req *remotesapi.RebaseRequest) (*remotesapi.RebaseResponse, error) {
 	logger := getReqLogger("GRPC", "Rebase")
 	defer func() { logger("finished") }()
 
-	cs := rs.getStore(req.RepoId, "Rebase")
+	cs, err := rs.getStore(req.RepoId, "Rebase")
 
-	if cs == nil {
-		return nil, status.Error(codes.Internal, "Could not get chunkstore")
+	if err != nil {
+		return nil, err
 	}
 
 	logger(fmt.Sprintf("found %s/%s", req.RepoId.Org, req.RepoId.RepoName))
 
-	err := cs.Rebase(ctx)
+	err = cs.Rebase(ctx)
 
 	if err != nil {
 		logger(fmt.Sprintf("error occurred during processing of Rebace rpc of %s/%s details: %v", req.RepoId.Org, req.RepoId.RepoName, err))
@@ -216,10 +258,10 @@ func (rs *RemoteChunkStore) Root(ctx context.Context, req *remotesapi.RootReques
 	logger := getReqLogger("GRPC", "Root")
 	defer func() { logger("finished") }()
 
-	cs := rs.getStore(req.RepoId, "Root")
+	cs, err := rs.getStore(req.RepoId, "Root")
 
-	if cs == nil {
-		return nil, status.Error(codes.Internal, "Could not get chunkstore")
+	if err != nil {
+		return nil, err
 	}
 
 	h, err := cs.Root(ctx)
@@ -236,10 +278,10 @@ func (rs *RemoteChunkStore) Commit(ctx context.Context, req *remotesapi.CommitRe
 	logger := getReqLogger("GRPC", "Commit")
 	defer func() { logger("finished") }()
 
-	cs := rs.getStore(req.RepoId, "Commit")
+	cs, err := rs.getStore(req.RepoId, "Commit")
 
-	if cs == nil {
-		return nil, status.Error(codes.Internal, "Could not get chunkstore")
+	if err != nil {
+		return nil, err
 	}
 
 	logger(fmt.Sprintf("found %s/%s", req.RepoId.Org, req.RepoId.RepoName))
@@ -250,7 +292,7 @@ func (rs *RemoteChunkStore) Commit(ctx context.Context, req *remotesapi.CommitRe
 		updates[hash.New(cti.Hash)] = cti.ChunkCount
 	}
 
-	_, err := cs.UpdateManifest(ctx, updates)
+	_, err = cs.UpdateManifest(ctx, updates)
 
 	if err != nil {
 		logger(fmt.Sprintf("error occurred updating the manifest: %s", err.Error()))
@@ -276,9 +318,9 @@ func (rs *RemoteChunkStore) GetRepoMetadata(ctx context.Context, req *remotesapi
 	logger := getReqLogger("GRPC", "GetRepoMetadata")
 	defer func() { logger("finished") }()
 
-	cs := rs.getOrCreateStore(req.RepoId, "GetRepoMetadata", req.ClientRepoFormat.NbfVersion)
-	if cs == nil {
-		return nil, status.Error(codes.Internal, "Could not get chunkstore")
+	cs, err := rs.getOrCreateStore(req.RepoId, "GetRepoMetadata", req.ClientRepoFormat.NbfVersion)
+	if err != nil {
+		return nil, err
 	}
 
 	_, tfs, err := cs.Sources(ctx)
@@ -287,9 +329,15 @@ func (rs *RemoteChunkStore) GetRepoMetadata(ctx context.Context, req *remotesapi
 		return nil, err
 	}
 
+	dir, err := rs.csCache.Dir(req.RepoId.Org, req.RepoId.RepoName)
+
+	if err != nil {
+		return nil, err
+	}
+
 	var size uint64
 	for _, tf := range tfs {
-		path := filepath.Join(req.RepoId.Org, req.RepoId.RepoName, tf.FileID())
+		path := filepath.Join(dir, tf.FileID())
 		info, err := os.Stat(path)
 
 		if err != nil {
@@ -310,10 +358,10 @@ func (rs *RemoteChunkStore) ListTableFiles(ctx context.Context, req *remotesapi.
 	logger := getReqLogger("GRPC", "ListTableFiles")
 	defer func() { logger("finished") }()
 
-	cs := rs.getStore(req.RepoId, "ListTableFiles")
+	cs, err := rs.getStore(req.RepoId, "ListTableFiles")
 
-	if cs == nil {
-		return nil, status.Error(codes.Internal, "Could not get chunkstore")
+	if err != nil {
+		return nil, err
 	}
 
 	logger(fmt.Sprintf("found repo %s/%s", req.RepoId.Org, req.RepoId.RepoName))
@@ -326,7 +374,7 @@ func (rs *RemoteChunkStore) ListTableFiles(ctx context.Context, req *remotesapi.
 
 	var tableFileInfo []*remotesapi.TableFileInfo
 	for _, tbl := range tables {
-		url, err := rs.getDownloadUrl(logger, req.RepoId.Org, req.RepoId.RepoName, tbl.FileID())
+		url, err := rs.getDownloadUrl(ctx, logger, req.RepoId.Org, req.RepoId.RepoName, tbl.FileID())
 
 		if err != nil {
 			return nil, status.Error(codes.Internal, "failed to get download url for "+tbl.FileID())
@@ -352,10 +400,10 @@ func (rs *RemoteChunkStore) AddTableFiles(ctx context.Context, req *remotesapi.A
 	logger := getReqLogger("GRPC", "Commit")
 	defer func() { logger("finished") }()
 
-	cs := rs.getStore(req.RepoId, "Commit")
+	cs, err := rs.getStore(req.RepoId, "Commit")
 
-	if cs == nil {
-		return nil, status.Error(codes.Internal, "Could not get chunkstore")
+	if err != nil {
+		return nil, err
 	}
 
 	logger(fmt.Sprintf("found %s/%s", req.RepoId.Org, req.RepoId.RepoName))
@@ -366,7 +414,7 @@ func (rs *RemoteChunkStore) AddTableFiles(ctx context.Context, req *remotesapi.A
 		updates[hash.New(cti.Hash)] = cti.ChunkCount
 	}
 
-	_, err := cs.UpdateManifest(ctx, updates)
+	_, err = cs.UpdateManifest(ctx, updates)
 
 	if err != nil {
 		logger(fmt.Sprintf("error occurred updating the manifest: %s", err.Error()))
@@ -376,21 +424,30 @@ func (rs *RemoteChunkStore) AddTableFiles(ctx context.Context, req *remotesapi.A
 	return &remotesapi.AddTableFilesResponse{Success: true}, nil
 }
 
-func (rs *RemoteChunkStore) getStore(repoId *remotesapi.RepoId, rpcName string) *nbs.NomsBlockStore {
+// getStore returns the store of an existing repository. Repositories are only created by GetRepoMetadata, which requires
+// write permission to do so, so that other rpcs can't create them with read permission.
+func (rs *RemoteChunkStore) getStore(repoId *remotesapi.RepoId, rpcName string) (*nbs.NomsBlockStore, error) {
+	if !rs.csCache.Has(repoId.Org, repoId.RepoName) {
+		return nil, status.Errorf(codes.NotFound, "repository %s/%s not found", repoId.Org, repoId.RepoName)
+	}
+
 	return rs.getOrCreateStore(repoId, rpcName, types.Format_Default.VersionString())
 }
 
-func (rs *RemoteChunkStore) getOrCreateStore(repoId *remotesapi.RepoId, rpcName, nbfVerStr string) *nbs.NomsBlockStore {
+func (rs *RemoteChunkStore) getOrCreateStore(repoId *remotesapi.RepoId, rpcName, nbfVerStr string) (*nbs.NomsBlockStore, error) {
 	org := repoId.Org
 	repoName := repoId.RepoName
 
 	cs, err := rs.csCache.Get(org, repoName, nbfVerStr)
 
-	if err != nil {
+	if err == ErrRepoNotFound {
+		return nil, status.Errorf(codes.NotFound, "repository %s/%s not found", org, repoName)
+	} else if err != nil || cs == nil {
 		log.Printf("Failed to retrieve chunkstore for %s/%s\n", org, repoName)
+		return nil, status.Error(codes.Internal, "Could not get chunkstore")
 	}
 
-	return cs
+	return cs, nil
 }
 
 var requestId int32
@@ -0,0 +1,62 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/utils/filesys"
+)
+
+func TestReadMissingRepo(t *testing.T) {
+	ctx := context.Background()
+	fs := filesys.EmptyInMemFS("/")
+	cache := NewLocalCSCache(fs)
+	rs := NewHttpFSBackedChunkStore("localhost", 0, cache, newExpectedFiles(), nil)
+
+	auth, err := newAuthenticator(authFile{Anonymous: map[string]string{"*": "read"}})
+	require.NoError(t, err)
+
+	repoId := &remotesapi.RepoId{Org: "org", RepoName: "repo"}
+	anonCtx := metadata.NewIncomingContext(ctx, metadata.MD{})
+
+	// creating the repository requires write permission
+	err = auth.authorize(anonCtx, "GetRepoMetadata", repoId, cache.Has)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// reading it only requires read permission, but doesn't create it
+	for _, method := range []string{"HasChunks", "Root", "ListTableFiles"} {
+		require.NoError(t, auth.authorize(anonCtx, method, repoId, cache.Has))
+	}
+
+	_, err = rs.HasChunks(ctx, &remotesapi.HasChunksRequest{RepoId: repoId})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = rs.Root(ctx, &remotesapi.RootRequest{RepoId: repoId})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = rs.ListTableFiles(ctx, &remotesapi.ListTableFilesRequest{RepoId: repoId})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	exists, _ := fs.Exists("org/repo")
+	assert.False(t, exists)
+	assert.False(t, cache.Has("org", "repo"))
+}
@@ -12,7 +12,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package main
+package remotesrv
 
 import (
 	"bytes"
@@ -26,6 +26,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 
 	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
 
@@ -33,9 +34,37 @@ import (
 	"github.com/dolthub/dolt/go/store/hash"
 )
 
-var expectedFiles = make(map[string]*remotesapi.TableFileDetails)
+// expectedFiles are the table files which clients have been given upload urls for, by file id.
+type expectedFiles struct {
+	mu    *sync.Mutex
+	files map[string]*remotesapi.TableFileDetails
+}
+
+func newExpectedFiles() *expectedFiles {
+	return &expectedFiles{&sync.Mutex{}, make(map[string]*remotesapi.TableFileDetails)}
+}
+
+func (ef *expectedFiles) add(fileId string, tfd *remotesapi.TableFileDetails) {
+	ef.mu.Lock()
+	defer ef.mu.Unlock()
+	ef.files[fileId] = tfd
+}
+
+func (ef *expectedFiles) get(fileId string) (*remotesapi.TableFileDetails, bool) {
+	ef.mu.Lock()
+	defer ef.mu.Unlock()
+	tfd, ok := ef.files[fileId]
+	return tfd, ok
+}
+
+// fileHandler serves the table files of the repositories in a DBCache over http, and accepts uploads of the table
+// files clients were given upload urls for.
+type fileHandler struct {
+	dbCache       DBCache
+	expectedFiles *expectedFiles
+}
 
-func ServeHTTP(respWr http.ResponseWriter, req *http.Request) {
+func (fh *fileHandler) ServeHTTP(respWr http.ResponseWriter, req *http.Request) {
 	logger := getReqLogger("HTTP_"+req.Method, req.RequestURI)
 	defer func() { logger("finished") }()
 
@@ -45,25 +74,40 @@ func ServeHTTP(respWr http.ResponseWriter, req *http.Request) {
 	if len(tokens) != 3 {
 		logger(fmt.Sprintf("response to: %v method: %v http response code: %v", req.RequestURI, req.Method, http.StatusNotFound))
 		respWr.WriteHeader(http.StatusNotFound)
+		return
 	}
 
 	org := tokens[0]
 	repo := tokens[1]
 	hashStr := tokens[2]
 
+	if !isValidPathComponent(org) || !isValidPathComponent(repo) || !isValidPathComponent(hashStr) {
+		logger(fmt.Sprintf("response to: %v method: %v http response code: %v", req.RequestURI, req.Method, http.StatusBadRequest))
+		respWr.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	dir, err := fh.dbCache.Dir(org, repo)
+
+	if err != nil {
+		logger(fmt.Sprintf("response to: %v method: %v http response code: %v", req.RequestURI, req.Method, http.StatusNotFound))
+		respWr.WriteHeader(http.StatusNotFound)
+		return
+	}
+
 	statusCode := http.StatusMethodNotAllowed
 	switch req.Method {
 	case http.MethodGet:
 		rangeStr := req.Header.Get("Range")
 
 		if rangeStr == "" {
-			statusCode = readFile(logger, org, repo, hashStr, respWr)
+			statusCode = readFile(logger, dir, hashStr, respWr)
 		} else {
-			statusCode = readChunk(logger, org, repo, hashStr, rangeStr, respWr)
+			statusCode = readChunk(logger, dir, hashStr, rangeStr, respWr)
 		}
 
 	case http.MethodPost, http.MethodPut:
-		statusCode = writeTableFile(logger, org, repo, hashStr, req)
+		statusCode = fh.writeTableFile(logger, dir, hashStr, req)
 	}
 
 	if statusCode != -1 {
@@ -71,7 +115,7 @@ func ServeHTTP(respWr http.ResponseWriter, req *http.Request) {
 	}
 }
 
-func writeTableFile(logger func(string), org, repo, fileId string, request *http.Request) int {
+func (fh *fileHandler) writeTableFile(logger func(string), dir, fileId string, request *http.Request) int {
 	_, ok := hash.MaybeParse(fileId)
 
 	if !ok {
@@ -79,7 +123,7 @@ func writeTableFile(logger func(string), org, repo, fileId string, request *http
 		return http.StatusBadRequest
 	}
 
-	tfd, ok := expectedFiles[fileId]
+	tfd, ok := fh.expectedFiles.get(fileId)
 
 	if !ok {
 		return http.StatusBadRequest
@@ -104,7 +148,7 @@ func writeTableFile(logger func(string), org, repo, fileId string, request *http
 		return http.StatusInternalServerError
 	}
 
-	err = writeLocal(logger, org, repo, fileId, data)
+	err = writeLocal(logger, dir, fileId, data)
 
 	if err != nil {
 		return http.StatusInternalServerError
@@ -113,8 +157,8 @@ func writeTableFile(logger func(string), org, repo, fileId string, request *http
 	return http.StatusOK
 }
 
-func writeLocal(logger func(string), org, repo, fileId string, data []byte) error {
-	path := filepath.Join(org, repo, fileId)
+func writeLocal(logger func(string), dir, fileId string, data []byte) error {
+	path := filepath.Join(dir, fileId)
 
 	err := ioutil.WriteFile(path, data, os.ModePerm)
 
@@ -158,8 +202,8 @@ func offsetAndLenFromRange(rngStr string) (int64, int64, error) {
 	return int64(start), int64(end-start) + 1, nil
 }
 
-func readFile(logger func(string), org, repo, fileId string, writer io.Writer) int {
-	path := filepath.Join(org, repo, fileId)
+func readFile(logger func(string), dir, fileId string, writer io.Writer) int {
+	path := filepath.Join(dir, fileId)
 
 	info, err := os.Stat(path)
 
@@ -200,7 +244,7 @@ func readFile(logger func(string), org, repo, fileId string, writer io.Writer) i
 	return -1
 }
 
-func readChunk(logger func(string), org, repo, fileId, rngStr string, writer io.Writer) int {
+func readChunk(logger func(string), dir, fileId, rngStr string, writer io.Writer) int {
 	offset, length, err := offsetAndLenFromRange(rngStr)
 
 	if err != nil {
@@ -208,7 +252,7 @@ func readChunk(logger func(string), org, repo, fileId, rngStr string, writer io.
 		return http.StatusBadRequest
 	}
 
-	data, retVal := readLocalRange(logger, org, repo, fileId, int64(offset), int64(length))
+	data, retVal := readLocalRange(logger, dir, fileId, int64(offset), int64(length))
 
 	if retVal != -1 {
 		return retVal
@@ -226,8 +270,8 @@ func readChunk(logger func(string), org, repo, fileId, rngStr string, writer io.
 	return -1
 }
 
-func readLocalRange(logger func(string), org, repo, fileId string, offset, length int64) ([]byte, int) {
-	path := filepath.Join(org, repo, fileId)
+func readLocalRange(logger func(string), dir, fileId string, offset, length int64) ([]byte, int) {
+	path := filepath.Join(dir, fileId)
 
 	logger(fmt.Sprintf("Attempting to read bytes %d to %d from %s", offset, offset+length, path))
 	info, err := os.Stat(path)
@@ -0,0 +1,162 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+)
+
+// ServerArgs are the options of a Server.
+type ServerArgs struct {
+	// HttpHost is the host and port clients use to reach the http file server. If it is empty, the host clients used
+	// to reach the grpc server is used with HttpPort.
+	HttpHost string
+	HttpPort int
+	GrpcPort int
+
+	// DBCache provides the repositories which are served.
+	DBCache DBCache
+
+	// Auth authenticates requests. If it is nil any client can read and write any repository.
+	Auth *Authenticator
+
+	// ReadOnly rejects every request which would modify a repository.
+	ReadOnly bool
+
+	// TLSConfig is used to serve both grpc and http over TLS. If it is nil both are served in plaintext.
+	TLSConfig *tls.Config
+}
+
+// Server serves the repositories of a DBCache over the remotesapi grpc chunk store service, and their table files
+// over http.
+type Server struct {
+	wg sync.WaitGroup
+
+	grpcSrv      *grpc.Server
+	grpcListener net.Listener
+	grpcPort     int
+
+	httpSrv      *http.Server
+	httpListener net.Listener
+	httpPort     int
+}
+
+// LoadTLSConfig returns a TLS config which serves the PEM encoded certificate chain in |certFile|, whose private key
+// is in |keyFile|.
+func LoadTLSConfig(certFile, keyFile string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12}, nil
+}
+
+// NewServer returns a Server listening on the ports in |args|. It does not accept requests until Serve is called.
+func NewServer(args ServerArgs) (*Server, error) {
+	// when authentication is enabled the http server only serves urls signed by the grpc server
+	var signer *urlSigner
+	if args.Auth != nil {
+		var err error
+		signer, err = newURLSigner()
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to create url signing key: %w", err)
+		}
+	}
+
+	grpcListener, err := net.Listen("tcp", fmt.Sprintf(":%d", args.GrpcPort))
+
+	if err != nil {
+		return nil, err
+	}
+
+	httpListener, err := net.Listen("tcp", fmt.Sprintf(":%d", args.HttpPort))
+
+	if err != nil {
+		_ = grpcListener.Close()
+		return nil, err
+	}
+
+	expected := newExpectedFiles()
+	chnkSt := NewHttpFSBackedChunkStore(args.HttpHost, args.HttpPort, args.DBCache, expected, signer)
+
+	opts := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(128 * 1024 * 1024),
+		grpc.UnaryInterceptor(repoUnaryInterceptor(args.Auth, args.ReadOnly, args.DBCache.Has)),
+	}
+
+	if args.TLSConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(args.TLSConfig)))
+		httpListener = tls.NewListener(httpListener, args.TLSConfig)
+		chnkSt.httpScheme = "https"
+	}
+
+	grpcSrv := grpc.NewServer(opts...)
+	remotesapi.RegisterChunkStoreServiceServer(grpcSrv, chnkSt)
+
+	var handler http.Handler = &fileHandler{dbCache: args.DBCache, expectedFiles: expected}
+	if signer != nil {
+		handler = signer.wrap(handler)
+	}
+
+	return &Server{
+		grpcSrv:      grpcSrv,
+		grpcListener: grpcListener,
+		grpcPort:     args.GrpcPort,
+		httpSrv:      &http.Server{Handler: handler},
+		httpListener: httpListener,
+		httpPort:     args.HttpPort,
+	}, nil
+}
+
+// Serve accepts requests until GracefulStop is called.
+func (s *Server) Serve() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		log.Println("Starting http server on port", s.httpPort)
+		err := s.httpSrv.Serve(s.httpListener)
+		log.Println("http server exited. exit error:", err)
+	}()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		log.Println("Starting grpc server on port", s.grpcPort)
+		err := s.grpcSrv.Serve(s.grpcListener)
+		log.Println("grpc server exited. error:", err)
+	}()
+
+	s.wg.Wait()
+}
+
+// GracefulStop stops accepting requests, and waits for the requests in flight to finish.
+func (s *Server) GracefulStop() {
+	s.grpcSrv.GracefulStop()
+	_ = s.httpSrv.Shutdown(context.Background())
+}
@@ -0,0 +1,107 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remotesrv
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	signedURLTTL = time.Hour
+
+	expiresParam   = "expires"
+	signatureParam = "signature"
+)
+
+// urlSigner signs the table file urls returned by the grpc server so that the http server, which has no credentials
+// of its own, can check that a request was authorized. A signed url is only valid for the access it was signed for,
+// and until it expires.
+type urlSigner struct {
+	key []byte
+}
+
+func newURLSigner() (*urlSigner, error) {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &urlSigner{key}, nil
+}
+
+func (s *urlSigner) signature(perm permission, path string, expires int64) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(fmt.Sprintf("%d\n%s\n%d", perm, path, expires)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// sign adds an expiration time and a signature granting |perm| access to |urlStr|.
+func (s *urlSigner) sign(urlStr string, perm permission) (string, error) {
+	u, err := url.Parse(urlStr)
+
+	if err != nil {
+		return "", err
+	}
+
+	expires := time.Now().Add(signedURLTTL).Unix()
+	q := u.Query()
+	q.Set(expiresParam, strconv.FormatInt(expires, 10))
+	q.Set(signatureParam, s.signature(perm, u.Path, expires))
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// verify returns whether |req| has a valid, unexpired signature for the access its method needs.
+func (s *urlSigner) verify(req *http.Request) bool {
+	perm := permRead
+	if req.Method == http.MethodPost || req.Method == http.MethodPut {
+		perm = permWrite
+	}
+
+	q := req.URL.Query()
+	expires, err := strconv.ParseInt(q.Get(expiresParam), 10, 64)
+
+	if err != nil || time.Now().Unix() > expires {
+		return false
+	}
+
+	expected := s.signature(perm, req.URL.Path, expires)
+	return hmac.Equal([]byte(expected), []byte(q.Get(signatureParam)))
+}
+
+// wrap returns a handler which rejects requests without a valid signature before calling |handler|.
+func (s *urlSigner) wrap(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(respWr http.ResponseWriter, req *http.Request) {
+		if !s.verify(req) {
+			logger := getReqLogger("HTTP_"+req.Method, req.URL.Path)
+			logger(fmt.Sprintf("rejected request with a missing, invalid or expired signature. http response code: %v", http.StatusForbidden))
+			respWr.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		handler.ServeHTTP(respWr, req)
+	})
+}
@@ -158,6 +158,20 @@ func updateTableWithModifiedColumn(ctx context.Context, tbl *doltdb.Table, oldSc
 		return nil, err
 	}
 
+	// the table is rebuilt from scratch, so carry over a schema conflict which this change may be resolving
+	if has, err := tbl.HasSchemaConflict(); err != nil {
+		return nil, err
+	} else if has {
+		base, ours, theirs, err := tbl.GetSchemaConflict(ctx)
+		if err != nil {
+			return nil, err
+		}
+		updatedTable, err = updatedTable.SetSchemaConflict(ctx, base, ours, theirs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if !oldCol.TypeInfo.Equals(modifiedCol.TypeInfo) {
 		// If we're modifying the primary key then all indexes are affected. Otherwise we just want to update the
 		// touched ones.
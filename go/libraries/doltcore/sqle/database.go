@@ -167,6 +167,11 @@ func (db Database) GetTableInsensitiveWithRoot(ctx *sql.Context, root *doltdb.Ro
 
 	// NOTE: system tables are not suitable for caching
 	switch {
+	case lwrName == doltdb.DiffSummaryTableName:
+		// checked before the diff table prefix, which it would otherwise match
+		dt, found = dtables.NewDiffSummaryTable(ctx, db.ddb, db.rsr, root), true
+	case lwrName == doltdb.SchemaDiffTableName:
+		dt, found = dtables.NewSchemaDiffTable(ctx, db.ddb, db.rsr, root), true
 	case strings.HasPrefix(lwrName, doltdb.DoltDiffTablePrefix):
 		suffix := tblName[len(doltdb.DoltDiffTablePrefix):]
 		found = true
@@ -174,15 +179,19 @@ func (db Database) GetTableInsensitiveWithRoot(ctx *sql.Context, root *doltdb.Ro
 	case strings.HasPrefix(lwrName, doltdb.DoltCommitDiffTablePrefix):
 		suffix := tblName[len(doltdb.DoltCommitDiffTablePrefix):]
 		found = true
-		dt, err = dtables.NewCommitDiffTable(ctx, suffix, db.ddb, root)
+		dt, err = dtables.NewCommitDiffTable(ctx, suffix, db.ddb, db.rsr, root)
 	case strings.HasPrefix(lwrName, doltdb.DoltHistoryTablePrefix):
 		suffix := tblName[len(doltdb.DoltHistoryTablePrefix):]
 		found = true
 		dt, err = dtables.NewHistoryTable(ctx, suffix, db.ddb, root, head)
+	case strings.HasPrefix(lwrName, doltdb.DoltBlameTablePrefix):
+		suffix := tblName[len(doltdb.DoltBlameTablePrefix):]
+		found = true
+		dt, err = dtables.NewBlameTable(ctx, suffix, db.ddb, head)
 	case strings.HasPrefix(lwrName, doltdb.DoltConfTablePrefix):
 		suffix := tblName[len(doltdb.DoltConfTablePrefix):]
 		found = true
-		dt, err = dtables.NewConflictsTable(ctx, suffix, root, dtables.RootSetter(db))
+		dt, err = dtables.NewConflictsTable(ctx, suffix, root, dtables.RootEditor(db))
 	}
 	if err != nil {
 		return nil, false, err
@@ -197,14 +206,22 @@ func (db Database) GetTableInsensitiveWithRoot(ctx *sql.Context, root *doltdb.Ro
 		dt, found = dtables.NewLogTable(ctx, db.ddb, head), true
 	case doltdb.TableOfTablesInConflictName:
 		dt, found = dtables.NewTableOfTablesInConflict(ctx, db.ddb, root), true
+	case doltdb.SchemaConflictsTableName:
+		dt, found = dtables.NewSchemaConflictsTable(ctx, root, dtables.RootEditor(db)), true
 	case doltdb.BranchesTableName:
-		dt, found = dtables.NewBranchesTable(ctx, db.ddb), true
+		dt, found = dtables.NewBranchesTable(ctx, db.ddb, db.rsr), true
 	case doltdb.CommitsTableName:
 		dt, found = dtables.NewCommitsTable(ctx, db.ddb), true
 	case doltdb.CommitAncestorsTableName:
 		dt, found = dtables.NewCommitAncestorsTable(ctx, db.ddb), true
 	case doltdb.StatusTableName:
 		dt, found = dtables.NewStatusTable(ctx, db.ddb, db.rsr, db.drw), true
+	case doltdb.ReplicationStatusTableName:
+		var rsr dtables.ReplicationStatusReader
+		if replicator := DSessFromSess(ctx.Session).Replicator; replicator != nil {
+			rsr = replicator
+		}
+		dt, found = dtables.NewReplicationStatusTable(ctx, db.name, rsr), true
 	}
 	if found {
 		return dt, found, nil
@@ -463,30 +480,7 @@ func (db Database) GetRoot(ctx *sql.Context) (*doltdb.RootValue, error) {
 // basic SQL execution engine. If |newRoot|'s FeatureVersion is
 // out-of-date with the client, SetRoot will update it.
 func (db Database) SetRoot(ctx *sql.Context, newRoot *doltdb.RootValue) error {
-	h, err := newRoot.HashOf()
-
-	if err != nil {
-		return err
-	}
-
-	hashStr := h.String()
-	key := db.WorkingKey()
-
-	err = ctx.Session.Set(ctx, key, hashType, hashStr)
-
-	if err != nil {
-		return err
-	}
-
-	dsess := DSessFromSess(ctx.Session)
-	dsess.dbRoots[db.name] = dbRoot{hashStr, newRoot}
-
-	err = dsess.dbEditors[db.name].SetRoot(ctx, newRoot)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return DSessFromSess(ctx.Session).SetRoot(ctx, db.name, newRoot)
 }
 
 // LoadRootFromRepoState loads the root value from the repo state's working hash, then calls SetRoot with the loaded
@@ -41,6 +41,10 @@ func (cf *CommitFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	dbName := ctx.GetCurrentDatabase()
 	dSess := sqle.DSessFromSess(ctx.Session)
 
+	if err := dSess.CheckExecute(dbName); err != nil {
+		return nil, err
+	}
+
 	//  Get the params associated with COMMIT.
 	ap := cli.CreateCommitArgParser()
 	args, err := getDoltArgs(ctx, row, cf.Children())
@@ -39,6 +39,11 @@ func (d DoltAddFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	}
 
 	dSess := sqle.DSessFromSess(ctx.Session)
+
+	if err := dSess.CheckWrite(dbName); err != nil {
+		return 1, err
+	}
+
 	dbData, ok := dSess.GetDbData(dbName)
 
 	if !ok {
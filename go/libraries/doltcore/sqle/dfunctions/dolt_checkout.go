@@ -45,6 +45,11 @@ func (d DoltCheckoutFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, erro
 	}
 
 	dSess := sqle.DSessFromSess(ctx.Session)
+
+	if err := dSess.CheckWrite(dbName); err != nil {
+		return 1, err
+	}
+
 	dbData, ok := dSess.GetDbData(dbName)
 
 	if !ok {
@@ -45,6 +45,11 @@ func (d DoltCommitFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 	// Get the information for the sql context.
 	dbName := ctx.GetCurrentDatabase()
 	dSess := sqle.DSessFromSess(ctx.Session)
+
+	if err := dSess.CheckExecute(dbName); err != nil {
+		return nil, err
+	}
+
 	dbData, ok := dSess.GetDbData(dbName)
 
 	if !ok {
@@ -140,6 +145,8 @@ func (d DoltCommitFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 		return nil, err
 	}
 
+	dSess.Replicate(dbName)
+
 	return h, nil
 }
 
@@ -0,0 +1,174 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+)
+
+const DoltConflictsResolveFuncName = "dolt_conflicts_resolve"
+
+type DoltConflictsResolveFunc struct {
+	expression.NaryExpression
+}
+
+// Eval resolves all of the conflicts of the given tables using either our or their version of each conflicting row.
+// Tables whose schemas conflict are replaced by either our or their version of the whole table.
+// The resolved tables are written to the session's working root, and are persisted when its transaction is committed.
+func (d DoltConflictsResolveFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	dbName := ctx.GetCurrentDatabase()
+
+	if len(dbName) == 0 {
+		return 1, fmt.Errorf("Empty database name.")
+	}
+
+	dSess := sqle.DSessFromSess(ctx.Session)
+
+	if err := dSess.CheckWrite(dbName); err != nil {
+		return 1, err
+	}
+
+	root, ok := dSess.GetRoot(dbName)
+
+	if !ok {
+		return 1, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	tes, ok := dSess.GetTableEditSession(dbName)
+
+	if !ok {
+		return 1, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	ap := cli.CreateConflictsResolveArgParser()
+	args, err := getDoltArgs(ctx, row, d.Children())
+
+	if err != nil {
+		return 1, err
+	}
+
+	apr := cli.ParseArgs(ap, args, nil)
+
+	if apr.Contains(cli.OursFlag) == apr.Contains(cli.TheirsFlag) {
+		return 1, fmt.Errorf("error: exactly one of --%s or --%s must be given", cli.OursFlag, cli.TheirsFlag)
+	}
+
+	if apr.NArg() == 0 {
+		return 1, fmt.Errorf("error: specify at least one table to resolve conflicts")
+	}
+
+	resolveFlag := cli.TheirsFlag
+	if apr.Contains(cli.OursFlag) {
+		resolveFlag = cli.OursFlag
+	}
+
+	autoResolver := merge.AutoResolvers[resolveFlag]
+	schResolver := merge.SchemaConflictResolvers[resolveFlag]
+
+	tblNames := apr.Args()
+	if len(tblNames) == 1 && tblNames[0] == "." {
+		tblNames, err = root.TablesInConflict(ctx)
+
+		if err != nil {
+			return 1, err
+		}
+
+		schTblNames, err := root.TablesWithSchemaConflicts(ctx)
+
+		if err != nil {
+			return 1, err
+		}
+
+		tblNames = append(schTblNames, tblNames...)
+	}
+
+	for _, tblName := range tblNames {
+		tbl, ok, err := root.GetTable(ctx, tblName)
+
+		if err != nil {
+			return 1, err
+		} else if !ok {
+			return 1, sql.ErrTableNotFound.New(tblName)
+		}
+
+		if has, err := tbl.HasSchemaConflict(); err != nil {
+			return 1, err
+		} else if has {
+			err = merge.ResolveSchemaConflict(ctx, tblName, tbl, schResolver, tes)
+
+			if err != nil {
+				return 1, err
+			}
+
+			continue
+		}
+
+		if has, err := tbl.HasConflicts(); err != nil {
+			return 1, err
+		} else if !has {
+			continue
+		}
+
+		err = merge.ResolveTable(ctx, root.VRW(), tblName, tbl, autoResolver, tes)
+
+		if err != nil {
+			return 1, err
+		}
+	}
+
+	newRoot, err := tes.Flush(ctx)
+
+	if err != nil {
+		return 1, err
+	}
+
+	err = dSess.SetRoot(ctx, dbName, newRoot)
+
+	if err != nil {
+		return 1, err
+	}
+
+	return 0, nil
+}
+
+func (d DoltConflictsResolveFunc) String() string {
+	childrenStrings := make([]string, len(d.Children()))
+
+	for i, child := range d.Children() {
+		childrenStrings[i] = child.String()
+	}
+
+	return fmt.Sprintf("DOLT_CONFLICTS_RESOLVE(%s)", strings.Join(childrenStrings, ","))
+}
+
+func (d DoltConflictsResolveFunc) Type() sql.Type {
+	return sql.Int8
+}
+
+func (d DoltConflictsResolveFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewDoltConflictsResolveFunc(children...)
+}
+
+func NewDoltConflictsResolveFunc(args ...sql.Expression) (sql.Expression, error) {
+	return &DoltConflictsResolveFunc{expression.NaryExpression{ChildExpressions: args}}, nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+
+	"github.com/dolthub/dolt/go/cmd/dolt/cli"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+)
+
+const DoltDescribeFuncName = "dolt_describe"
+
+type DoltDescribeFunc struct {
+	expression.NaryExpression
+}
+
+func (d DoltDescribeFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	dbName := ctx.GetCurrentDatabase()
+
+	if len(dbName) == 0 {
+		return nil, fmt.Errorf("Empty database name.")
+	}
+
+	sess := sqle.DSessFromSess(ctx.Session)
+	dbData, ok := sess.GetDbData(dbName)
+
+	if !ok {
+		return nil, fmt.Errorf("Could not load database %s", dbName)
+	}
+
+	ap := cli.CreateDescribeArgParser()
+	args, err := getDoltArgs(ctx, row, d.Children())
+
+	if err != nil {
+		return nil, err
+	}
+
+	apr := cli.ParseArgs(ap, args, nil)
+
+	if apr.NArg() > 1 {
+		return nil, fmt.Errorf("error: %s takes at most one commit", strings.ToUpper(DoltDescribeFuncName))
+	}
+
+	if apr.Contains(cli.DirtyFlag) && apr.NArg() > 0 {
+		return nil, fmt.Errorf("error: --%s can only be used when describing HEAD", cli.DirtyFlag)
+	}
+
+	cSpecStr := "HEAD"
+	if apr.NArg() == 1 {
+		cSpecStr = apr.Arg(0)
+	}
+
+	cm, err := resolveCommitSpec(ctx, cSpecStr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	opts := actions.DescribeOpts{AllTags: apr.Contains(cli.TagsFlag), Long: apr.Contains(cli.LongFlag)}
+	name, err := actions.Describe(ctx, dbData.Ddb, cm, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if apr.Contains(cli.DirtyFlag) {
+		working, ok := sess.GetRoot(dbName)
+
+		if !ok {
+			return nil, sql.ErrDatabaseNotFound.New(dbName)
+		}
+
+		dirty, err := actions.IsWorkingSetDirty(working, cm)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if dirty {
+			name += actions.DirtySuffix
+		}
+	}
+
+	return name, nil
+}
+
+func (d DoltDescribeFunc) String() string {
+	childrenStrings := make([]string, len(d.Children()))
+
+	for i, child := range d.Children() {
+		childrenStrings[i] = child.String()
+	}
+
+	return fmt.Sprintf("DOLT_DESCRIBE(%s)", strings.Join(childrenStrings, ","))
+}
+
+func (d DoltDescribeFunc) Type() sql.Type {
+	return sql.Text
+}
+
+func (d DoltDescribeFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	return NewDoltDescribeFunc(children...)
+}
+
+func NewDoltDescribeFunc(args ...sql.Expression) (sql.Expression, error) {
+	return &DoltDescribeFunc{expression.NaryExpression{ChildExpressions: args}}, nil
+}
@@ -45,6 +45,11 @@ func (d DoltMergeFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 	}
 
 	sess := sqle.DSessFromSess(ctx.Session)
+
+	if err := sess.CheckExecute(dbName); err != nil {
+		return 1, err
+	}
+
 	dbData, ok := sess.GetDbData(dbName)
 
 	if !ok {
@@ -328,7 +333,7 @@ func mergeRootToWorking(ctx *sql.Context, squash bool, dbData env.DbData, merged
 
 func checkForConflicts(tblToStats map[string]*merge.MergeStats) bool {
 	for _, stats := range tblToStats {
-		if stats.Operation == merge.TableModified && stats.Conflicts > 0 {
+		if stats.Operation == merge.TableModified && (stats.Conflicts > 0 || stats.SchemaConflicts > 0) {
 			return true
 		}
 	}
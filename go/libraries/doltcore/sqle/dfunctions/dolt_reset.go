@@ -40,6 +40,11 @@ func (d DoltResetFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error)
 	}
 
 	dSess := sqle.DSessFromSess(ctx.Session)
+
+	if err := dSess.CheckExecute(dbName); err != nil {
+		return 1, err
+	}
+
 	dbData, ok := dSess.GetDbData(dbName)
 
 	if !ok {
@@ -27,6 +27,10 @@ var DoltFunctions = []sql.Function{
 	sql.FunctionN{Name: DoltResetFuncName, Fn: NewDoltResetFunc},
 	sql.FunctionN{Name: DoltCheckoutFuncName, Fn: NewDoltCheckoutFunc},
 	sql.FunctionN{Name: DoltMergeFuncName, Fn: NewDoltMergeFunc},
+	sql.FunctionN{Name: DoltConflictsResolveFuncName, Fn: NewDoltConflictsResolveFunc},
+	sql.Function2{Name: DoltMergeBaseFuncName, Fn: NewDoltMergeBaseFunc},
+	sql.Function2{Name: DoltIsAncestorFuncName, Fn: NewDoltIsAncestorFunc},
+	sql.FunctionN{Name: DoltDescribeFuncName, Fn: NewDoltDescribeFunc},
 }
 
 // These are the DoltFunctions that get exposed to Dolthub Api.
@@ -43,6 +43,10 @@ func NewMergeFunc(args ...sql.Expression) (sql.Expression, error) {
 func (cf *MergeFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	sess := sqle.DSessFromSess(ctx.Session)
 
+	if err := sess.CheckExecute(ctx.GetCurrentDatabase()); err != nil {
+		return nil, err
+	}
+
 	// TODO: Move to a separate MERGE argparser.
 	ap := cli.CreateCommitArgParser()
 	args, err := getDoltArgs(ctx, row, cf.Children())
@@ -0,0 +1,202 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dfunctions
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+)
+
+const (
+	DoltMergeBaseFuncName  = "dolt_merge_base"
+	DoltIsAncestorFuncName = "dolt_is_ancestor"
+)
+
+type DoltMergeBaseFunc struct {
+	expression.BinaryExpression
+}
+
+// NewDoltMergeBaseFunc creates a new DoltMergeBaseFunc expression, which returns the hash of the merge base of two
+// commits.
+func NewDoltMergeBaseFunc(left, right sql.Expression) sql.Expression {
+	return &DoltMergeBaseFunc{expression.BinaryExpression{Left: left, Right: right}}
+}
+
+// Eval implements the Expression interface.
+func (d *DoltMergeBaseFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	left, right, err := evalCommitPair(ctx, row, d.Left, d.Right)
+
+	if err != nil || left == nil {
+		return nil, err
+	}
+
+	// when there are several merge bases, return the same one as `dolt merge-base`
+	mergeBases, err := doltdb.GetMergeBases(ctx, left, right)
+
+	if err == doltdb.ErrNoCommonAncestor {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	h, err := mergeBases[0].HashOf()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return h.String(), nil
+}
+
+// String implements the Stringer interface.
+func (d *DoltMergeBaseFunc) String() string {
+	return fmt.Sprintf("DOLT_MERGE_BASE(%s, %s)", d.Left.String(), d.Right.String())
+}
+
+// IsNullable implements the Expression interface.
+func (d *DoltMergeBaseFunc) IsNullable() bool {
+	return true
+}
+
+// Type implements the Expression interface.
+func (d *DoltMergeBaseFunc) Type() sql.Type {
+	return sql.Text
+}
+
+// WithChildren implements the Expression interface.
+func (d *DoltMergeBaseFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(d, len(children), 2)
+	}
+	return NewDoltMergeBaseFunc(children[0], children[1]), nil
+}
+
+type DoltIsAncestorFunc struct {
+	expression.BinaryExpression
+}
+
+// NewDoltIsAncestorFunc creates a new DoltIsAncestorFunc expression, which returns whether the first commit is an
+// ancestor of the second commit.
+func NewDoltIsAncestorFunc(left, right sql.Expression) sql.Expression {
+	return &DoltIsAncestorFunc{expression.BinaryExpression{Left: left, Right: right}}
+}
+
+// Eval implements the Expression interface.
+func (d *DoltIsAncestorFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	left, right, err := evalCommitPair(ctx, row, d.Left, d.Right)
+
+	if err != nil || left == nil {
+		return nil, err
+	}
+
+	return doltdb.IsAncestor(ctx, left, right)
+}
+
+// String implements the Stringer interface.
+func (d *DoltIsAncestorFunc) String() string {
+	return fmt.Sprintf("DOLT_IS_ANCESTOR(%s, %s)", d.Left.String(), d.Right.String())
+}
+
+// IsNullable implements the Expression interface.
+func (d *DoltIsAncestorFunc) IsNullable() bool {
+	return d.Left.IsNullable() || d.Right.IsNullable()
+}
+
+// Type implements the Expression interface.
+func (d *DoltIsAncestorFunc) Type() sql.Type {
+	return sql.Boolean
+}
+
+// WithChildren implements the Expression interface.
+func (d *DoltIsAncestorFunc) WithChildren(children ...sql.Expression) (sql.Expression, error) {
+	if len(children) != 2 {
+		return nil, sql.ErrInvalidChildrenNumber.New(d, len(children), 2)
+	}
+	return NewDoltIsAncestorFunc(children[0], children[1]), nil
+}
+
+// evalCommitPair evaluates |left| and |right| and resolves them as commit specs in the current database. The commits
+// are nil if either expression evaluates to NULL.
+func evalCommitPair(ctx *sql.Context, row sql.Row, left, right sql.Expression) (*doltdb.Commit, *doltdb.Commit, error) {
+	var commits [2]*doltdb.Commit
+	for i, expr := range []sql.Expression{left, right} {
+		val, err := expr.Eval(ctx, row)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if val == nil {
+			return nil, nil, nil
+		}
+
+		cSpecStr, ok := val.(string)
+
+		if !ok {
+			return nil, nil, errors.New("commit spec is not a string")
+		}
+
+		commits[i], err = resolveCommitSpec(ctx, cSpecStr)
+
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return commits[0], commits[1], nil
+}
+
+// resolveCommitSpec resolves a commit spec such as a branch name, commit hash, or HEAD~2 in the current database. HEAD
+// refers to the head commit of the session.
+func resolveCommitSpec(ctx *sql.Context, cSpecStr string) (*doltdb.Commit, error) {
+	dbName := ctx.GetCurrentDatabase()
+	sess := sqle.DSessFromSess(ctx.Session)
+	dbData, ok := sess.GetDbData(dbName)
+
+	if !ok {
+		return nil, sql.ErrDatabaseNotFound.New(dbName)
+	}
+
+	name, as, err := doltdb.SplitAncestorSpec(cSpecStr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.ToUpper(name) == "HEAD" {
+		cm, _, err := sess.GetParentCommit(ctx, dbName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return cm.GetAncestor(ctx, as)
+	}
+
+	cs, err := doltdb.NewCommitSpec(cSpecStr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return dbData.Ddb.Resolve(ctx, cs, dbData.Rsr.CWBHeadRef())
+}
@@ -54,6 +54,10 @@ func (rf ResetFunc) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	dbName := ctx.GetCurrentDatabase()
 	dSess := sqle.DSessFromSess(ctx.Session)
 
+	if err := dSess.CheckExecute(dbName); err != nil {
+		return nil, err
+	}
+
 	var h hash.Hash
 	if strings.ToLower(arg) != resetHardParameter {
 		return nil, fmt.Errorf("invalid arugument to %s(): %s", resetFuncName, arg)
@@ -23,6 +23,8 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/dtables"
 	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
 	"github.com/dolthub/dolt/go/store/hash"
 )
@@ -44,6 +46,34 @@ type DoltSession struct {
 
 	Username string
 	Email    string
+
+	// Privileges restricts which users may call the dolt functions which change the commit graph of a database. If it
+	// is nil every user may call them.
+	Privileges PrivilegeChecker
+
+	// Replicator pushes the branches changed in the session to a remote. If it is nil the databases of the session are
+	// not replicated.
+	Replicator Replicator
+}
+
+// PrivilegeChecker checks the privileges of the users of a DoltSession.
+type PrivilegeChecker interface {
+	// CheckExecute returns an error if |user| may not call the dolt functions which change the commit graph of the
+	// database |dbName|, such as DOLT_COMMIT, DOLT_MERGE and DOLT_RESET.
+	CheckExecute(user, dbName string) error
+
+	// CheckWrite returns an error if |user| may not call the dolt functions which change the working set of the
+	// database |dbName| or create branches, such as DOLT_ADD, DOLT_CHECKOUT and DOLT_CONFLICTS_RESOLVE.
+	CheckWrite(user, dbName string) error
+}
+
+// Replicator replicates the changes made to the branches of the databases of a DoltSession to a remote.
+type Replicator interface {
+	dtables.ReplicationStatusReader
+
+	// Replicate queues the branch |branch| of the database |dbName| to be pushed to its remote. It does not block
+	// while the branch is pushed.
+	Replicate(dbName string, branch ref.DoltRef)
 }
 
 // TableCache is a caches for sql.Tables.
@@ -136,7 +166,48 @@ func (sess *DoltSession) CommitTransaction(ctx *sql.Context) error {
 		return err
 	}
 
-	return dbData.Rsw.SetWorkingHash(ctx, h)
+	err = dbData.Rsw.SetWorkingHash(ctx, h)
+	if err != nil {
+		return err
+	}
+
+	sess.Replicate(currentDb)
+	return nil
+}
+
+// Replicate queues the checked out branch of the database |dbName| to be pushed to its remote, if the session has a
+// Replicator.
+func (sess *DoltSession) Replicate(dbName string) {
+	if sess.Replicator == nil {
+		return
+	}
+
+	dbData, ok := sess.dbDatas[dbName]
+	if !ok {
+		return
+	}
+
+	sess.Replicator.Replicate(dbName, dbData.Rsr.CWBHeadRef())
+}
+
+// CheckExecute returns an error if the user of the session may not call the dolt functions which change the commit
+// graph of the database |dbName|.
+func (sess *DoltSession) CheckExecute(dbName string) error {
+	if sess.Privileges == nil {
+		return nil
+	}
+
+	return sess.Privileges.CheckExecute(sess.Client().User, dbName)
+}
+
+// CheckWrite returns an error if the user of the session may not call the dolt functions which change the working set
+// of the database |dbName| or create branches.
+func (sess *DoltSession) CheckWrite(dbName string) error {
+	if sess.Privileges == nil {
+		return nil
+	}
+
+	return sess.Privileges.CheckWrite(sess.Client().User, dbName)
 }
 
 // GetDoltDB returns the *DoltDB for a given database by name
@@ -224,6 +295,33 @@ func (sess *DoltSession) GetRoot(dbName string) (*doltdb.RootValue, bool) {
 	return dbRoot.root, true
 }
 
+// SetRoot sets the working *RootValue for a given database associated with the session. The new root is part of the
+// session's transaction, and is written to the repo state when the transaction is committed.
+func (sess *DoltSession) SetRoot(ctx *sql.Context, dbName string, newRoot *doltdb.RootValue) error {
+	h, err := newRoot.HashOf()
+
+	if err != nil {
+		return err
+	}
+
+	hashStr := h.String()
+	err = ctx.Session.Set(ctx, dbName+WorkingKeySuffix, hashType, hashStr)
+
+	if err != nil {
+		return err
+	}
+
+	sess.dbRoots[dbName] = dbRoot{hashStr, newRoot}
+
+	return sess.dbEditors[dbName].SetRoot(ctx, newRoot)
+}
+
+// GetTableEditSession returns the *editor.TableEditSession for a given database associated with the session
+func (sess *DoltSession) GetTableEditSession(dbName string) (*editor.TableEditSession, bool) {
+	tes, ok := sess.dbEditors[dbName]
+	return tes, ok
+}
+
 // GetParentCommit returns the parent commit of the current session.
 func (sess *DoltSession) GetParentCommit(ctx context.Context, dbName string) (*doltdb.Commit, hash.Hash, error) {
 	dbd, dbFound := sess.dbDatas[dbName]
@@ -365,9 +463,19 @@ func (sess *DoltSession) AddDB(ctx context.Context, db Database) error {
 
 	sess.caches[db.name] = newTableCache()
 
-	cs := rsr.CWBHeadSpec()
+	return sess.LoadHead(ctx, name)
+}
+
+// LoadHead sets the head of the database |dbName| to the latest commit of its checked out branch, and its working
+// root to the root of that commit. Any changes made in the session which were not committed are discarded.
+func (sess *DoltSession) LoadHead(ctx context.Context, dbName string) error {
+	dbd, ok := sess.dbDatas[dbName]
+
+	if !ok {
+		return sql.ErrDatabaseNotFound.New(dbName)
+	}
 
-	cm, err := ddb.Resolve(ctx, cs, rsr.CWBHeadRef())
+	cm, err := dbd.Ddb.Resolve(ctx, dbd.Rsr.CWBHeadSpec(), dbd.Rsr.CWBHeadRef())
 
 	if err != nil {
 		return err
@@ -379,7 +487,7 @@ func (sess *DoltSession) AddDB(ctx context.Context, db Database) error {
 		return err
 	}
 
-	return sess.Set(ctx, name+HeadKeySuffix, sql.Text, h.String())
+	return sess.Set(ctx, dbName+HeadKeySuffix, sql.Text, h.String())
 }
 
 func newTableCache() TableCache {
@@ -0,0 +1,182 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var _ sql.Table = (*BlameTable)(nil)
+
+// ErrBlameColumnClash is returned when a primary key column of a table has the same name as one of the columns which
+// the blame system table adds after the primary key.
+var ErrBlameColumnClash = errors.New("primary key column has the same name as a blame column")
+
+// BlameTable is a sql.Table implementation of a system table which has a row for every row of a table at the head
+// commit, containing the row's primary key and the commit which last modified the row.
+type BlameTable struct {
+	name   string
+	ddb    *doltdb.DoltDB
+	head   *doltdb.Commit
+	pkSch  schema.Schema
+	sqlSch sql.Schema
+}
+
+// NewBlameTable creates a BlameTable for the table named |tblName| as of |head|
+func NewBlameTable(ctx *sql.Context, tblName string, ddb *doltdb.DoltDB, head *doltdb.Commit) (sql.Table, error) {
+	blameTblName := doltdb.DoltBlameTablePrefix + tblName
+
+	if head == nil {
+		return nil, sql.ErrTableNotFound.New(blameTblName)
+	}
+
+	root, err := head.GetRootValue()
+
+	if err != nil {
+		return nil, err
+	}
+
+	tbl, tblName, ok, err := root.GetTableInsensitive(ctx, tblName)
+
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, sql.ErrTableNotFound.New(blameTblName)
+	}
+
+	sch, err := tbl.GetSchema(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if schema.IsKeyless(sch) {
+		return nil, fmt.Errorf("error creating %s: %w", blameTblName, diff.ErrBlameKeyless)
+	}
+
+	pkSch, err := schema.SchemaFromCols(sch.GetPKCols())
+
+	if err != nil {
+		return nil, err
+	}
+
+	sqlSch, err := sqlutil.FromDoltSchema(blameTblName, pkSch)
+
+	if err != nil {
+		return nil, err
+	}
+
+	blameCols := sql.Schema{
+		&sql.Column{Name: "commit_hash", Type: sql.Text, Source: blameTblName},
+		&sql.Column{Name: "committer", Type: sql.Text, Source: blameTblName},
+		&sql.Column{Name: "email", Type: sql.Text, Source: blameTblName},
+		&sql.Column{Name: "commit_date", Type: sql.Datetime, Source: blameTblName},
+		&sql.Column{Name: "message", Type: sql.Text, Source: blameTblName},
+	}
+
+	for _, col := range blameCols {
+		if sqlSch.Contains(col.Name, blameTblName) {
+			return nil, fmt.Errorf("error creating %s: %w: '%s'", blameTblName, ErrBlameColumnClash, col.Name)
+		}
+	}
+
+	sqlSch = append(sqlSch, blameCols...)
+
+	return &BlameTable{
+		name:   tblName,
+		ddb:    ddb,
+		head:   head,
+		pkSch:  pkSch,
+		sqlSch: sqlSch,
+	}, nil
+}
+
+// Name is a sql.Table interface function which returns the name of the table
+func (bt *BlameTable) Name() string {
+	return doltdb.DoltBlameTablePrefix + bt.name
+}
+
+// String is a sql.Table interface function which returns the name of the table
+func (bt *BlameTable) String() string {
+	return doltdb.DoltBlameTablePrefix + bt.name
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the blame system table.
+func (bt *BlameTable) Schema() sql.Schema {
+	return bt.sqlSch
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (bt *BlameTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(types.Map{}), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (bt *BlameTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	blame, err := diff.BlameRows(ctx, bt.ddb, bt.head, bt.name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]sql.Row, len(blame))
+	for i, rb := range blame {
+		r, err := bt.pkToSqlRow(rb.Key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		rows[i] = append(r, rb.CommitHash.String(), rb.Meta.Name, rb.Meta.Email, rb.Meta.Time(), rb.Meta.Description)
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// pkToSqlRow converts the primary key tuple of a row into the sql values of its primary key columns.
+func (bt *BlameTable) pkToSqlRow(key types.Tuple) (sql.Row, error) {
+	fields, err := key.AsSlice()
+
+	if err != nil {
+		return nil, err
+	}
+
+	taggedVals := make(map[uint64]types.Value, len(fields)/2)
+	for i := 0; i+1 < len(fields); i += 2 {
+		taggedVals[uint64(fields[i].(types.Uint))] = fields[i+1]
+	}
+
+	var r sql.Row
+	err = bt.pkSch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		val, err := col.TypeInfo.ConvertNomsValueToValue(taggedVals[tag])
+		if err != nil {
+			return true, err
+		}
+
+		r = append(r, val)
+		return false, nil
+	})
+
+	return r, err
+}
@@ -21,6 +21,8 @@ import (
 	"github.com/dolthub/go-mysql-server/sql"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env/actions"
 	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
 	"github.com/dolthub/dolt/go/store/types"
@@ -35,11 +37,12 @@ var _ sql.ReplaceableTable = (*BranchesTable)(nil)
 // BranchesTable is a sql.Table implementation that implements a system table which shows the dolt branches
 type BranchesTable struct {
 	ddb *doltdb.DoltDB
+	rsr env.RepoStateReader
 }
 
 // NewBranchesTable creates a BranchesTable
-func NewBranchesTable(_ *sql.Context, ddb *doltdb.DoltDB) sql.Table {
-	return &BranchesTable{ddb}
+func NewBranchesTable(_ *sql.Context, ddb *doltdb.DoltDB, rsr env.RepoStateReader) sql.Table {
+	return &BranchesTable{ddb, rsr}
 }
 
 // Name is a sql.Table interface function which returns the name of the table which is defined by the constant
@@ -63,6 +66,9 @@ func (bt *BranchesTable) Schema() sql.Schema {
 		{Name: "latest_committer_email", Type: sql.Text, Source: doltdb.BranchesTableName, PrimaryKey: false, Nullable: true},
 		{Name: "latest_commit_date", Type: sql.Datetime, Source: doltdb.BranchesTableName, PrimaryKey: false, Nullable: true},
 		{Name: "latest_commit_message", Type: sql.Text, Source: doltdb.BranchesTableName, PrimaryKey: false, Nullable: true},
+		{Name: "upstream", Type: sql.Text, Source: doltdb.BranchesTableName, PrimaryKey: false, Nullable: true},
+		{Name: "ahead", Type: sql.Int64, Source: doltdb.BranchesTableName, PrimaryKey: false, Nullable: true},
+		{Name: "behind", Type: sql.Int64, Source: doltdb.BranchesTableName, PrimaryKey: false, Nullable: true},
 	}
 }
 
@@ -73,18 +79,19 @@ func (bt *BranchesTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
 
 // PartitionRows is a sql.Table interface function that gets a row iterator for a partition
 func (bt *BranchesTable) PartitionRows(sqlCtx *sql.Context, part sql.Partition) (sql.RowIter, error) {
-	return NewBranchItr(sqlCtx, bt.ddb)
+	return NewBranchItr(sqlCtx, bt.ddb, bt.rsr)
 }
 
 // BranchItr is a sql.RowItr implementation which iterates over each commit as if it's a row in the table.
 type BranchItr struct {
-	branches []string
-	commits  []*doltdb.Commit
-	idx      int
+	branches  []string
+	commits   []*doltdb.Commit
+	upstreams []*actions.UpstreamStatus
+	idx       int
 }
 
 // NewBranchItr creates a BranchItr from the current environment.
-func NewBranchItr(sqlCtx *sql.Context, ddb *doltdb.DoltDB) (*BranchItr, error) {
+func NewBranchItr(sqlCtx *sql.Context, ddb *doltdb.DoltDB, rsr env.RepoStateReader) (*BranchItr, error) {
 	branches, err := ddb.GetBranches(sqlCtx)
 
 	if err != nil {
@@ -93,6 +100,7 @@ func NewBranchItr(sqlCtx *sql.Context, ddb *doltdb.DoltDB) (*BranchItr, error) {
 
 	branchNames := make([]string, len(branches))
 	commits := make([]*doltdb.Commit, len(branches))
+	upstreams := make([]*actions.UpstreamStatus, len(branches))
 	for i, branch := range branches {
 		commit, err := ddb.ResolveRef(sqlCtx, branch)
 
@@ -100,11 +108,18 @@ func NewBranchItr(sqlCtx *sql.Context, ddb *doltdb.DoltDB) (*BranchItr, error) {
 			return nil, err
 		}
 
+		upstream, err := actions.GetUpstreamStatus(sqlCtx, ddb, rsr.GetBranches(), branch.GetPath())
+
+		if err != nil {
+			return nil, err
+		}
+
 		branchNames[i] = branch.GetPath()
 		commits[i] = commit
+		upstreams[i] = upstream
 	}
 
-	return &BranchItr{branchNames, commits, 0}, nil
+	return &BranchItr{branchNames, commits, upstreams, 0}, nil
 }
 
 // Next retrieves the next row. It will return io.EOF if it's the last row.
@@ -132,7 +147,12 @@ func (itr *BranchItr) Next() (sql.Row, error) {
 		return nil, err
 	}
 
-	return sql.NewRow(name, h.String(), meta.Name, meta.Email, meta.Time(), meta.Description), nil
+	var upstream, ahead, behind interface{}
+	if us := itr.upstreams[itr.idx]; us != nil {
+		upstream, ahead, behind = us.RemoteRef.GetPath(), int64(us.Ahead), int64(us.Behind)
+	}
+
+	return sql.NewRow(name, h.String(), meta.Name, meta.Email, meta.Time(), meta.Description, upstream, ahead, behind), nil
 }
 
 // Close closes the iterator.
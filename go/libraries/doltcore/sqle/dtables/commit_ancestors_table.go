@@ -100,7 +100,8 @@ func (itr *CommitAncestorsRowItr) Next() (sql.Row, error) {
 			return nil, err
 		}
 
-		parents, err := itr.ddb.ResolveAllParents(itr.ctx, cm)
+		// the parents of the commits at the boundary of a shallow clone are not present, so only their hashes are read
+		parents, err := cm.ParentHashes(itr.ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -111,12 +112,7 @@ func (itr *CommitAncestorsRowItr) Next() (sql.Row, error) {
 		}
 
 		itr.cache = make([]sql.Row, len(parents))
-		for i, p := range parents {
-			ph, err := p.HashOf()
-			if err != nil {
-				return nil, err
-			}
-
+		for i, ph := range parents {
 			itr.cache[i] = sql.NewRow(ch.String(), ph.String(), int32(i))
 		}
 	}
@@ -28,6 +28,8 @@ import (
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
 	"github.com/dolthub/dolt/go/libraries/doltcore/rowconv"
 	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
@@ -42,16 +44,21 @@ var _ sql.Table = (*CommitDiffTable)(nil)
 type CommitDiffTable struct {
 	name              string
 	ddb               *doltdb.DoltDB
+	rsr               env.RepoStateReader
 	ss                *schema.SuperSchema
+	ssSch             schema.Schema
 	joiner            *rowconv.Joiner
 	sqlSch            sql.Schema
 	workingRoot       *doltdb.RootValue
 	fromCommitFilter  *expression.Equals
 	toCommitFilter    *expression.Equals
+	pkFilters         []*expression.Equals
 	requiredFilterErr error
 }
 
-func NewCommitDiffTable(ctx *sql.Context, tblName string, ddb *doltdb.DoltDB, root *doltdb.RootValue) (sql.Table, error) {
+// NewCommitDiffTable creates a CommitDiffTable for |tblName|. Commit specs relative to HEAD used in the from_commit and
+// to_commit filters of the table are resolved against the current branch of |rsr|.
+func NewCommitDiffTable(ctx *sql.Context, tblName string, ddb *doltdb.DoltDB, rsr env.RepoStateReader, root *doltdb.RootValue) (sql.Table, error) {
 	diffTblName := doltdb.DoltCommitDiffTablePrefix + tblName
 
 	ss, err := calcSuperDuperSchema(ctx, ddb, root, tblName)
@@ -107,8 +114,10 @@ func NewCommitDiffTable(ctx *sql.Context, tblName string, ddb *doltdb.DoltDB, ro
 	return &CommitDiffTable{
 		name:        tblName,
 		ddb:         ddb,
+		rsr:         rsr,
 		workingRoot: root,
 		ss:          ss,
+		ssSch:       sch,
 		joiner:      j,
 		sqlSch:      sqlSch,
 	}, nil
@@ -221,13 +230,18 @@ func (dt *CommitDiffTable) Partitions(ctx *sql.Context) (sql.PartitionIter, erro
 		return nil, fmt.Errorf("error querying table %s: %w", dt.Name(), ErrExactlyOneFromCommit)
 	}
 
-	toRoot, toName, toDate, err := dt.rootValForFilter(ctx, dt.toCommitFilter)
+	var cwb ref.DoltRef
+	if dt.rsr != nil {
+		cwb = dt.rsr.CWBHeadRef()
+	}
+
+	toRoot, toName, toDate, err := rootValForFilter(ctx, dt.ddb, dt.workingRoot, cwb, dt.toCommitFilter)
 
 	if err != nil {
 		return nil, err
 	}
 
-	fromRoot, fromName, fromDate, err := dt.rootValForFilter(ctx, dt.fromCommitFilter)
+	fromRoot, fromName, fromDate, err := rootValForFilter(ctx, dt.ddb, dt.workingRoot, cwb, dt.fromCommitFilter)
 
 	if err != nil {
 		return nil, err
@@ -245,6 +259,12 @@ func (dt *CommitDiffTable) Partitions(ctx *sql.Context) (sql.PartitionIter, erro
 		return nil, err
 	}
 
+	lookup, err := dt.pkLookupForFilters(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
 	return NewSliceOfPartitionsItr([]sql.Partition{diffPartition{
 		to:       toTable,
 		from:     fromTable,
@@ -252,10 +272,54 @@ func (dt *CommitDiffTable) Partitions(ctx *sql.Context) (sql.PartitionIter, erro
 		fromName: fromName,
 		toDate:   toDate,
 		fromDate: fromDate,
+		lookup:   lookup,
 	}}), nil
 }
 
-func (dt *CommitDiffTable) rootValForFilter(ctx *sql.Context, eqFilter *expression.Equals) (*doltdb.RootValue, string, *types.Timestamp, error) {
+// pkLookupForFilters evaluates the equality filters on the to_ and from_ columns of the table which were seen by
+// HandledFilters, and returns the values keyed by column tag.
+func (dt *CommitDiffTable) pkLookupForFilters(ctx *sql.Context) (pkLookup, error) {
+	if len(dt.pkFilters) == 0 {
+		return nil, nil
+	}
+
+	lookup := make(pkLookup)
+	for _, eqFilter := range dt.pkFilters {
+		gf, nonGF := eqFilter.Left(), eqFilter.Right()
+		if _, ok := gf.(*expression.GetField); !ok {
+			nonGF, gf = eqFilter.Left(), eqFilter.Right()
+		}
+
+		name := strings.ToLower(gf.(*expression.GetField).Name())
+		if strings.HasPrefix(name, diff.To+"_") {
+			name = name[len(diff.To)+1:]
+		} else {
+			name = name[len(diff.From)+1:]
+		}
+
+		col, ok := dt.ssSch.GetAllCols().LowerNameToCol[name]
+		if !ok {
+			continue
+		}
+
+		val, err := nonGF.Eval(ctx, nil)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if val != nil {
+			lookup[col.Tag] = val
+		}
+	}
+
+	return lookup, nil
+}
+
+// rootValForFilter returns the root value, commit name and commit date for the commit spec compared against in
+// |eqFilter|. The special value "working" resolves to |workingRoot|, which has no commit date. Commit specs relative to
+// HEAD are resolved against |cwb|, and are an error if it is nil.
+func rootValForFilter(ctx *sql.Context, ddb *doltdb.DoltDB, workingRoot *doltdb.RootValue, cwb ref.DoltRef, eqFilter *expression.Equals) (*doltdb.RootValue, string, *types.Timestamp, error) {
 	gf, nonGF := eqFilter.Left(), eqFilter.Right()
 	if _, ok := gf.(*expression.GetField); !ok {
 		nonGF, gf = eqFilter.Left(), eqFilter.Right()
@@ -276,7 +340,7 @@ func (dt *CommitDiffTable) rootValForFilter(ctx *sql.Context, eqFilter *expressi
 	var root *doltdb.RootValue
 	var commitTime *types.Timestamp
 	if strings.ToLower(hashStr) == "working" {
-		root = dt.workingRoot
+		root = workingRoot
 	} else {
 		cs, err := doltdb.NewCommitSpec(hashStr)
 
@@ -284,7 +348,11 @@ func (dt *CommitDiffTable) rootValForFilter(ctx *sql.Context, eqFilter *expressi
 			return nil, "", nil, err
 		}
 
-		cm, err := dt.ddb.Resolve(ctx, cs, nil)
+		if cwb == nil && strings.HasPrefix(strings.ToUpper(hashStr), "HEAD") {
+			return nil, "", nil, fmt.Errorf("unable to resolve '%s' without a current branch", hashStr)
+		}
+
+		cm, err := ddb.Resolve(ctx, cs, cwb)
 
 		if err != nil {
 			return nil, "", nil, err
@@ -340,12 +408,55 @@ func (dt *CommitDiffTable) HandledFilters(filters []sql.Expression) []sql.Expres
 
 		if isCommitFilter {
 			commitFilters = append(commitFilters, filter)
+		} else if isPkLookupFilter(filter) {
+			// these are only used to narrow down the rows which are diffed, so they are not reported as handled
+			dt.pkFilters = append(dt.pkFilters, filter.(*expression.Equals))
 		}
 	}
 
 	return commitFilters
 }
 
+// isPkLookupFilter returns whether |filter| compares a to_ or from_ column of the table to a value which does not
+// depend on the row being filtered.
+func isPkLookupFilter(filter sql.Expression) bool {
+	eqFilter, ok := filter.(*expression.Equals)
+	if !ok {
+		return false
+	}
+
+	gf, nonGF := eqFilter.Left(), eqFilter.Right()
+	if _, ok := gf.(*expression.GetField); !ok {
+		nonGF, gf = eqFilter.Left(), eqFilter.Right()
+	}
+
+	gfExpr, ok := gf.(*expression.GetField)
+	if !ok {
+		return false
+	}
+
+	isRowIndependent := true
+	sql.Inspect(nonGF, func(e sql.Expression) bool {
+		switch e.(type) {
+		case *expression.GetField, *expression.UnresolvedColumn:
+			isRowIndependent = false
+		}
+		return isRowIndependent
+	})
+
+	if !isRowIndependent {
+		return false
+	}
+
+	name := strings.ToLower(gfExpr.Name())
+	switch name {
+	case toCommit, fromCommit, toCommitDate, fromCommitDate, diffTypeColName:
+		return false
+	}
+
+	return strings.HasPrefix(name, diff.To+"_") || strings.HasPrefix(name, diff.From+"_")
+}
+
 // Filters returns the list of filters that are applied to this table.
 func (dt *CommitDiffTable) Filters() []sql.Expression {
 	if dt.toCommitFilter == nil || dt.fromCommitFilter == nil {
@@ -364,3 +475,63 @@ func (dt *CommitDiffTable) PartitionRows(ctx *sql.Context, part sql.Partition) (
 	dp := part.(diffPartition)
 	return dp.getRowIter(ctx, dt.ddb, dt.ss, dt.joiner)
 }
+
+// pkLookup holds values, keyed by column tag, which the rows of a diff are being filtered to. When it contains a value
+// for every primary key column of a table, only the row with that key needs to be read from the table's row data.
+type pkLookup map[uint64]interface{}
+
+// filterMap returns a map containing only the row of |m| whose key is given by the lookup. If the lookup does not
+// contain every primary key column of |sch|, or its values can't be converted to a key exactly, |m| is returned as is.
+func (l pkLookup) filterMap(ctx context.Context, vrw types.ValueReadWriter, m types.Map, sch schema.Schema) (types.Map, error) {
+	pkCols := sch.GetPKCols()
+	if schema.IsKeyless(sch) || pkCols.Size() == 0 {
+		return m, nil
+	}
+
+	var keyVals []types.Value
+	err := pkCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		val, ok := l[tag]
+		if !ok {
+			return true, nil
+		}
+
+		// only integer keys are looked up, as other types may compare equal to values with a different encoding
+		switch col.Kind {
+		case types.IntKind, types.UintKind:
+		default:
+			return true, nil
+		}
+
+		nomsVal, err := col.TypeInfo.ConvertValueToNomsValue(ctx, vrw, val)
+		if err != nil {
+			return true, nil
+		}
+
+		keyVals = append(keyVals, types.Uint(tag), nomsVal)
+		return false, nil
+	})
+
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	if len(keyVals) != 2*pkCols.Size() {
+		return m, nil
+	}
+
+	key, err := types.NewTuple(m.Format(), keyVals...)
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	val, ok, err := m.MaybeGet(ctx, key)
+	if err != nil {
+		return types.EmptyMap, err
+	}
+
+	if !ok {
+		return types.NewMap(ctx, vrw)
+	}
+
+	return types.NewMap(ctx, vrw, key, val)
+}
@@ -0,0 +1,114 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+func TestPkLookupFilterMap(t *testing.T) {
+	ctx := context.Background()
+	vrw := types.NewMemoryValueStore()
+
+	oneIntPKMap, err := mapFromRows(ctx, vrw, oneIntPKSch, genOneIntPKRows(int64Range(0, 20, 1)...)...)
+	require.NoError(t, err)
+	twoIntPKMap, err := mapFromRows(ctx, vrw, twoIntPKSch, genTwoIntPKRows(int64TupleGen(map[int64][]int64{
+		0: {0, 1, 2},
+		1: {0, 1, 2},
+	})...)...)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		lookup       pkLookup
+		sch          schema.Schema
+		m            types.Map
+		expectedRows []row.Row
+		unfiltered   bool
+	}{
+		{
+			name:         "one pk",
+			lookup:       pkLookup{pk0Tag: int64(10)},
+			sch:          oneIntPKSch,
+			m:            oneIntPKMap,
+			expectedRows: genOneIntPKRows(10),
+		},
+		{
+			name:         "one pk converted from another integer type",
+			lookup:       pkLookup{pk0Tag: int8(3)},
+			sch:          oneIntPKSch,
+			m:            oneIntPKMap,
+			expectedRows: genOneIntPKRows(3),
+		},
+		{
+			name:   "one pk missing key",
+			lookup: pkLookup{pk0Tag: int64(100)},
+			sch:    oneIntPKSch,
+			m:      oneIntPKMap,
+		},
+		{
+			name:       "non pk column",
+			lookup:     pkLookup{c1Tag: int64(10)},
+			sch:        oneIntPKSch,
+			m:          oneIntPKMap,
+			unfiltered: true,
+		},
+		{
+			name:         "two pks",
+			lookup:       pkLookup{pk0Tag: int64(1), pk1Tag: int64(2)},
+			sch:          twoIntPKSch,
+			m:            twoIntPKMap,
+			expectedRows: genTwoIntPKRows([2]int64{1, 2}),
+		},
+		{
+			name:       "partial key",
+			lookup:     pkLookup{pk0Tag: int64(1)},
+			sch:        twoIntPKSch,
+			m:          twoIntPKMap,
+			unfiltered: true,
+		},
+		{
+			name:       "value not convertible to key",
+			lookup:     pkLookup{pk0Tag: "not a number"},
+			sch:        oneIntPKSch,
+			m:          oneIntPKMap,
+			unfiltered: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.lookup.filterMap(ctx, vrw, test.m, test.sch)
+			require.NoError(t, err)
+
+			if test.unfiltered {
+				assert.True(t, res.Equals(test.m))
+				return
+			}
+
+			expected, err := mapFromRows(ctx, vrw, test.sch, test.expectedRows...)
+			require.NoError(t, err)
+			assert.True(t, res.Equals(expected))
+		})
+	}
+}
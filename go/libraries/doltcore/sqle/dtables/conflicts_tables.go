@@ -15,15 +15,23 @@ package dtables
 // limitations under the License.
 
 import (
+	"fmt"
+
 	"github.com/dolthub/go-mysql-server/sql"
 
 	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
 	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/row"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
 	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/libraries/doltcore/table/editor"
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
 )
 
 var _ sql.Table = ConflictsTable{}
+var _ sql.DeletableTable = ConflictsTable{}
+var _ sql.UpdatableTable = ConflictsTable{}
 
 // ConflictsTable is a sql.Table implementation that provides access to the conflicts that exist for a user table
 type ConflictsTable struct {
@@ -32,15 +40,22 @@ type ConflictsTable struct {
 	root    *doltdb.RootValue
 	tbl     *doltdb.Table
 	rd      *merge.ConflictReader
-	rs      RootSetter
+	rs      RootEditor
 }
 
 type RootSetter interface {
 	SetRoot(ctx *sql.Context, root *doltdb.RootValue) error
 }
 
+// RootEditor is a RootSetter which also provides the TableEditSession used to edit the tables of the root within the
+// session's transaction.
+type RootEditor interface {
+	RootSetter
+	TableEditSession(ctx *sql.Context) *editor.TableEditSession
+}
+
 // NewConflictsTable returns a new ConflictsTableTable instance
-func NewConflictsTable(ctx *sql.Context, tblName string, root *doltdb.RootValue, rs RootSetter) (sql.Table, error) {
+func NewConflictsTable(ctx *sql.Context, tblName string, root *doltdb.RootValue, rs RootEditor) (sql.Table, error) {
 	tbl, ok, err := root.GetTable(ctx, tblName)
 
 	if err != nil {
@@ -102,6 +117,12 @@ func (ct ConflictsTable) Deleter(*sql.Context) sql.RowDeleter {
 	return &conflictDeleter{ct: ct, rs: ct.rs}
 }
 
+// Updater returns a RowUpdater for this table. Updating the our_ columns of a conflict writes the updated values to the
+// working table, and updates our version of the row in the conflict. The conflict remains until it is deleted.
+func (ct ConflictsTable) Updater(*sql.Context) sql.RowUpdater {
+	return &conflictUpdater{ct: ct, rs: ct.rs, updates: make(map[hash.Hash]conflictUpdate)}
+}
+
 type conflictRowIter struct {
 	ctx *sql.Context
 	rd  *merge.ConflictReader
@@ -170,3 +191,228 @@ func (cd *conflictDeleter) Close(ctx *sql.Context) error {
 
 	return cd.rs.SetRoot(ctx, updatedRoot)
 }
+
+var _ sql.RowUpdater = &conflictUpdater{}
+
+// conflictUpdate is our new version of a conflicting row. A nil row means the row is deleted.
+type conflictUpdate struct {
+	key types.Tuple
+	r   row.Row
+}
+
+type conflictUpdater struct {
+	ct      ConflictsTable
+	rs      RootEditor
+	updates map[hash.Hash]conflictUpdate
+}
+
+// Update updates our version of the conflicting row |old| to the our_ columns of |new|. Setting all of the our_
+// columns to NULL deletes the row. Update will be called once for each row to process for the update operation, which
+// may involve many rows. After all rows have been processed, Close is called.
+func (cu *conflictUpdater) Update(ctx *sql.Context, old sql.Row, new sql.Row) error {
+	cnfSch := cu.ct.rd.GetSchema()
+	vrw := cu.ct.tbl.ValueReadWriter()
+
+	oldCnfRow, err := sqlutil.SqlRowToDoltRow(ctx, vrw, old, cnfSch)
+
+	if err != nil {
+		return err
+	}
+
+	key, err := cu.ct.rd.GetKeyForConflict(ctx, oldCnfRow)
+
+	if err != nil {
+		return err
+	}
+
+	newCnfRow, err := sqlutil.SqlRowToDoltRow(ctx, vrw, new, cnfSch)
+
+	if err != nil {
+		return err
+	}
+
+	ours, oursSch, ok, err := cu.ct.rd.GetOurs(newCnfRow)
+
+	if err != nil {
+		return err
+	}
+
+	keyHash, err := key.Hash(cu.ct.tbl.Format())
+
+	if err != nil {
+		return err
+	}
+
+	update := conflictUpdate{key: key.(types.Tuple)}
+	if ok {
+		update.r, err = oursWithKey(update.key, ours, oursSch)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	cu.updates[keyHash] = update
+	return nil
+}
+
+// oursWithKey returns a row of |sch| with the primary key |key| and the non-primary key values of |ours|.
+func oursWithKey(key types.Tuple, ours row.Row, sch schema.Schema) (row.Row, error) {
+	keyVals, err := row.ParseTaggedValues(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = ours.IterCols(func(tag uint64, val types.Value) (stop bool, err error) {
+		if _, ok := sch.GetPKCols().GetByTag(tag); !ok {
+			keyVals[tag] = val
+		}
+		return false, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return row.New(key.Format(), sch, keyVals)
+}
+
+// Close finalizes the update operation, writing the updated rows to the working table and updating the conflicts.
+func (cu *conflictUpdater) Close(ctx *sql.Context) error {
+	if len(cu.updates) == 0 {
+		return nil
+	}
+
+	sch, err := cu.ct.tbl.GetSchema(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if schema.IsKeyless(sch) {
+		return fmt.Errorf("updating the conflicts of keyless table %s is not supported", cu.ct.tblName)
+	}
+
+	rowData, err := cu.ct.tbl.GetRowData(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	tes := cu.rs.TableEditSession(ctx)
+	tblEd, err := tes.GetTableEditor(ctx, cu.ct.tblName, sch)
+
+	if err != nil {
+		return err
+	}
+
+	for _, update := range cu.updates {
+		val, ok, err := rowData.MaybeGetTuple(ctx, update.key)
+
+		if err != nil {
+			return err
+		}
+
+		var current row.Row
+		if ok {
+			current, err = row.FromNoms(sch, update.key, val)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case current != nil && update.r != nil:
+			err = tblEd.UpdateRow(ctx, current, update.r)
+		case current != nil:
+			err = tblEd.DeleteRow(ctx, current)
+		case update.r != nil:
+			err = tblEd.InsertRow(ctx, update.r)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	root, err := tes.Flush(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	tbl, ok, err := root.GetTable(ctx, cu.ct.tblName)
+
+	if err != nil {
+		return err
+	} else if !ok {
+		return sql.ErrTableNotFound.New(cu.ct.tblName)
+	}
+
+	tbl, err = cu.updateConflicts(ctx, tbl, sch)
+
+	if err != nil {
+		return err
+	}
+
+	root, err = root.PutTable(ctx, cu.ct.tblName, tbl)
+
+	if err != nil {
+		return err
+	}
+
+	return cu.rs.SetRoot(ctx, root)
+}
+
+// updateConflicts sets our version of each updated conflicting row in the conflicts of |tbl|.
+func (cu *conflictUpdater) updateConflicts(ctx *sql.Context, tbl *doltdb.Table, sch schema.Schema) (*doltdb.Table, error) {
+	schemas, confData, err := tbl.GetConflicts(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	confEd := confData.Edit()
+	for _, update := range cu.updates {
+		val, ok, err := confData.MaybeGet(ctx, update.key)
+
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			continue
+		}
+
+		cnf, err := doltdb.ConflictFromTuple(val.(types.Tuple))
+
+		if err != nil {
+			return nil, err
+		}
+
+		cnf.Value = types.NullValue
+		if update.r != nil {
+			cnf.Value, err = update.r.NomsMapValue(sch).Value(ctx)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		cnfTpl, err := cnf.ToNomsList(tbl.ValueReadWriter())
+
+		if err != nil {
+			return nil, err
+		}
+
+		confEd.Set(update.key, cnfTpl)
+	}
+
+	confData, err = confEd.Map(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tbl.SetConflicts(ctx, schemas, confData)
+}
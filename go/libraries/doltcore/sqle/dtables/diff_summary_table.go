@@ -0,0 +1,259 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/dolthub/go-mysql-server/sql/expression"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/ref"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const tableNameColName = "table_name"
+
+var ErrDiffRangeToCommit = errors.New("dolt_diff_summary and dolt_schema_diff tables must be filtered to a single 'to_commit'")
+var ErrDiffRangeFromCommit = errors.New("dolt_diff_summary and dolt_schema_diff tables must be filtered to a single 'from_commit'")
+
+// diffRangeFilters holds the filters pushed down to the tables which summarize the differences between two commits.
+// The from_commit and to_commit filters are required, and an optional table_name filter restricts the tables that are
+// diffed. The table_name filter is not reported as handled, so it is still applied to the returned rows.
+type diffRangeFilters struct {
+	fromCommitFilter  *expression.Equals
+	toCommitFilter    *expression.Equals
+	tableNameFilter   *expression.Equals
+	requiredFilterErr error
+}
+
+func (f *diffRangeFilters) handledFilters(filters []sql.Expression) []sql.Expression {
+	var commitFilters []sql.Expression
+	for _, filter := range filters {
+		eqFilter, isEquality := filter.(*expression.Equals)
+		if !isEquality {
+			continue
+		}
+
+		for _, e := range []sql.Expression{eqFilter.Left(), eqFilter.Right()} {
+			if val, ok := e.(*expression.GetField); ok {
+				switch strings.ToLower(val.Name()) {
+				case toCommit:
+					if f.toCommitFilter != nil {
+						f.requiredFilterErr = ErrDiffRangeToCommit
+					}
+
+					f.toCommitFilter = eqFilter
+					commitFilters = append(commitFilters, filter)
+				case fromCommit:
+					if f.fromCommitFilter != nil {
+						f.requiredFilterErr = ErrDiffRangeFromCommit
+					}
+
+					f.fromCommitFilter = eqFilter
+					commitFilters = append(commitFilters, filter)
+				case tableNameColName:
+					f.tableNameFilter = eqFilter
+				}
+			}
+		}
+	}
+
+	return commitFilters
+}
+
+func (f *diffRangeFilters) filters() []sql.Expression {
+	if f.toCommitFilter == nil || f.fromCommitFilter == nil {
+		return nil
+	}
+
+	return []sql.Expression{f.toCommitFilter, f.fromCommitFilter}
+}
+
+// tableDeltas resolves the from and to commits of the filters and returns their names along with the deltas of the
+// tables changed between them, ordered by table name.
+func (f *diffRangeFilters) tableDeltas(ctx *sql.Context, tblName string, ddb *doltdb.DoltDB, rsr env.RepoStateReader, workingRoot *doltdb.RootValue) (string, string, []diff.TableDelta, error) {
+	if f.requiredFilterErr != nil {
+		return "", "", nil, fmt.Errorf("error querying table %s: %w", tblName, f.requiredFilterErr)
+	} else if f.toCommitFilter == nil {
+		return "", "", nil, fmt.Errorf("error querying table %s: %w", tblName, ErrDiffRangeToCommit)
+	} else if f.fromCommitFilter == nil {
+		return "", "", nil, fmt.Errorf("error querying table %s: %w", tblName, ErrDiffRangeFromCommit)
+	}
+
+	var cwb ref.DoltRef
+	if rsr != nil {
+		cwb = rsr.CWBHeadRef()
+	}
+
+	toRoot, toName, _, err := rootValForFilter(ctx, ddb, workingRoot, cwb, f.toCommitFilter)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	fromRoot, fromName, _, err := rootValForFilter(ctx, ddb, workingRoot, cwb, f.fromCommitFilter)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	deltas, err := diff.GetTableDeltas(ctx, fromRoot, toRoot)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	if f.tableNameFilter != nil {
+		nonGF := f.tableNameFilter.Right()
+		if _, ok := nonGF.(*expression.GetField); ok {
+			nonGF = f.tableNameFilter.Left()
+		}
+
+		val, err := nonGF.Eval(ctx, nil)
+		if err != nil {
+			return "", "", nil, err
+		}
+
+		if name, ok := val.(string); ok {
+			var filtered []diff.TableDelta
+			for _, td := range deltas {
+				if strings.EqualFold(td.FromName, name) || strings.EqualFold(td.ToName, name) {
+					filtered = append(filtered, td)
+				}
+			}
+			deltas = filtered
+		}
+	}
+
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].CurName() < deltas[j].CurName()
+	})
+
+	return fromName, toName, deltas, nil
+}
+
+var _ sql.Table = (*DiffSummaryTable)(nil)
+var _ sql.FilteredTable = (*DiffSummaryTable)(nil)
+
+// DiffSummaryTable is a sql.Table implementation of a system table which reports the number of rows and cells changed
+// in each table between a from_commit and a to_commit. Tables whose schema changed but none of whose rows did are
+// reported with zero changed rows.
+type DiffSummaryTable struct {
+	ddb         *doltdb.DoltDB
+	rsr         env.RepoStateReader
+	workingRoot *doltdb.RootValue
+	filters     *diffRangeFilters
+}
+
+// NewDiffSummaryTable creates a DiffSummaryTable
+func NewDiffSummaryTable(_ *sql.Context, ddb *doltdb.DoltDB, rsr env.RepoStateReader, root *doltdb.RootValue) sql.Table {
+	return &DiffSummaryTable{ddb: ddb, rsr: rsr, workingRoot: root, filters: &diffRangeFilters{}}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// DiffSummaryTableName
+func (dt *DiffSummaryTable) Name() string {
+	return doltdb.DiffSummaryTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// DiffSummaryTableName
+func (dt *DiffSummaryTable) String() string {
+	return doltdb.DiffSummaryTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the diff summary system table.
+func (dt *DiffSummaryTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: fromCommit, Type: sql.Text, Source: doltdb.DiffSummaryTableName, PrimaryKey: true},
+		{Name: toCommit, Type: sql.Text, Source: doltdb.DiffSummaryTableName, PrimaryKey: true},
+		{Name: tableNameColName, Type: sql.Text, Source: doltdb.DiffSummaryTableName, PrimaryKey: true},
+		{Name: "rows_unmodified", Type: sql.Uint64, Source: doltdb.DiffSummaryTableName, Nullable: true},
+		{Name: "rows_added", Type: sql.Uint64, Source: doltdb.DiffSummaryTableName},
+		{Name: "rows_deleted", Type: sql.Uint64, Source: doltdb.DiffSummaryTableName},
+		{Name: "rows_modified", Type: sql.Uint64, Source: doltdb.DiffSummaryTableName, Nullable: true},
+		{Name: "cells_modified", Type: sql.Uint64, Source: doltdb.DiffSummaryTableName, Nullable: true},
+		{Name: "old_row_count", Type: sql.Uint64, Source: doltdb.DiffSummaryTableName, Nullable: true},
+		{Name: "new_row_count", Type: sql.Uint64, Source: doltdb.DiffSummaryTableName, Nullable: true},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (dt *DiffSummaryTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(types.Map{}), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition.
+func (dt *DiffSummaryTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	fromName, toName, deltas, err := dt.filters.tableDeltas(ctx, dt.Name(), dt.ddb, dt.rsr, dt.workingRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sql.Row
+	for _, td := range deltas {
+		keyless, err := td.IsKeyless(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		acc, err := diff.SummarizeTableDelta(ctx, td)
+		if err != nil {
+			return nil, err
+		}
+
+		// tables whose schema changed are reported even if none of their rows did
+		if acc.Adds+acc.Removes+acc.Changes == 0 {
+			schemaChanged, err := td.HasSchemaChanged(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			if !schemaChanged {
+				continue
+			}
+		}
+
+		if keyless {
+			// keyless tables have no notion of a modified row
+			rows = append(rows, sql.NewRow(fromName, toName, td.CurName(), nil, acc.Adds, acc.Removes, nil, nil, nil, nil))
+		} else {
+			unmodified := acc.OldSize - acc.Changes - acc.Removes
+			rows = append(rows, sql.NewRow(fromName, toName, td.CurName(), unmodified, acc.Adds, acc.Removes, acc.Changes, acc.CellChanges, acc.OldSize, acc.NewSize))
+		}
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// HandledFilters returns the list of filters that will be handled by the table itself
+func (dt *DiffSummaryTable) HandledFilters(filters []sql.Expression) []sql.Expression {
+	return dt.filters.handledFilters(filters)
+}
+
+// Filters returns the list of filters that are applied to this table.
+func (dt *DiffSummaryTable) Filters() []sql.Expression {
+	return dt.filters.filters()
+}
+
+// WithFilters returns a new sql.Table instance with the filters applied
+func (dt *DiffSummaryTable) WithFilters(filters []sql.Expression) sql.Table {
+	return dt
+}
@@ -320,6 +320,8 @@ type diffPartition struct {
 	fromName string
 	toDate   *types.Timestamp
 	fromDate *types.Timestamp
+	// lookup, when non-nil, restricts the diff to the rows with the primary key it contains
+	lookup pkLookup
 }
 
 func (dp diffPartition) Key() []byte {
@@ -339,6 +341,20 @@ func (dp diffPartition) getRowIter(ctx *sql.Context, ddb *doltdb.DoltDB, ss *sch
 		return nil, err
 	}
 
+	if dp.lookup != nil {
+		fromData, err = dp.lookup.filterMap(ctx, ddb.ValueReadWriter(), fromData, fromSch)
+
+		if err != nil {
+			return nil, err
+		}
+
+		toData, err = dp.lookup.filterMap(ctx, ddb.ValueReadWriter(), toData, toSch)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	vrw := types.NewMemoryValueStore() // We're displaying here, so all values that require a VRW will use an internal one
 
 	fromConv, err := rowConvForSchema(ctx, vrw, ss, fromSch)
@@ -493,7 +509,7 @@ func (dp *diffPartitions) processCommit(ctx *sql.Context, cmHash hash.Hash, cm *
 
 	var nextPartition *diffPartition
 	if tblHash != toInfoForCommit.tblHash {
-		partition := diffPartition{toInfoForCommit.tbl, tbl, toInfoForCommit.name, cmHashStr, toInfoForCommit.date, &ts}
+		partition := diffPartition{toInfoForCommit.tbl, tbl, toInfoForCommit.name, cmHashStr, toInfoForCommit.date, &ts, nil}
 		selected, err := dp.selectFunc(ctx, partition)
 
 		if err != nil {
@@ -0,0 +1,130 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"time"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+// ReplicationStatus is the status of the replication of a branch to a remote.
+type ReplicationStatus struct {
+	// Branch is the name of the replicated branch.
+	Branch string
+	// Remote is the name of the remote the branch is replicated to.
+	Remote string
+	// HeadHash is the hash of the latest commit of the branch which was queued to be replicated.
+	HeadHash string
+	// ReplicatedHash is the hash of the latest commit of the branch which was pushed to the remote.
+	ReplicatedHash string
+	// Lag is how long the oldest change that was not yet pushed to the remote has been waiting. It is zero when
+	// the remote is up to date.
+	Lag time.Duration
+	// LastReplicated is when the branch was last pushed to the remote.
+	LastReplicated time.Time
+	// LastError is the error of the last attempt to push the branch, if it failed.
+	LastError string
+}
+
+// ReplicationStatusReader provides the status of the replication of the branches of a database.
+type ReplicationStatusReader interface {
+	// ReplicationStatus returns the status of the replication of each replicated branch of the database |dbName|.
+	ReplicationStatus(dbName string) []ReplicationStatus
+}
+
+var _ sql.Table = (*ReplicationStatusTable)(nil)
+
+// ReplicationStatusTable is a sql.Table implementation of a system table which shows how far behind the remote that
+// a database is replicated to is.
+type ReplicationStatusTable struct {
+	dbName string
+	rsr    ReplicationStatusReader
+}
+
+// NewReplicationStatusTable creates a ReplicationStatusTable. |rsr| may be nil if the database is not replicated, in
+// which case the table is empty.
+func NewReplicationStatusTable(_ *sql.Context, dbName string, rsr ReplicationStatusReader) sql.Table {
+	return &ReplicationStatusTable{dbName: dbName, rsr: rsr}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// ReplicationStatusTableName
+func (rt *ReplicationStatusTable) Name() string {
+	return doltdb.ReplicationStatusTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// ReplicationStatusTableName
+func (rt *ReplicationStatusTable) String() string {
+	return doltdb.ReplicationStatusTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the replication status system table.
+func (rt *ReplicationStatusTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "branch", Type: sql.Text, Source: doltdb.ReplicationStatusTableName, PrimaryKey: true},
+		{Name: "remote", Type: sql.Text, Source: doltdb.ReplicationStatusTableName},
+		{Name: "head_hash", Type: sql.Text, Source: doltdb.ReplicationStatusTableName, Nullable: true},
+		{Name: "replicated_hash", Type: sql.Text, Source: doltdb.ReplicationStatusTableName, Nullable: true},
+		{Name: "lag_seconds", Type: sql.Float64, Source: doltdb.ReplicationStatusTableName},
+		{Name: "last_replicated", Type: sql.Datetime, Source: doltdb.ReplicationStatusTableName, Nullable: true},
+		{Name: "last_error", Type: sql.Text, Source: doltdb.ReplicationStatusTableName, Nullable: true},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (rt *ReplicationStatusTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(types.Map{}), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition.
+func (rt *ReplicationStatusTable) PartitionRows(*sql.Context, sql.Partition) (sql.RowIter, error) {
+	if rt.rsr == nil {
+		return sql.RowsToRowIter(), nil
+	}
+
+	var rows []sql.Row
+	for _, status := range rt.rsr.ReplicationStatus(rt.dbName) {
+		var lastReplicated interface{}
+		if !status.LastReplicated.IsZero() {
+			lastReplicated = status.LastReplicated
+		}
+
+		rows = append(rows, sql.NewRow(
+			status.Branch,
+			status.Remote,
+			nullIfEmpty(status.HeadHash),
+			nullIfEmpty(status.ReplicatedHash),
+			status.Lag.Seconds(),
+			lastReplicated,
+			nullIfEmpty(status.LastError),
+		))
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+
+	return s
+}
@@ -0,0 +1,179 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"strings"
+
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/merge"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+var _ sql.Table = (*SchemaConflictsTable)(nil)
+var _ sql.DeletableTable = (*SchemaConflictsTable)(nil)
+
+// SchemaConflictsTable is a sql.Table implementation of a system table which has a row for every table whose schema
+// conflicted during a merge. Deleting a row resolves the conflict by merging their version of the table into the
+// working table, which must first be altered so that its schema no longer conflicts.
+type SchemaConflictsTable struct {
+	root *doltdb.RootValue
+	rs   RootEditor
+}
+
+// NewSchemaConflictsTable creates a SchemaConflictsTable
+func NewSchemaConflictsTable(_ *sql.Context, root *doltdb.RootValue, rs RootEditor) sql.Table {
+	return &SchemaConflictsTable{root: root, rs: rs}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// SchemaConflictsTableName
+func (sct *SchemaConflictsTable) Name() string {
+	return doltdb.SchemaConflictsTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// SchemaConflictsTableName
+func (sct *SchemaConflictsTable) String() string {
+	return doltdb.SchemaConflictsTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the schema conflicts system table.
+func (sct *SchemaConflictsTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: "table_name", Type: sql.Text, Source: doltdb.SchemaConflictsTableName, PrimaryKey: true},
+		{Name: "base_schema", Type: sql.Text, Source: doltdb.SchemaConflictsTableName, PrimaryKey: false},
+		{Name: "our_schema", Type: sql.Text, Source: doltdb.SchemaConflictsTableName, PrimaryKey: false},
+		{Name: "their_schema", Type: sql.Text, Source: doltdb.SchemaConflictsTableName, PrimaryKey: false},
+		{Name: "description", Type: sql.Text, Source: doltdb.SchemaConflictsTableName, PrimaryKey: false},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (sct *SchemaConflictsTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(types.Map{}), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition
+func (sct *SchemaConflictsTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	tblNames, err := sct.root.TablesWithSchemaConflicts(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]sql.Row, 0, len(tblNames))
+	for _, tblName := range tblNames {
+		tbl, _, err := sct.root.GetTable(ctx, tblName)
+
+		if err != nil {
+			return nil, err
+		}
+
+		base, ours, theirs, err := tbl.GetSchemaConflict(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		r := sql.Row{tblName}
+		for _, t := range []*doltdb.Table{base, ours, theirs} {
+			sch, err := t.GetSchema(ctx)
+
+			if err != nil {
+				return nil, err
+			}
+
+			r = append(r, sqlfmt.CreateTableStmt(tblName, sch))
+		}
+
+		// the conflicts are described against the working table, so that altering it shows what is left to resolve
+		sc, err := merge.SchemaConflictOf(ctx, tblName, tbl, theirs, base)
+
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, append(r, describeSchemaConflict(sc)))
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+func describeSchemaConflict(sc merge.SchemaConflict) string {
+	descs := make([]string, 0, sc.Count())
+	for _, c := range sc.ColConflicts {
+		descs = append(descs, c.String())
+	}
+	for _, c := range sc.IdxConflicts {
+		descs = append(descs, c.String())
+	}
+
+	return strings.Join(descs, "\n")
+}
+
+// Deleter returns a RowDeleter for this table. Deleting the row of a table merges their version of the table into the
+// working table, resolving its schema conflict.
+func (sct *SchemaConflictsTable) Deleter(*sql.Context) sql.RowDeleter {
+	return &schemaConflictDeleter{sct: sct}
+}
+
+var _ sql.RowDeleter = &schemaConflictDeleter{}
+
+type schemaConflictDeleter struct {
+	sct      *SchemaConflictsTable
+	tblNames []string
+}
+
+// Delete deletes the given row. Delete will be called once for each row to process for the delete operation, which
+// may involve many rows. After all rows have been processed, Close is called.
+func (sd *schemaConflictDeleter) Delete(_ *sql.Context, r sql.Row) error {
+	sd.tblNames = append(sd.tblNames, r[0].(string))
+	return nil
+}
+
+// Close finalizes the delete operation, persisting the result.
+func (sd *schemaConflictDeleter) Close(ctx *sql.Context) error {
+	if len(sd.tblNames) == 0 {
+		return nil
+	}
+
+	tes := sd.sct.rs.TableEditSession(ctx)
+	for _, tblName := range sd.tblNames {
+		tbl, _, err := sd.sct.root.GetTable(ctx, tblName)
+
+		if err != nil {
+			return err
+		}
+
+		_, err = merge.MergeSchemaConflict(ctx, tblName, tbl, tes)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	newRoot, err := tes.Flush(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return sd.sct.rs.SetRoot(ctx, newRoot)
+}
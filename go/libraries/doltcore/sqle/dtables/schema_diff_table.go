@@ -0,0 +1,182 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dtables
+
+import (
+	"github.com/dolthub/go-mysql-server/sql"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/diff"
+	"github.com/dolthub/dolt/go/libraries/doltcore/doltdb"
+	"github.com/dolthub/dolt/go/libraries/doltcore/env"
+	"github.com/dolthub/dolt/go/libraries/doltcore/schema"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlfmt"
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle/sqlutil"
+	"github.com/dolthub/dolt/go/store/types"
+)
+
+const (
+	schemaElementTable  = "table"
+	schemaElementColumn = "column"
+	schemaElementIndex  = "index"
+)
+
+var schemaDiffTypeNames = map[diff.SchemaChangeType]string{
+	diff.SchDiffAdded:    diffTypeAdded,
+	diff.SchDiffRemoved:  diffTypeRemoved,
+	diff.SchDiffModified: diffTypeModified,
+}
+
+var _ sql.Table = (*SchemaDiffTable)(nil)
+var _ sql.FilteredTable = (*SchemaDiffTable)(nil)
+
+// SchemaDiffTable is a sql.Table implementation of a system table which reports the tables, columns and indexes that
+// were added, removed or modified between a from_commit and a to_commit.
+type SchemaDiffTable struct {
+	ddb         *doltdb.DoltDB
+	rsr         env.RepoStateReader
+	workingRoot *doltdb.RootValue
+	filters     *diffRangeFilters
+}
+
+// NewSchemaDiffTable creates a SchemaDiffTable
+func NewSchemaDiffTable(_ *sql.Context, ddb *doltdb.DoltDB, rsr env.RepoStateReader, root *doltdb.RootValue) sql.Table {
+	return &SchemaDiffTable{ddb: ddb, rsr: rsr, workingRoot: root, filters: &diffRangeFilters{}}
+}
+
+// Name is a sql.Table interface function which returns the name of the table which is defined by the constant
+// SchemaDiffTableName
+func (dt *SchemaDiffTable) Name() string {
+	return doltdb.SchemaDiffTableName
+}
+
+// String is a sql.Table interface function which returns the name of the table which is defined by the constant
+// SchemaDiffTableName
+func (dt *SchemaDiffTable) String() string {
+	return doltdb.SchemaDiffTableName
+}
+
+// Schema is a sql.Table interface function that gets the sql.Schema of the schema diff system table.
+func (dt *SchemaDiffTable) Schema() sql.Schema {
+	return []*sql.Column{
+		{Name: fromCommit, Type: sql.Text, Source: doltdb.SchemaDiffTableName, PrimaryKey: true},
+		{Name: toCommit, Type: sql.Text, Source: doltdb.SchemaDiffTableName, PrimaryKey: true},
+		{Name: tableNameColName, Type: sql.Text, Source: doltdb.SchemaDiffTableName, PrimaryKey: true},
+		{Name: "element_type", Type: sql.Text, Source: doltdb.SchemaDiffTableName, PrimaryKey: true},
+		{Name: "element_name", Type: sql.Text, Source: doltdb.SchemaDiffTableName, PrimaryKey: true},
+		{Name: diffTypeColName, Type: sql.Text, Source: doltdb.SchemaDiffTableName},
+		{Name: "from_definition", Type: sql.Text, Source: doltdb.SchemaDiffTableName, Nullable: true},
+		{Name: "to_definition", Type: sql.Text, Source: doltdb.SchemaDiffTableName, Nullable: true},
+	}
+}
+
+// Partitions is a sql.Table interface function that returns a partition of the data. Currently the data is unpartitioned.
+func (dt *SchemaDiffTable) Partitions(*sql.Context) (sql.PartitionIter, error) {
+	return sqlutil.NewSinglePartitionIter(types.Map{}), nil
+}
+
+// PartitionRows is a sql.Table interface function that gets a row iterator for a partition.
+func (dt *SchemaDiffTable) PartitionRows(ctx *sql.Context, _ sql.Partition) (sql.RowIter, error) {
+	fromName, toName, deltas, err := dt.filters.tableDeltas(ctx, dt.Name(), dt.ddb, dt.rsr, dt.workingRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sql.Row
+	for _, td := range deltas {
+		tblName := td.CurName()
+		newRow := func(elementType, elementName, diffType string, fromDef, toDef interface{}) sql.Row {
+			return sql.NewRow(fromName, toName, tblName, elementType, elementName, diffType, fromDef, toDef)
+		}
+
+		if td.IsAdd() {
+			rows = append(rows, newRow(schemaElementTable, tblName, diffTypeAdded, nil, td.ToName))
+		} else if td.IsDrop() {
+			rows = append(rows, newRow(schemaElementTable, tblName, diffTypeRemoved, td.FromName, nil))
+		} else if td.IsRename() {
+			rows = append(rows, newRow(schemaElementTable, tblName, "renamed", td.FromName, td.ToName))
+		}
+
+		fromSch, toSch, err := td.GetSchemas(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		colDiffs, unionTags := diff.DiffSchColumns(fromSch, toSch)
+		for _, tag := range unionTags {
+			cd := colDiffs[tag]
+			if cd.DiffType == diff.SchDiffNone {
+				continue
+			}
+
+			name := cd.Old
+			if cd.New != nil {
+				name = cd.New
+			}
+
+			rows = append(rows, newRow(schemaElementColumn, name.Name, schemaDiffTypeNames[cd.DiffType], colDefinition(cd.Old), colDefinition(cd.New)))
+		}
+
+		for _, idxDiff := range diff.DiffSchIndexes(fromSch, toSch) {
+			if idxDiff.DiffType == diff.SchDiffNone {
+				continue
+			}
+
+			idx := idxDiff.From
+			if idxDiff.To != nil {
+				idx = idxDiff.To
+			}
+
+			rows = append(rows, newRow(schemaElementIndex, idx.Name(), schemaDiffTypeNames[idxDiff.DiffType], idxDefinition(idxDiff.From), idxDefinition(idxDiff.To)))
+		}
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+func colDefinition(col *schema.Column) interface{} {
+	if col == nil {
+		return nil
+	}
+
+	def := sqlfmt.FmtCol(0, 0, 0, *col)
+	if col.IsPartOfPK {
+		def += " PRIMARY KEY"
+	}
+
+	return def
+}
+
+func idxDefinition(idx schema.Index) interface{} {
+	if idx == nil {
+		return nil
+	}
+
+	return sqlfmt.FmtIndex(idx)
+}
+
+// HandledFilters returns the list of filters that will be handled by the table itself
+func (dt *SchemaDiffTable) HandledFilters(filters []sql.Expression) []sql.Expression {
+	return dt.filters.handledFilters(filters)
+}
+
+// Filters returns the list of filters that are applied to this table.
+func (dt *SchemaDiffTable) Filters() []sql.Expression {
+	return dt.filters.filters()
+}
+
+// WithFilters returns a new sql.Table instance with the filters applied
+func (dt *SchemaDiffTable) WithFilters(filters []sql.Expression) sql.Table {
+	return dt
+}
@@ -95,13 +95,19 @@ func newStatusItr(ctx *sql.Context, st *StatusTable) (*StatusItr, error) {
 		return &StatusItr{}, err
 	}
 
-	workingDocsInConflict, err := merge.GetDocsInConflict(ctx, ddb, rsr, drw)
+	workingTblsWithSchConflicts, err := merge.GetTablesWithSchemaConflicts(ctx, ddb, rsr)
 
 	if err != nil {
 		return &StatusItr{}, err
 	}
 
-	tLength := len(stagedTables) + len(unstagedTables) + len(stagedDocDiffs.Docs) + len(unStagedDocDiffs.Docs) + len(workingTblsInConflict) + len(workingDocsInConflict.Docs)
+	workingDocsInConflict, err := merge.GetDocsInConflict(ctx, ddb, rsr)
+
+	if err != nil {
+		return &StatusItr{}, err
+	}
+
+	tLength := len(stagedTables) + len(unstagedTables) + len(stagedDocDiffs.Docs) + len(unStagedDocDiffs.Docs) + len(workingTblsInConflict) + len(workingTblsWithSchConflicts) + len(workingDocsInConflict)
 
 	tables := make([]string, tLength)
 	isStaged := make([]bool, tLength)
@@ -112,6 +118,7 @@ func newStatusItr(ctx *sql.Context, st *StatusTable) (*StatusItr, error) {
 	idx := handleStagedUnstagedTables(stagedTables, unstagedTables, itr, 0)
 	idx = handleStagedUnstagedDocDiffs(stagedDocDiffs, unStagedDocDiffs, itr, idx)
 	idx = handleWorkingTablesInConflict(workingTblsInConflict, itr, idx)
+	idx = handleWorkingTablesWithSchemaConflicts(workingTblsWithSchConflicts, itr, idx)
 	idx = handleWorkingDocConflicts(workingDocsInConflict, itr, idx)
 
 	return itr, nil
@@ -183,8 +190,22 @@ func handleWorkingTablesInConflict(workingTables []string, itr *StatusItr, idx i
 	return idx
 }
 
-func handleWorkingDocConflicts(workingDocs *diff.DocDiffs, itr *StatusItr, idx int) int {
-	for _, docName := range workingDocs.Docs {
+const schemaConflictStatus = "schema conflict"
+
+func handleWorkingTablesWithSchemaConflicts(workingTables []string, itr *StatusItr, idx int) int {
+	for _, tableName := range workingTables {
+		itr.tables[idx] = tableName
+		itr.isStaged[idx] = false
+		itr.statuses[idx] = schemaConflictStatus
+
+		idx += 1
+	}
+
+	return idx
+}
+
+func handleWorkingDocConflicts(workingDocs []string, itr *StatusItr, idx int) int {
+	for _, docName := range workingDocs {
 		itr.tables[idx] = docName
 		itr.isStaged[idx] = false
 		itr.statuses[idx] = mergeConflictStatus
@@ -0,0 +1,79 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dolthub/go-mysql-server/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dolthub/dolt/go/libraries/doltcore/sqle"
+)
+
+func TestBlameTable(t *testing.T) {
+	dEnv := setupHistoryTests(t)
+
+	tests := []struct {
+		name  string
+		query string
+		rows  []sql.Row
+	}{
+		{
+			name:  "select pk, commit_hash from dolt_blame_test",
+			query: "select pk, commit_hash from dolt_blame_test",
+			rows: []sql.Row{
+				{int32(0), HEAD},
+				{int32(1), HEAD_2},
+				{int32(2), HEAD},
+				{int32(3), HEAD_1},
+			},
+		},
+		{
+			name:  "select pk, message from dolt_blame_test",
+			query: "select pk, message from dolt_blame_test where pk > 0",
+			rows: []sql.Row{
+				{int32(1), "second"},
+				{int32(2), "fourth"},
+				{int32(3), "third"},
+			},
+		},
+		{
+			name:  "join dolt_blame_test with test",
+			query: "select t.pk, t.c0, b.commit_hash from test t join dolt_blame_test b on t.pk = b.pk where t.c0 >= 10 order by t.pk",
+			rows: []sql.Row{
+				{int32(0), int32(10), HEAD},
+				{int32(2), int32(12), HEAD},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root, err := dEnv.WorkingRoot(context.Background())
+			require.NoError(t, err)
+
+			actRows, err := sqle.ExecuteSelect(dEnv, dEnv.DoltDB, root, test.query)
+			require.NoError(t, err)
+
+			require.Equal(t, len(test.rows), len(actRows))
+			for i := range test.rows {
+				assert.Equal(t, test.rows[i], actRows[i])
+			}
+		})
+	}
+}
@@ -125,6 +125,36 @@ func FmtForeignKey(fk doltdb.ForeignKey, sch, parentSch schema.Schema) string {
 	return sb.String()
 }
 
+// CreateTableStmt creates a sql create table statement for a table with the given name and schema. Foreign keys are not
+// included, as they are not part of the schema.
+func CreateTableStmt(tableName string, sch schema.Schema) string {
+	var defs []string
+	_ = sch.GetAllCols().Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
+		defs = append(defs, FmtCol(2, 0, 0, col))
+		return false, nil
+	})
+
+	if !schema.IsKeyless(sch) {
+		pkNames := sch.GetPKCols().GetColumnNames()
+		for i, name := range pkNames {
+			pkNames[i] = QuoteIdentifier(name)
+		}
+		defs = append(defs, fmt.Sprintf("  PRIMARY KEY (%s)", strings.Join(pkNames, ",")))
+	}
+
+	for _, idx := range sch.Indexes().AllIndexes() {
+		defs = append(defs, "  "+FmtIndex(idx))
+	}
+
+	var b strings.Builder
+	b.WriteString("CREATE TABLE ")
+	b.WriteString(QuoteIdentifier(tableName))
+	b.WriteString(" (\n")
+	b.WriteString(strings.Join(defs, ",\n"))
+	b.WriteString("\n);")
+	return b.String()
+}
+
 func DropTableStmt(tableName string) string {
 	var b strings.Builder
 	b.WriteString("DROP TABLE ")
@@ -68,3 +68,18 @@ func TestFmtCol(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateTableStmt(t *testing.T) {
+	sch, err := schema.SchemaFromCols(schema.NewColCollection(
+		schema.NewColumn("id", 0, types.IntKind, true),
+		schema.NewColumn("name", 1, types.StringKind, false),
+	))
+	assert.NoError(t, err)
+
+	expected := "CREATE TABLE `people` (\n" +
+		"  `id` BIGINT,\n" +
+		"  `name` LONGTEXT,\n" +
+		"  PRIMARY KEY (`id`)\n" +
+		");"
+	assert.Equal(t, expected, CreateTableStmt("people", sch))
+}
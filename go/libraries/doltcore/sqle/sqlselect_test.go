@@ -712,7 +712,7 @@ var BasicSelectTests = []SelectTest{
 		Query: "select * from dolt_log",
 		ExpectedRows: []sql.Row{
 			{
-				"1aqqa9u6kdaafn03p9dkskt444kvf69f",
+				"m8lrhp8bmfesmknc6d5iatmjbcjf17al",
 				"billy bob",
 				"bigbillieb@fake.horse",
 				time.Date(1970, 1, 1, 0, 0, 0, 0, &time.Location{}),
@@ -742,10 +742,11 @@ var BasicSelectTests = []SelectTest{
 		ExpectedRows: []sql.Row{
 			{
 				"master",
-				"1aqqa9u6kdaafn03p9dkskt444kvf69f",
+				"m8lrhp8bmfesmknc6d5iatmjbcjf17al",
 				"billy bob", "bigbillieb@fake.horse",
 				time.Date(1970, 1, 1, 0, 0, 0, 0, &time.Location{}),
 				"Initialize data repository",
+				nil, nil, nil,
 			},
 		},
 		ExpectedSqlSchema: sql.Schema{
@@ -755,6 +756,9 @@ var BasicSelectTests = []SelectTest{
 			&sql.Column{Name: "latest_committer_email", Type: sql.Text},
 			&sql.Column{Name: "latest_commit_date", Type: sql.Datetime},
 			&sql.Column{Name: "latest_commit_message", Type: sql.Text},
+			&sql.Column{Name: "upstream", Type: sql.Text},
+			&sql.Column{Name: "ahead", Type: sql.Int64},
+			&sql.Column{Name: "behind", Type: sql.Int64},
 		},
 	},
 }
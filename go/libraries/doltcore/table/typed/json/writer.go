@@ -75,7 +75,37 @@ func (jsonw *JSONWriter) GetSchema() schema.Schema {
 
 // WriteRow will write a row to a table
 func (jsonw *JSONWriter) WriteRow(ctx context.Context, r row.Row) error {
-	allCols := jsonw.sch.GetAllCols()
+	colValMap, err := RowToColValMap(r, jsonw.sch)
+	if err != nil {
+		return err
+	}
+
+	data, err := marshalToJson(colValMap)
+	if err != nil {
+		return errors.New("marshaling did not work")
+	}
+
+	if jsonw.rowsWritten != 0 {
+		_, err := jsonw.bWr.WriteRune(',')
+
+		if err != nil {
+			return err
+		}
+	}
+
+	newErr := iohelp.WriteAll(jsonw.bWr, data)
+	if newErr != nil {
+		return newErr
+	}
+	jsonw.rowsWritten++
+
+	return nil
+}
+
+// RowToColValMap returns a map from column name to a value for each non-null column of |r| that can be marshalled to
+// JSON. Types without a JSON primitive equivalent are formatted as strings.
+func RowToColValMap(r row.Row, sch schema.Schema) (map[string]interface{}, error) {
+	allCols := sch.GetAllCols()
 	colValMap := make(map[string]interface{}, allCols.Size())
 	err := allCols.Iter(func(tag uint64, col schema.Column) (stop bool, err error) {
 		val, ok := r.GetColVal(tag)
@@ -114,26 +144,11 @@ func (jsonw *JSONWriter) WriteRow(ctx context.Context, r row.Row) error {
 		return false, nil
 	})
 
-	data, err := marshalToJson(colValMap)
 	if err != nil {
-		return errors.New("marshaling did not work")
-	}
-
-	if jsonw.rowsWritten != 0 {
-		_, err := jsonw.bWr.WriteRune(',')
-
-		if err != nil {
-			return err
-		}
-	}
-
-	newErr := iohelp.WriteAll(jsonw.bWr, data)
-	if newErr != nil {
-		return newErr
+		return nil, err
 	}
-	jsonw.rowsWritten++
 
-	return nil
+	return colValMap, nil
 }
 
 // Close should flush all writes, release resources being held
@@ -147,6 +147,9 @@ type Database interface {
 
 	Flush(ctx context.Context) error
 
+	// SetEnforceCompleteness sets whether writing a value checks that every value it references is in the database.
+	SetEnforceCompleteness(enforce bool)
+
 	// chunkStore returns the ChunkStore used to read and write
 	// groups of values to the database efficiently. This interface is a low-
 	// level detail of the database that should infrequently be needed by
@@ -63,6 +63,9 @@ func makeProgTrack(progressCh chan PullProgress) func(moreDone, moreKnown, moreA
 	}
 }
 
+// Clone copies the table files of |srcDB| to |sinkDB|, and sets the root of |sinkDB| to the root of |srcDB|. Table
+// files which |sinkDB| already has are not copied again, so cloning to the same sink again only copies the table
+// files written to |srcDB| since.
 func Clone(ctx context.Context, srcDB, sinkDB Database, eventCh chan<- TableFileEvent) error {
 
 	srcCS := srcDB.chunkStore().(interface{})
@@ -135,6 +138,13 @@ func clone(ctx context.Context, srcTS, sinkTS nbs.TableFileStore, eventCh chan<-
 		return err
 	}
 
+	sinkRoot, sinkTblFiles, err := sinkTS.Sources(ctx)
+	if err != nil {
+		return err
+	}
+
+	tblFiles = missingTableFiles(tblFiles, sinkTblFiles)
+
 	report := func(e TableFileEvent) {
 		if eventCh != nil {
 			eventCh <- e
@@ -236,15 +246,31 @@ func clone(ctx context.Context, srcTS, sinkTS nbs.TableFileStore, eventCh chan<-
 		}
 	}
 
-	return sinkTS.SetRootChunk(ctx, root, hash.Hash{})
+	return sinkTS.SetRootChunk(ctx, root, sinkRoot)
+}
+
+// missingTableFiles returns the table files of |tblFiles| which are not in |sinkTblFiles|.
+func missingTableFiles(tblFiles, sinkTblFiles []nbs.TableFile) []nbs.TableFile {
+	_, sinkFileIDToTF := mapTableFiles(sinkTblFiles)
+
+	var missing []nbs.TableFile
+	for _, tblFile := range tblFiles {
+		if _, ok := sinkFileIDToTF[tblFile.FileID()]; !ok {
+			missing = append(missing, tblFile)
+		}
+	}
+
+	return missing
 }
 
 // Pull objects that descend from sourceRef from srcDB to sinkDB.
 func Pull(ctx context.Context, srcDB, sinkDB Database, sourceRef types.Ref, progressCh chan PullProgress) error {
-	return pull(ctx, srcDB, sinkDB, sourceRef, progressCh, defaultBatchSize)
+	return pull(ctx, srcDB, sinkDB, sourceRef, nil, progressCh, defaultBatchSize)
 }
 
-func pull(ctx context.Context, srcDB, sinkDB Database, sourceRef types.Ref, progressCh chan PullProgress, batchSize int) error {
+// pull pulls the chunks reachable from |sourceRef| from srcDB to sinkDB. The chunks |skip| are treated as if sinkDB
+// already had them, so that neither they nor the chunks which are only reachable through them are pulled.
+func pull(ctx context.Context, srcDB, sinkDB Database, sourceRef types.Ref, skip hash.HashSet, progressCh chan PullProgress, batchSize int) error {
 	// Sanity Check
 	exists, err := srcDB.chunkStore().Has(ctx, sourceRef.TargetHash())
 
@@ -302,7 +328,7 @@ func pull(ctx context.Context, srcDB, sinkDB Database, sourceRef types.Ref, prog
 			}
 		}
 
-		absent, err = nextLevelMissingChunks(ctx, sinkDB, nextLevel, absent, uniqueOrdered)
+		absent, err = nextLevelMissingChunks(ctx, sinkDB, nextLevel, absent, uniqueOrdered, skip)
 
 		if err != nil {
 			return err
@@ -344,7 +370,14 @@ func persistChunks(ctx context.Context, cs chunks.ChunkStore) error {
 // optimization problem down to the chunk store which can make smarter decisions.
 func PullWithoutBatching(ctx context.Context, srcDB, sinkDB Database, sourceRef types.Ref, progressCh chan PullProgress) error {
 	// by increasing the batch size to MaxInt32 we effectively remove batching here.
-	return pull(ctx, srcDB, sinkDB, sourceRef, progressCh, math.MaxInt32)
+	return pull(ctx, srcDB, sinkDB, sourceRef, nil, progressCh, math.MaxInt32)
+}
+
+// PullWithoutBatchingSkipping is like PullWithoutBatching, but treats the chunks |skip| as if sinkDB already had them,
+// so that neither they nor the chunks which are only reachable through them are pulled. It is used to pull a history
+// which is cut off at some commits.
+func PullWithoutBatchingSkipping(ctx context.Context, srcDB, sinkDB Database, sourceRef types.Ref, skip hash.HashSet, progressCh chan PullProgress) error {
+	return pull(ctx, srcDB, sinkDB, sourceRef, skip, progressCh, math.MaxInt32)
 }
 
 // concurrently pull all chunks from this batch that the sink is missing out of the source
@@ -399,7 +432,7 @@ func putChunks(ctx context.Context, sinkDB Database, hashes hash.HashSlice, need
 
 // ask sinkDB which of the next level's hashes it doesn't have, and add those chunks to the absent list which will need
 // to be retrieved.
-func nextLevelMissingChunks(ctx context.Context, sinkDB Database, nextLevel hash.HashSet, absent hash.HashSlice, uniqueOrdered hash.HashSlice) (hash.HashSlice, error) {
+func nextLevelMissingChunks(ctx context.Context, sinkDB Database, nextLevel hash.HashSet, absent hash.HashSlice, uniqueOrdered hash.HashSlice, skip hash.HashSet) (hash.HashSlice, error) {
 	missingFromSink, err := sinkDB.chunkStore().HasMany(ctx, nextLevel)
 
 	if err != nil {
@@ -408,7 +441,7 @@ func nextLevelMissingChunks(ctx context.Context, sinkDB Database, nextLevel hash
 
 	absent = absent[:0]
 	for _, h := range uniqueOrdered {
-		if missingFromSink.Has(h) {
+		if missingFromSink.Has(h) && !skip.Has(h) {
 			absent = append(absent, h)
 		}
 	}
@@ -338,6 +338,39 @@ func (suite *PullSuite) TestPullUpdates() {
 	suite.True(srcL.Equals(mustGetValue(v.(types.Struct).MaybeGet(ValueField))))
 }
 
+// Source: -5-> C2(L4) -1-> N
+//               .  \  -4-> L3 -1-> N
+//                .          \ -3-> L2 -1-> N
+//                 3                 \ -2-> L1 -1-> N
+//                  .                        \ -1-> L0
+//                 C1(L2) -1-> N
+//                     \  -2-> L1 -1-> N
+//                              \ -1-> L0
+//
+// Sink: Nada
+//
+// C1 is skipped, so only C2 and its value are pulled.
+func (suite *PullSuite) TestPullSkipping() {
+	ctx := context.Background()
+
+	srcL := buildListOfHeight(2, suite.source)
+	parentRef := suite.commitToSource(srcL, mustList(types.NewList(ctx, suite.source)))
+	srcL = buildListOfHeight(4, suite.source)
+	sourceRef := suite.commitToSource(srcL, mustList(types.NewList(ctx, suite.source, parentRef)))
+
+	err := PullWithoutBatchingSkipping(ctx, suite.source, suite.sink, sourceRef, hash.NewHashSet(parentRef.TargetHash()), nil)
+	suite.NoError(err)
+
+	v, err := suite.sink.ReadValue(ctx, sourceRef.TargetHash())
+	suite.NoError(err)
+	suite.NotNil(v)
+	suite.True(srcL.Equals(mustGetValue(v.(types.Struct).MaybeGet(ValueField))))
+
+	v, err = suite.sink.ReadValue(ctx, parentRef.TargetHash())
+	suite.NoError(err)
+	suite.Nil(v)
+}
+
 func (suite *PullSuite) commitToSource(v types.Value, p types.List) types.Ref {
 	ds, err := suite.source.GetDataset(context.Background(), datasetID)
 	suite.NoError(err)
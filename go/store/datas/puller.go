@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sync"
@@ -75,6 +76,8 @@ type Puller struct {
 	sinkDB        Database
 	rootChunkHash hash.Hash
 	downloaded    hash.HashSet
+	skip          hash.HashSet
+	resumedTFs    map[string]*resumedTableFile
 
 	wr          *nbs.CmpChunkTableWriter
 	tempDir     string
@@ -83,6 +86,12 @@ type Puller struct {
 	eventCh chan PullerEvent
 }
 
+// resumedTableFile is a table file which was downloaded by an earlier pull that did not complete.
+type resumedTableFile struct {
+	rd   *nbs.TableFileReader
+	used bool
+}
+
 type PullerEventType int
 
 const (
@@ -166,6 +175,12 @@ func NewPuller(ctx context.Context, tempDir string, chunksPerTF int, srcDB, sink
 		return nil, err
 	}
 
+	resumedTFs, err := openResumedTableFiles(tempDir)
+
+	if err != nil {
+		return nil, err
+	}
+
 	return &Puller{
 		fmt:           srcDB.Format(),
 		srcDB:         srcDB,
@@ -173,6 +188,8 @@ func NewPuller(ctx context.Context, tempDir string, chunksPerTF int, srcDB, sink
 		sinkDB:        sinkDB,
 		rootChunkHash: rootChunkHash,
 		downloaded:    hash.HashSet{},
+		skip:          hash.HashSet{},
+		resumedTFs:    resumedTFs,
 		tempDir:       tempDir,
 		wr:            wr,
 		chunksPerTF:   chunksPerTF,
@@ -180,6 +197,109 @@ func NewPuller(ctx context.Context, tempDir string, chunksPerTF int, srcDB, sink
 	}, nil
 }
 
+// openResumedTableFiles opens the table files in |tempDir| which were downloaded by earlier pulls that did not
+// complete, so that their chunks do not need to be downloaded again. A table file is only used if its content matches
+// its id, the others are deleted.
+func openResumedTableFiles(tempDir string) (map[string]*resumedTableFile, error) {
+	infos, err := ioutil.ReadDir(tempDir)
+
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	resumedTFs := make(map[string]*resumedTableFile)
+	for _, info := range infos {
+		if !info.Mode().IsRegular() {
+			continue
+		}
+
+		// the completed table files of a pull are named by their id
+		if _, ok := hash.MaybeParse(info.Name()); !ok {
+			continue
+		}
+
+		path := filepath.Join(tempDir, info.Name())
+		err := verifyTableFile(path, info.Size(), info.Name())
+
+		if err != nil {
+			_ = os.Remove(path)
+			continue
+		}
+
+		rd, err := nbs.OpenTableFile(path)
+
+		if err != nil {
+			continue
+		}
+
+		resumedTFs[path] = &resumedTableFile{rd: rd}
+	}
+
+	return resumedTFs, nil
+}
+
+func verifyTableFile(path string, size int64, fileId string) (err error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer CloseWithErr(f, &err)
+
+	return nbs.VerifyTableFile(f, size, fileId)
+}
+
+// closeResumedTableFiles closes the table files downloaded by earlier pulls. If the pull succeeded, the table files
+// which it used are deleted, as their chunks are now in the sink.
+func (p *Puller) closeResumedTableFiles(succeeded bool) {
+	for path, tf := range p.resumedTFs {
+		_ = tf.rd.Close()
+
+		if succeeded && tf.used {
+			_ = os.Remove(path)
+		}
+	}
+
+	p.resumedTFs = nil
+}
+
+// getResumedChunks reads the chunks of |batch| which the table files downloaded by earlier pulls have, instead of
+// downloading them again, and returns the chunks of |batch| which they do not have.
+func (p *Puller) getResumedChunks(batch hash.HashSet, found func(nbs.CompressedChunk)) (hash.HashSet, error) {
+	if len(p.resumedTFs) == 0 {
+		return batch, nil
+	}
+
+	remaining := make(hash.HashSet, len(batch))
+	for h := range batch {
+		ok := false
+		for _, tf := range p.resumedTFs {
+			var cmpChnk nbs.CompressedChunk
+			var err error
+			cmpChnk, ok, err = tf.rd.GetCompressed(h)
+
+			if err != nil {
+				return nil, err
+			}
+
+			if ok {
+				tf.used = true
+				found(cmpChnk)
+				break
+			}
+		}
+
+		if !ok {
+			remaining.Insert(h)
+		}
+	}
+
+	return remaining, nil
+}
+
 func (p *Puller) processCompletedTables(ctx context.Context, ae *atomicerr.AtomicError, completedTables <-chan FilledWriters) {
 	type tempTblFile struct {
 		id          string
@@ -262,6 +382,14 @@ func (p *Puller) processCompletedTables(ctx context.Context, ae *atomicerr.Atomi
 	}
 }
 
+// Skip makes the Puller treat the chunks |hashes| as if the sink already had them, so that neither they nor the chunks
+// which are only reachable through them are pulled. It is used to pull a history which is cut off at some commits.
+func (p *Puller) Skip(hashes hash.HashSet) {
+	for h := range hashes {
+		p.skip.Insert(h)
+	}
+}
+
 // Pull executes the sync operation
 func (p *Puller) Pull(ctx context.Context) error {
 	twDetails := &TreeWalkEventDetails{TreeLevel: -1}
@@ -282,6 +410,7 @@ func (p *Puller) Pull(ctx context.Context) error {
 
 	for len(absent) > 0 {
 		limitToNewChunks(absent, p.downloaded)
+		limitToNewChunks(absent, p.skip)
 
 		chunksInLevel := len(absent)
 		twDetails.ChunksInLevel = chunksInLevel
@@ -313,7 +442,11 @@ func (p *Puller) Pull(ctx context.Context) error {
 	close(completedTables)
 
 	wg.Wait()
-	return ae.Get()
+
+	err := ae.Get()
+	p.closeResumedTableFiles(err == nil)
+
+	return err
 }
 
 func limitToNewChunks(absent hash.HashSet, downloaded hash.HashSet) {
@@ -338,7 +471,13 @@ func (p *Puller) getCmp(ctx context.Context, twDetails *TreeWalkEventDetails, le
 	ae := atomicerr.New()
 	go func() {
 		defer close(found)
-		err := p.srcChunkStore.GetManyCompressed(ctx, batch, func(c nbs.CompressedChunk) { found <- c })
+		remaining, err := p.getResumedChunks(batch, func(c nbs.CompressedChunk) { found <- c })
+
+		if ae.SetIfError(err) || len(remaining) == 0 {
+			return
+		}
+
+		err = p.srcChunkStore.GetManyCompressed(ctx, remaining, func(c nbs.CompressedChunk) { found <- c })
 		ae.SetIfError(err)
 	}()
 
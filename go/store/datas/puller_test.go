@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sync"
@@ -27,6 +28,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/nbs"
 	"github.com/dolthub/dolt/go/store/types"
 	"github.com/dolthub/dolt/go/store/util/clienttest"
@@ -518,3 +520,125 @@ func writeValAndGetRef(ctx context.Context, vrw types.ValueReadWriter, val types
 
 	return valRef, err
 }
+
+// interruptedChunkStore fails to get compressed chunks after it got |limit| of them, and counts the chunks it got.
+type interruptedChunkStore struct {
+	*nbs.NomsBlockStore
+	limit int
+	count int
+}
+
+var errInterrupted = errors.New("interrupted")
+
+func (cs *interruptedChunkStore) GetManyCompressed(ctx context.Context, hashes hash.HashSet, found func(nbs.CompressedChunk)) error {
+	var mu sync.Mutex
+	err := cs.NomsBlockStore.GetManyCompressed(ctx, hashes, func(c nbs.CompressedChunk) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if cs.limit >= 0 && cs.count >= cs.limit {
+			return
+		}
+
+		cs.count++
+		found(c)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if cs.limit >= 0 && cs.count >= cs.limit {
+		return errInterrupted
+	}
+
+	return nil
+}
+
+func TestPullerResumes(t *testing.T) {
+	ctx := context.Background()
+
+	dir := filepath.Join(os.TempDir(), uuid.New().String())
+	err := os.MkdirAll(dir, os.ModePerm)
+	require.NoError(t, err)
+	st, err := nbs.NewLocalStore(ctx, types.Format_Default.VersionString(), dir, clienttest.DefaultMemTableSize)
+	require.NoError(t, err)
+	db := NewDatabase(st)
+
+	tbl, err := makeABigTable(ctx, db)
+	require.NoError(t, err)
+	tblRef, err := writeValAndGetRef(ctx, db, tbl)
+	require.NoError(t, err)
+	rootMap, err := types.NewMap(ctx, db, types.String("big_table"), tblRef)
+	require.NoError(t, err)
+	ds, err := db.GetDataset(ctx, "ds")
+	require.NoError(t, err)
+	ds, err = db.CommitValue(ctx, ds, rootMap)
+	require.NoError(t, err)
+	rootRef, ok, err := ds.MaybeHeadRef()
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	pull := func(srcCS *interruptedChunkStore, sinkDB Database, tmpDir string) error {
+		eventCh := make(chan PullerEvent, 128)
+		go func() {
+			for range eventCh {
+			}
+		}()
+		defer close(eventCh)
+
+		plr, err := NewPuller(ctx, tmpDir, 128, NewDatabase(srcCS), sinkDB, rootRef.TargetHash(), eventCh)
+		require.NoError(t, err)
+
+		return plr.Pull(ctx)
+	}
+
+	newTmpDir := func() string {
+		tmpDir := filepath.Join(os.TempDir(), uuid.New().String())
+		err := os.MkdirAll(tmpDir, os.ModePerm)
+		require.NoError(t, err)
+		return tmpDir
+	}
+
+	// a complete pull, to count the chunks which are pulled
+	full := &interruptedChunkStore{NomsBlockStore: st, limit: -1}
+	sinkDB, err := tempDirDB(ctx)
+	require.NoError(t, err)
+	err = pull(full, sinkDB, newTmpDir())
+	require.NoError(t, err)
+
+	sinkDB, err = tempDirDB(ctx)
+	require.NoError(t, err)
+	tmpDir := newTmpDir()
+
+	// a table file which does not match its id is not used
+	corruptPath := filepath.Join(tmpDir, hash.Of([]byte("corrupt")).String())
+	err = ioutil.WriteFile(corruptPath, []byte("corrupt"), os.ModePerm)
+	require.NoError(t, err)
+
+	interrupted := &interruptedChunkStore{NomsBlockStore: st, limit: full.count / 2}
+	err = pull(interrupted, sinkDB, tmpDir)
+	require.Equal(t, errInterrupted, err)
+
+	resumed := &interruptedChunkStore{NomsBlockStore: st, limit: -1}
+	err = pull(resumed, sinkDB, tmpDir)
+	require.NoError(t, err)
+
+	// the chunks which were pulled before the interruption are not pulled again
+	assert.Less(t, resumed.count, full.count)
+
+	_, err = os.Stat(corruptPath)
+	assert.True(t, os.IsNotExist(err))
+
+	sinkDS, err := sinkDB.GetDataset(ctx, "ds")
+	require.NoError(t, err)
+	sinkDS, err = sinkDB.FastForward(ctx, sinkDS, rootRef)
+	require.NoError(t, err)
+	sinkRootRef, ok, err := sinkDS.MaybeHeadRef()
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	eq, err := pullerRefEquality(ctx, rootRef, sinkRootRef, db, sinkDB)
+	require.NoError(t, err)
+	assert.True(t, eq)
+}
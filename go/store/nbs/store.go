@@ -42,6 +42,7 @@ import (
 	"github.com/dolthub/dolt/go/store/blobstore"
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/hash"
+	"github.com/dolthub/dolt/go/store/util/tempfiles"
 )
 
 var ErrFetchFailure = errors.New("fetch failed")
@@ -1107,48 +1108,76 @@ func (nbs *NomsBlockStore) SupportedOperations() TableFileStoreOps {
 
 // WriteTableFile will read a table file from the provided reader and write it to the TableFileStore
 func (nbs *NomsBlockStore) WriteTableFile(ctx context.Context, fileId string, numChunks int, rd io.Reader, contentLength uint64, contentHash []byte) error {
-	fsPersister, ok := nbs.p.(*fsTablePersister)
+	fileIdHash, ok := hash.MaybeParse(fileId)
 
 	if !ok {
-		return errors.New("Not implemented")
+		return errors.New("invalid base32 encoded hash: " + fileId)
 	}
 
-	path := filepath.Join(fsPersister.dir, fileId)
+	var err error
+	switch p := nbs.p.(type) {
+	case *fsTablePersister:
+		err = writeTableFileToDir(p.dir, fileId, rd)
+	case *awsTablePersister:
+		var data []byte
+		data, err = ioutil.ReadAll(rd)
 
-	err := func() (err error) {
-		var f *os.File
-		f, err = os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, os.ModePerm)
+		if err == nil {
+			err = p.multipartUpload(ctx, data, fileId)
+		}
+	case *blobstorePersister:
+		_, err = p.bs.Put(ctx, fileId, rd)
+	default:
+		err = errors.New("Not implemented")
+	}
 
-		if err != nil {
-			return err
+	if err != nil {
+		return err
+	}
+
+	_, err = nbs.UpdateManifest(ctx, map[hash.Hash]uint32{fileIdHash: uint32(numChunks)})
+
+	return err
+}
+
+// writeTableFileToDir writes the table file |fileId| read from |rd| to |dir|. The file is written to a temp file first,
+// and only moved into place once it is verified, so that an interrupted or corrupt download never ends up in |dir|
+// under the name of a table file.
+func writeTableFileToDir(dir, fileId string, rd io.Reader) error {
+	tempName, err := func() (tempName string, ferr error) {
+		var temp *os.File
+		temp, ferr = tempfiles.MovableTempFileProvider.NewFile(dir, tempTablePrefix)
+
+		if ferr != nil {
+			return "", ferr
 		}
 
 		defer func() {
-			closeErr := f.Close()
+			closeErr := temp.Close()
 
-			if err == nil {
-				err = closeErr
+			if ferr == nil {
+				ferr = closeErr
 			}
 		}()
 
-		_, err = io.Copy(f, rd)
+		size, ferr := io.Copy(temp, rd)
 
-		return err
+		if ferr != nil {
+			return temp.Name(), ferr
+		}
+
+		return temp.Name(), VerifyTableFile(temp, size, fileId)
 	}()
 
 	if err != nil {
-		return err
-	}
-
-	fileIdHash, ok := hash.MaybeParse(fileId)
+		if tempName != "" {
+			_ = os.Remove(tempName)
+		}
 
-	if !ok {
-		return errors.New("invalid base32 encoded hash: " + fileId)
+		return err
 	}
 
-	_, err = nbs.UpdateManifest(ctx, map[hash.Hash]uint32{fileIdHash: uint32(numChunks)})
-
-	return err
+	return os.Rename(tempName, filepath.Join(dir, fileId))
 }
 
 // PruneTableFiles deletes old table files that are no longer referenced in the manifest.
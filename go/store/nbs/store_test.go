@@ -17,11 +17,13 @@ package nbs
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -31,6 +33,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/dolthub/dolt/go/libraries/utils/set"
+	"github.com/dolthub/dolt/go/store/blobstore"
 	"github.com/dolthub/dolt/go/store/chunks"
 	"github.com/dolthub/dolt/go/store/hash"
 	"github.com/dolthub/dolt/go/store/types"
@@ -107,6 +110,76 @@ func TestNBSAsTableFileStore(t *testing.T) {
 	require.Greater(t, size, uint64(0))
 }
 
+func TestNBSWriteTableFileVerifiesContent(t *testing.T) {
+	ctx := context.Background()
+	st, nomsDir := makeTestLocalStore(t, defaultMaxTables)
+
+	data, addr, err := buildTable([][]byte{[]byte("hello"), []byte("world")})
+	require.NoError(t, err)
+
+	_, other, err := buildTable([][]byte{[]byte("goodbye")})
+	require.NoError(t, err)
+
+	corrupt := make([]byte, len(data))
+	copy(corrupt, data)
+	corrupt[0] ^= 0xff
+
+	err = st.WriteTableFile(ctx, other.String(), 2, bytes.NewReader(data), 0, nil)
+	assert.True(t, errors.Is(err, ErrInvalidTableFile))
+
+	err = st.WriteTableFile(ctx, addr.String(), 2, bytes.NewReader(corrupt), 0, nil)
+	assert.True(t, errors.Is(err, ErrInvalidTableFile))
+
+	err = st.WriteTableFile(ctx, addr.String(), 2, bytes.NewReader(data[:len(data)-1]), 0, nil)
+	assert.Error(t, err)
+
+	_, sources, err := st.Sources(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, sources)
+
+	// neither the rejected table files nor their temp files are left behind
+	infos, err := ioutil.ReadDir(nomsDir)
+	require.NoError(t, err)
+	for _, info := range infos {
+		assert.False(t, strings.HasPrefix(info.Name(), tempTablePrefix))
+		assert.NotEqual(t, addr.String(), info.Name())
+		assert.NotEqual(t, other.String(), info.Name())
+	}
+
+	err = st.WriteTableFile(ctx, addr.String(), 2, bytes.NewReader(data), 0, nil)
+	require.NoError(t, err)
+
+	_, sources, err = st.Sources(ctx)
+	require.NoError(t, err)
+	require.Len(t, sources, 1)
+	assert.Equal(t, addr.String(), sources[0].FileID())
+}
+
+func TestNBSWriteTableFileToBlobstore(t *testing.T) {
+	ctx := context.Background()
+
+	st, err := NewBSStore(ctx, types.Format_Default.VersionString(), blobstore.NewInMemoryBlobstore(), defaultMemTableSize)
+	require.NoError(t, err)
+
+	numTableFiles := 8
+	fileToData := populateLocalStore(t, st, numTableFiles)
+
+	_, sources, err := st.Sources(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, numTableFiles, len(sources))
+
+	for _, src := range sources {
+		rd, err := src.Open(ctx)
+		require.NoError(t, err)
+
+		data, err := ioutil.ReadAll(rd)
+		require.NoError(t, err)
+		require.NoError(t, rd.Close())
+
+		assert.Equal(t, fileToData[src.FileID()], data)
+	}
+}
+
 type tableFileSet map[string]TableFile
 
 func (s tableFileSet) contains(fileName string) (ok bool) {
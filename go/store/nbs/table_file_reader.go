@@ -0,0 +1,194 @@
+// Copyright 2021 Dolthub, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nbs
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dolthub/dolt/go/store/hash"
+)
+
+// TableFileReader reads the compressed chunks of a single table file on disk, which is not part of any store, such
+// as a table file which was downloaded by an earlier pull that did not complete.
+type TableFileReader struct {
+	f     *os.File
+	index onHeapTableIndex
+}
+
+// OpenTableFile opens the table file at |path| and reads its index.
+func OpenTableFile(path string) (*TableFileReader, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := f.Stat()
+
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	index, err := readTableIndex(f, fi.Size())
+
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	return &TableFileReader{f, index}, nil
+}
+
+// Has returns true if the table file has the chunk |h|.
+func (r *TableFileReader) Has(h hash.Hash) bool {
+	_, ok := r.index.Lookup((*addr)(&h))
+	return ok
+}
+
+// GetCompressed returns the compressed chunk |h| of the table file. The returned bool is false if the table file does
+// not have it.
+func (r *TableFileReader) GetCompressed(h hash.Hash) (CompressedChunk, bool, error) {
+	e, ok := r.index.Lookup((*addr)(&h))
+
+	if !ok {
+		return CompressedChunk{}, false, nil
+	}
+
+	data := make([]byte, e.Length())
+	_, err := r.f.ReadAt(data, int64(e.Offset()))
+
+	if err != nil {
+		return CompressedChunk{}, false, err
+	}
+
+	cmp, err := NewCompressedChunk(h, data)
+
+	if err != nil {
+		return CompressedChunk{}, false, err
+	}
+
+	return cmp, true, nil
+}
+
+// Close closes the table file.
+func (r *TableFileReader) Close() error {
+	return r.f.Close()
+}
+
+// VerifyTableFile checks that the table file read from |rd|, which is |size| bytes long, is intact and is the table
+// file |fileId|. The id of a table file is the hash of the addresses of its chunks, so the addresses in the index are
+// checked against the id, and the data of every chunk is checked against its address.
+func VerifyTableFile(rd io.ReaderAt, size int64, fileId string) error {
+	index, err := readTableIndex(rd, size)
+
+	if err != nil {
+		return err
+	}
+
+	if name := nameFromSuffixes(index.suffixes); name.String() != fileId {
+		return fmt.Errorf("%w: table file %s has the content of table file %s", ErrInvalidTableFile, fileId, name.String())
+	}
+
+	for i := uint32(0); i < index.chunkCount; i++ {
+		var a addr
+		e := index.IndexEntry(i, &a)
+
+		data := make([]byte, e.Length())
+		_, err = rd.ReadAt(data, int64(e.Offset()))
+
+		if err != nil {
+			return err
+		}
+
+		cmp, err := NewCompressedChunk(hash.Hash(a), data)
+
+		if err != nil {
+			return fmt.Errorf("%w: chunk %s of table file %s: %s", ErrInvalidTableFile, a.String(), fileId, err.Error())
+		}
+
+		chk, err := cmp.ToChunk()
+
+		if err != nil {
+			return fmt.Errorf("%w: chunk %s of table file %s: %s", ErrInvalidTableFile, a.String(), fileId, err.Error())
+		}
+
+		if computeAddr(chk.Data()) != a {
+			return fmt.Errorf("%w: chunk %s of table file %s does not match its address", ErrInvalidTableFile, a.String(), fileId)
+		}
+	}
+
+	return nil
+}
+
+// readTableIndex reads the index of the table file read from |rd|, which is |size| bytes long, and checks that it
+// describes a table file of that size.
+func readTableIndex(rd io.ReaderAt, size int64) (onHeapTableIndex, error) {
+	if size < footerSize {
+		return onHeapTableIndex{}, ErrInvalidTableFile
+	}
+
+	footer := make([]byte, footerSize)
+	_, err := rd.ReadAt(footer, size-footerSize)
+
+	if err != nil {
+		return onHeapTableIndex{}, err
+	}
+
+	chunkCount := binary.BigEndian.Uint32(footer)
+
+	if chunkCount == 0 {
+		if size != footerSize || string(footer[footerSize-magicNumberSize:]) != magicNumber {
+			return onHeapTableIndex{}, ErrInvalidTableFile
+		}
+
+		return onHeapTableIndex{}, nil
+	}
+
+	idxSize := int64(indexSize(chunkCount)) + footerSize
+
+	if idxSize > size {
+		return onHeapTableIndex{}, ErrInvalidTableFile
+	}
+
+	buff := make([]byte, idxSize)
+	_, err = rd.ReadAt(buff, size-idxSize)
+
+	if err != nil {
+		return onHeapTableIndex{}, err
+	}
+
+	index, err := parseTableIndex(buff)
+
+	if err != nil {
+		return onHeapTableIndex{}, err
+	}
+
+	if index.TableFileSize() != uint64(size) {
+		return onHeapTableIndex{}, ErrInvalidTableFile
+	}
+
+	// every chunk holds at least its checksum
+	for i := uint32(0); i < chunkCount; i++ {
+		if index.lengths[i] < checksumSize {
+			return onHeapTableIndex{}, ErrInvalidTableFile
+		}
+	}
+
+	return index, nil
+}
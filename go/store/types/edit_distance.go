@@ -90,6 +90,13 @@ func addSplice(splices []Splice, s Splice) []Splice {
 	return splices
 }
 
+// CalcSplices computes the splices that transform a sequence of |previousLength| elements into a sequence of
+// |currentLength| elements. |eqFn| is called with an index into each sequence and reports whether the elements at
+// those indexes are equal.
+func CalcSplices(previousLength uint64, currentLength uint64, maxSpliceMatrixSize uint64, eqFn EditDistanceEqualsFn) ([]Splice, error) {
+	return calcSplices(previousLength, currentLength, maxSpliceMatrixSize, eqFn)
+}
+
 func calcSplices(previousLength uint64, currentLength uint64, maxSpliceMatrixSize uint64, eqFn EditDistanceEqualsFn) ([]Splice, error) {
 	minLength := uint64Min(previousLength, currentLength)
 	prefixCount, err := sharedPrefix(eqFn, minLength)
@@ -15,19 +15,14 @@
 package main
 
 import (
-	"context"
+	"crypto/tls"
 	"flag"
 	"fmt"
 	"log"
-	"net"
-	"net/http"
 	"os"
 	"os/signal"
-	"sync"
 
-	"google.golang.org/grpc"
-
-	remotesapi "github.com/dolthub/dolt/go/gen/proto/dolt/services/remotesapi/v1alpha1"
+	"github.com/dolthub/dolt/go/libraries/doltcore/remotesrv"
 	"github.com/dolthub/dolt/go/libraries/utils/filesys"
 )
 
@@ -35,8 +30,41 @@ func main() {
 	dirParam := flag.String("dir", "", "root directory that this command will run in.")
 	grpcPortParam := flag.Int("grpc-port", -1, "root directory that this command will run in.")
 	httpPortParam := flag.Int("http-port", -1, "root directory that this command will run in.")
+	authFileParam := flag.String("auth-file", "", "json file of the users allowed to access the server, and their permissions.")
+	tlsCertParam := flag.String("tls-cert", "", "PEM encoded certificate chain used to serve grpc and http over TLS.")
+	tlsKeyParam := flag.String("tls-key", "", "PEM encoded private key of the certificate in tls-cert.")
 	flag.Parse()
 
+	if (len(*tlsCertParam) > 0) != (len(*tlsKeyParam) > 0) {
+		log.Fatalln("'tls-cert' and 'tls-key' must be provided together")
+	}
+
+	var tlsConfig *tls.Config
+	if len(*tlsCertParam) > 0 {
+		var err error
+		tlsConfig, err = remotesrv.LoadTLSConfig(*tlsCertParam, *tlsKeyParam)
+
+		if err != nil {
+			log.Fatalln("failed to load TLS certificate:", err.Error())
+		}
+
+		log.Println("TLS enabled using " + *tlsCertParam)
+	}
+
+	var auth *remotesrv.Authenticator
+	if len(*authFileParam) > 0 {
+		var err error
+		auth, err = remotesrv.LoadAuthFile(*authFileParam)
+
+		if err != nil {
+			log.Fatalln("failed to load auth file:", err.Error())
+		}
+
+		log.Println("authentication enabled using " + *authFileParam)
+	} else {
+		log.Println("'auth-file' parameter not provided. Any client can read and write any repository.")
+	}
+
 	if dirParam != nil && len(*dirParam) > 0 {
 		err := os.Chdir(*dirParam)
 
@@ -65,82 +93,31 @@ func main() {
 		log.Println("'grpc-port' parameter not provided. Using default port 50051")
 	}
 
-	stopChan, wg := startServer(httpHost, *httpPortParam, *grpcPortParam)
-	waitForSignal()
-
-	close(stopChan)
-	wg.Wait()
-}
-
-func waitForSignal() {
-	c := make(chan os.Signal)
-	signal.Notify(c, os.Interrupt)
-	signal.Notify(c, os.Kill)
-
-	<-c
-}
-
-func startServer(httpHost string, httpPort, grpcPort int) (chan interface{}, *sync.WaitGroup) {
-	wg := sync.WaitGroup{}
-	stopChan := make(chan interface{})
-
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		httpServer(httpPort, stopChan)
-	}()
-
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		grpcServer(httpHost, grpcPort, stopChan)
-	}()
-
-	return stopChan, &wg
-}
-
-func grpcServer(httpHost string, grpcPort int, stopChan chan interface{}) {
-	defer func() {
-		log.Println("exiting grpc Server go routine")
-	}()
-
-	dbCache := NewLocalCSCache(filesys.LocalFS)
-	chnkSt := NewHttpFSBackedChunkStore(httpHost, dbCache)
+	server, err := remotesrv.NewServer(remotesrv.ServerArgs{
+		HttpHost:  httpHost,
+		HttpPort:  *httpPortParam,
+		GrpcPort:  *grpcPortParam,
+		DBCache:   remotesrv.NewLocalCSCache(filesys.LocalFS),
+		Auth:      auth,
+		TLSConfig: tlsConfig,
+	})
 
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", grpcPort))
 	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+		log.Fatalln("failed to start server:", err.Error())
 	}
 
-	grpcServer := grpc.NewServer(grpc.MaxRecvMsgSize(128 * 1024 * 1024))
 	go func() {
-		remotesapi.RegisterChunkStoreServiceServer(grpcServer, chnkSt)
-
-		log.Println("Starting grpc server on port", grpcPort)
-		err := grpcServer.Serve(lis)
-		log.Println("grpc server exited. error:", err)
+		waitForSignal()
+		server.GracefulStop()
 	}()
 
-	<-stopChan
-	grpcServer.GracefulStop()
+	server.Serve()
 }
 
-func httpServer(httpPort int, stopChan chan interface{}) {
-	defer func() {
-		log.Println("exiting http Server go routine")
-	}()
-
-	server := http.Server{
-		Addr:    fmt.Sprintf(":%d", httpPort),
-		Handler: http.HandlerFunc(ServeHTTP),
-	}
-
-	go func() {
-		log.Println("Starting http server on port ", httpPort)
-		err := server.ListenAndServe()
-		log.Println("http server exited. exit error:", err)
-	}()
+func waitForSignal() {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt)
+	signal.Notify(c, os.Kill)
 
-	<-stopChan
-	server.Shutdown(context.Background())
+	<-c
 }